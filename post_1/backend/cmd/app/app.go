@@ -2,32 +2,50 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
 	"personal_website/config"
 	"personal_website/internal/app/core/ports"
+	"personal_website/internal/app/core/services/analytics"
+	"personal_website/internal/app/core/services/antispam"
+	"personal_website/internal/app/core/services/contentsync"
 	"personal_website/internal/app/core/services/mailer"
+	"personal_website/internal/app/core/services/newsletter"
+	"personal_website/internal/app/core/services/preview"
 	"personal_website/internal/app/core/services/registration"
 	"personal_website/internal/infrastructure/adapters/email_sender"
+	"personal_website/internal/infrastructure/adapters/gitsource"
+	"personal_website/internal/infrastructure/adapters/ratelimit"
 	datastore_adapter "personal_website/internal/infrastructure/adapters/repository/datastore"
 	postgres_adapter "personal_website/internal/infrastructure/adapters/repository/postgres"
 	valkey_adapter "personal_website/internal/infrastructure/adapters/repository/valkey"
 	"personal_website/internal/infrastructure/adapters/resume"
 	"personal_website/internal/infrastructure/http"
+	"personal_website/pkg/clientip"
+	"personal_website/pkg/health"
 	"personal_website/pkg/telemetry"
 	"personal_website/pkg/utils"
+	"strconv"
 	"syscall"
 
 	_ "github.com/lib/pq"
 )
 
-func StartApp(cfg *config.Config) {
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+func StartApp(live *config.Live) {
+	cfg := live.Config()
+	logger := slog.New(telemetry.NewLogHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: live.LogLevel()})))
 
 	logger.Info("Initializing telemetry...")
-	telemetryInstance, err := telemetry.NewTelemetry(logger)
+	telemetryInstance, err := telemetry.NewTelemetry(logger, telemetry.TracingOptions{
+		Exporter:    cfg.App.Tracing.Exporter,
+		Endpoint:    cfg.App.Tracing.Endpoint,
+		Insecure:    cfg.App.Tracing.Insecure,
+		SampleRatio: cfg.App.Tracing.SampleRatio,
+		Version:     cfg.App.Version,
+	})
 	if err != nil {
 		logger.Error("Failed to initialize telemetry", "error", err)
 		os.Exit(1)
@@ -42,6 +60,25 @@ func StartApp(cfg *config.Config) {
 	}
 	defer pgDatabase.Close()
 
+	if cfg.Postgres.AutoMigrate {
+		logger.Info("Applying database migrations...")
+		version, err := pgDatabase.Migrate(context.Background())
+		if err != nil {
+			logger.Error("Failed to migrate the database", "error", err)
+			os.Exit(1)
+		}
+		logger.Info("Database schema up to date", "version", version)
+	}
+
+	// An older binary may not work with a newer schema, e.g. during a rollback
+	schemaVersion, err := pgDatabase.SchemaVersion(context.Background())
+	if errors.Is(err, postgres_adapter.ErrSchemaBehind) {
+		logger.Warn("Database migrations are pending, run the migrate up command or set postgres.auto_migrate", "error", err)
+	} else if err != nil {
+		logger.Error("Refusing to start with this database schema", "error", err)
+		os.Exit(1)
+	}
+
 	logger.Info("Initializing valkey connection...")
 	vkDatabase, err := valkey_adapter.NewDatabase(&cfg.Valkey)
 	if err != nil {
@@ -50,21 +87,36 @@ func StartApp(cfg *config.Config) {
 	}
 	defer vkDatabase.Close()
 
-	resumeService, err := resume.NewService(&cfg.Minio)
+	datastore := datastore_adapter.NewDatastore(pgDatabase, vkDatabase, &cfg.App.Cache)
+
+	resumeService, err := resume.NewService(&cfg.Minio, datastore.ResumeRepo())
 	if err != nil {
 		logger.Error("Failed to initialize resume service", "error", err)
 		os.Exit(1)
 	}
 
-	datastore := datastore_adapter.NewDatastore(pgDatabase, vkDatabase)
+	emailSender := email_sender.NewEmailSender()
+
+	healthChecks := health.NewRegistry(cfg.App.Health.CacheTTL)
+	registerHealthChecks(healthChecks, cfg, pgDatabase, vkDatabase, resumeService, emailSender)
+	healthChecks.SetInfo("schema_version", strconv.FormatUint(uint64(schemaVersion), 10))
+
+	var contentSource ports.ContentSource
+	if cfg.Sync.RepoPath != "" {
+		logger.Info("Content sync enabled", "repo_path", cfg.Sync.RepoPath, "ref", cfg.Sync.Ref)
+		contentSource = gitsource.NewGitSource(&cfg.Sync)
+	}
 
 	server, err := NewServer(ServerDeps{
 		Logger:        logger,
 		Config:        cfg,
 		Datastore:     datastore,
-		EmailSender:   email_sender.NewEmailSender(),
+		EmailSender:   emailSender,
 		ResumeService: resumeService,
+		ContentSource: contentSource,
 		Telemetry:     telemetryInstance,
+		HealthChecks:  healthChecks,
+		Live:          live,
 	})
 	if err != nil {
 		logger.Error("Error when initializing server", "error", err.Error())
@@ -72,11 +124,14 @@ func StartApp(cfg *config.Config) {
 	}
 
 	// Initialize metrics server
-	metricsServer := http.NewMetricsServer(logger, cfg)
+	metricsServer := http.NewMetricsServer(logger, cfg, healthChecks, telemetryInstance)
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	// Reload the reloadable settings on SIGHUP or when the config file changes
+	go live.Watch(ctx, logger)
+
 	// Start main server
 	go func() {
 		logger.Info("Starting main server...")
@@ -117,7 +172,42 @@ type ServerDeps struct {
 	Datastore     ports.Datastore
 	EmailSender   ports.EmailSender
 	ResumeService ports.ResumeService
+	// ContentSource defaults to none, the content sync is then disabled
+	ContentSource ports.ContentSource
 	Telemetry     *telemetry.Telemetry
+	// HealthChecks defaults to an empty registry, always ready
+	HealthChecks *health.Registry
+	// Live defaults to Config, never reloaded
+	Live *config.Live
+}
+
+// registerHealthChecks registers the dependencies checked by the readiness
+// probe. The API cannot work without Postgres and Valkey, while MinIO and SMTP
+// only break the resume download and the emails. A schema migrated by another
+// replica only degrades the instances still running the previous binary.
+func registerHealthChecks(registry *health.Registry, cfg *config.Config, pgDatabase ports.PostgresDatabase, vkDatabase ports.ValkeyDatabase, resumeService ports.ResumeService, emailSender *email_sender.EmailSender) {
+	timeout := cfg.App.Health.CheckTimeout
+
+	registry.Register(health.Check{Name: "postgres", Checker: pgDatabase.Ping, Timeout: timeout, Critical: true})
+	registry.Register(health.Check{Name: "valkey", Checker: vkDatabase.Ping, Timeout: timeout, Critical: true})
+	registry.Register(health.Check{Name: "minio", Checker: resumeService.CheckConnection, Timeout: timeout})
+	registry.Register(health.Check{
+		Name: "schema",
+		Checker: func(ctx context.Context) error {
+			_, err := pgDatabase.SchemaVersion(ctx)
+			return err
+		},
+		Timeout: timeout,
+	})
+
+	if cfg.SMTP.Host != nil && cfg.SMTP.Port != nil {
+		smtpAddr := cfg.SMTP.Host.String() + ":" + cfg.SMTP.Port.String()
+		registry.Register(health.Check{
+			Name:    "smtp",
+			Checker: func(ctx context.Context) error { return emailSender.Ping(ctx, smtpAddr) },
+			Timeout: timeout,
+		})
+	}
 }
 
 func NewServer(deps ServerDeps) (*http.Server, error) {
@@ -130,13 +220,76 @@ func NewServer(deps ServerDeps) (*http.Server, error) {
 
 	userService := registration.NewUserService(emailService, deps.Datastore)
 
+	if deps.Config.Contact.ChallengeSecret == nil {
+		deps.Logger.Warn("No contact challenge secret configured, using a random one: challenges will not be valid across instances or restarts")
+	}
+
+	contactGuard, err := antispam.NewContactGuard(&deps.Config.Contact, deps.Datastore.QuotaRepo())
+	if err != nil {
+		return nil, fmt.Errorf("error when initializing contact guard: %w", err)
+	}
+
+	if deps.Config.Newsletter.UnsubscribeSecret == nil {
+		deps.Logger.Warn("No newsletter unsubscribe secret configured, using a random one: unsubscribe links will break on restart")
+	}
+
+	newsletterService, err := newsletter.NewNewsletterService(&deps.Config.Newsletter, emailService, deps.Datastore, deps.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("error when initializing newsletter service: %w", err)
+	}
+
+	analyticsService := analytics.NewAnalyticsService(&deps.Config.Analytics, deps.Datastore, deps.Logger)
+
+	syncService := contentsync.NewSyncService(deps.ContentSource, deps.Datastore, deps.Logger)
+
+	if deps.Config.Preview.Secret == nil {
+		deps.Logger.Warn("No preview secret configured, using a random one: preview links will break on restart")
+	}
+
+	previewService, err := preview.NewPreviewService(&deps.Config.Preview, deps.Datastore)
+	if err != nil {
+		return nil, fmt.Errorf("error when initializing preview service: %w", err)
+	}
+
+	var rateLimiter ports.RateLimiter = ratelimit.NewMemoryLimiter()
+	switch deps.Config.App.Limiter.Backend {
+	case "", "memory":
+	case "valkey":
+		rateLimiter = ratelimit.NewFallbackLimiter(deps.Datastore.RateLimiter(), rateLimiter, deps.Logger)
+	default:
+		return nil, fmt.Errorf("unknown rate limiter backend %q", deps.Config.App.Limiter.Backend)
+	}
+
+	clientIPResolver, err := clientip.NewResolver(deps.Config.App.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("error when initializing client IP resolver: %w", err)
+	}
+
+	healthChecks := deps.HealthChecks
+	if healthChecks == nil {
+		healthChecks = health.NewRegistry(deps.Config.App.Health.CacheTTL)
+	}
+
+	live := deps.Live
+	if live == nil {
+		live = config.NewLive(deps.Config, config.Options{})
+	}
+
 	server := http.NewServer(
 		deps.Logger,
-		deps.Config,
+		live,
 		deps.Datastore,
 		deps.ResumeService,
 		emailService,
 		userService,
+		contactGuard,
+		newsletterService,
+		analyticsService,
+		syncService,
+		previewService,
+		rateLimiter,
+		clientIPResolver,
+		healthChecks,
 		errorReponder,
 		deps.Telemetry,
 	)
@@ -1,11 +1,56 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
 	"personal_website/cmd/app"
 	"personal_website/config"
+	"personal_website/internal/infrastructure/cli"
+
+	"github.com/awnumar/memguard"
 )
 
 func main() {
-	cfg := config.InitConfig()
-	app.StartApp(&cfg)
+	memguard.CatchInterrupt()
+
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] == "serve" {
+		args = args[1:]
+	} else if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		code := cli.Run(ctx, args, os.Stdin, os.Stdout, os.Stderr)
+		stop()
+		memguard.SafeExit(code)
+	}
+
+	serve(args)
+}
+
+// serve starts the API server, it is the command run without a command name.
+func serve(args []string) {
+	cfg, opts, err := config.Load(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		memguard.SafeExit(2)
+	}
+
+	if opts.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			memguard.SafeExit(1)
+		}
+		memguard.SafeExit(0)
+	}
+
+	app.StartApp(config.NewLive(&cfg, opts))
 }
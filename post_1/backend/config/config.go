@@ -1,10 +1,10 @@
 package config
 
 import (
-	"flag"
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
+	"runtime/debug"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +26,8 @@ type PostgresConfig struct {
 	RetryDelay     time.Duration
 	MaxRetryDelay  time.Duration
 	ConnectTimeout time.Duration
+	// AutoMigrate applies the migrations embedded in the binary at startup
+	AutoMigrate bool
 }
 
 func (p *PostgresConfig) DSN() string {
@@ -49,6 +51,77 @@ type LimiterConfig struct {
 	Rps     int
 	Burst   int
 	Enabled bool
+	// Backend is "valkey" to share the limits between instances, or "memory"
+	Backend string
+	// Policies are the stricter limits attached by name to sensitive routes
+	Policies map[string]RateLimitPolicy
+}
+
+type RateLimitPolicy struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+// DefaultRateLimitPolicies limits the endpoints worth brute forcing or
+// spamming, and the writes of the authenticated users. RATE_LIMIT_POLICIES
+// overrides them one by one.
+func DefaultRateLimitPolicies() map[string]RateLimitPolicy {
+	return map[string]RateLimitPolicy{
+		"login":        {Requests: 5, Period: time.Minute, Burst: 5},
+		"registration": {Requests: 3, Period: time.Hour, Burst: 3},
+		"activation":   {Requests: 10, Period: time.Hour, Burst: 5},
+		"contact":      {Requests: 5, Period: time.Hour, Burst: 3},
+		"newsletter":   {Requests: 5, Period: time.Hour, Burst: 3},
+		"write":        {Requests: 60, Period: time.Minute, Burst: 30},
+	}
+}
+
+// ParseRateLimitPolicies reads policies written as name=requests/period or
+// name=requests/period:burst, separated by commas, e.g.
+// "login=10/1m,contact=5/1h:2". The burst defaults to the number of requests.
+func ParseRateLimitPolicies(value string) (map[string]RateLimitPolicy, error) {
+	policies := make(map[string]RateLimitPolicy)
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, spec, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid rate limit policy %q: expected name=requests/period[:burst]", entry)
+		}
+
+		spec, burstValue, hasBurst := strings.Cut(spec, ":")
+		requestsValue, periodValue, ok := strings.Cut(spec, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit policy %q: expected name=requests/period[:burst]", entry)
+		}
+
+		requests, err := strconv.Atoi(requestsValue)
+		if err != nil || requests <= 0 {
+			return nil, fmt.Errorf("invalid rate limit policy %q: requests must be a positive integer", entry)
+		}
+
+		period, err := time.ParseDuration(periodValue)
+		if err != nil || period <= 0 {
+			return nil, fmt.Errorf("invalid rate limit policy %q: period must be a positive duration", entry)
+		}
+
+		burst := requests
+		if hasBurst {
+			burst, err = strconv.Atoi(burstValue)
+			if err != nil || burst <= 0 {
+				return nil, fmt.Errorf("invalid rate limit policy %q: burst must be a positive integer", entry)
+			}
+		}
+
+		policies[strings.TrimSpace(name)] = RateLimitPolicy{Requests: requests, Period: period, Burst: burst}
+	}
+
+	return policies, nil
 }
 
 type SMTPConfig struct {
@@ -57,6 +130,95 @@ type SMTPConfig struct {
 	Host      *memguard.LockedBuffer
 	Port      *memguard.LockedBuffer
 	Recipient *memguard.LockedBuffer
+	// TemplateDir optionally points to a directory whose templates override
+	// the embedded ones (e.g. activation.html, activation.fr.html).
+	TemplateDir   string
+	DefaultLocale string
+}
+
+type ContactConfig struct {
+	// ChallengeSecret signs the proof-of-work challenges. When unset a random
+	// secret is generated at startup, which only works with a single instance.
+	ChallengeSecret     *memguard.LockedBuffer
+	ChallengeDifficulty int
+	ChallengeTTL        time.Duration
+	MinFillTime         time.Duration
+	MaxLinks            int
+	BlockedKeywords     []string
+	EmailQuota          int
+	IPQuota             int
+	QuotaWindow         time.Duration
+}
+
+type NewsletterConfig struct {
+	ConfirmationURL string
+	UnsubscribeURL  string
+	// ArticleURL is the base URL of the articles, the slug is appended to it.
+	ArticleURL string
+	// UnsubscribeSecret signs the unsubscribe links. When unset a random secret
+	// is generated at startup and links sent before a restart stop working.
+	UnsubscribeSecret *memguard.LockedBuffer
+	TokenTTL          time.Duration
+	// ResendCooldown is the minimum delay between two confirmation emails sent
+	// to the same address, 0 disables it.
+	ResendCooldown time.Duration
+	BatchSize      int
+	BatchInterval  time.Duration
+}
+
+type AnalyticsConfig struct {
+	// FlushInterval is how often the view counters kept in Valkey are added to
+	// the daily totals stored in Postgres, 0 leaves the flushes to the other
+	// instances
+	FlushInterval time.Duration
+}
+
+type SyncConfig struct {
+	// RepoPath is the git repository, working tree or bare, the articles are
+	// synced from. The sync is disabled when it is empty.
+	RepoPath string
+	// Ref is the revision synced, e.g. a branch or a tag
+	Ref string
+}
+
+type PreviewConfig struct {
+	// Secret signs the preview links. When unset a random secret is generated
+	// at startup and the links shared before a restart stop working.
+	Secret *memguard.LockedBuffer
+	// TTL is how long a preview link gives access to its article
+	TTL time.Duration
+}
+
+type HealthConfig struct {
+	// CacheTTL is how long a health report is reused before the dependencies
+	// are checked again
+	CacheTTL     time.Duration
+	CheckTimeout time.Duration
+}
+
+type CacheConfig struct {
+	// MaxAge is how long the browsers and proxies reuse a public article
+	// response without revalidating it
+	MaxAge time.Duration
+	// StaleWhileRevalidate is how long a stale response may still be served
+	// while it is revalidated in the background
+	StaleWhileRevalidate time.Duration
+	// TTL bounds how long a response is kept in the shared Valkey cache, which
+	// is also cleared by every change of the articles
+	TTL time.Duration
+	// Articles caches the published articles read from Postgres in Valkey,
+	// for ArticlesTTL at most. It is only read at startup.
+	Articles    bool
+	ArticlesTTL time.Duration
+}
+
+type TracingConfig struct {
+	// Exporter is "otlp" to send the spans to an OpenTelemetry collector, or
+	// "none" to only propagate and log the trace IDs
+	Exporter    string
+	Endpoint    string
+	Insecure    bool
+	SampleRatio float64
 }
 
 type CORSConfig struct {
@@ -78,104 +240,121 @@ type AppConfig struct {
 	MetricsPort     int
 	Limiter         LimiterConfig
 	Cors            CORSConfig
+	Health          HealthConfig
+	Cache           CacheConfig
+	Tracing         TracingConfig
+	TrustedProxies  []string
 	ShutdownTimeout time.Duration
 	ActivationUrl   string
+	LogLevel        slog.Level
+	Features        []string
 }
 
 type Config struct {
-	Postgres PostgresConfig
-	Valkey   ValkeyConfig
-	SMTP     SMTPConfig
-	Minio    MinioConfig
-	App      AppConfig
-}
-
-func readSecret(filename string) *memguard.LockedBuffer {
-	filepath := "/run/secrets/" + filename
-	if _, err := os.Stat(filepath); os.IsNotExist(err) {
-		// Try case insensitive environment variable lookup
-		envVar := getEnvCaseInsensitive(filename)
-		if envVar != "" {
-			return memguard.NewBufferFromBytes([]byte(envVar))
-		}
-		log.Fatalf("Secret %s not found and no %s environment variable set", filepath, filename)
-	}
-
-	data, err := os.ReadFile(filepath)
-	if err != nil {
-		log.Fatalf("Failed to read secret %s: %v", filepath, err)
-	}
-	trimmed := strings.TrimSpace(string(data))
-
-	return memguard.NewBufferFromBytes([]byte(trimmed))
+	Postgres   PostgresConfig
+	Valkey     ValkeyConfig
+	SMTP       SMTPConfig
+	Contact    ContactConfig
+	Newsletter NewsletterConfig
+	Analytics  AnalyticsConfig
+	Sync       SyncConfig
+	Preview    PreviewConfig
+	Minio      MinioConfig
+	App        AppConfig
 }
 
-func getEnvCaseInsensitive(key string) string {
-	// Try original key first
-	if val := os.Getenv(key); val != "" {
-		return val
-	}
-
-	// Try uppercase
-	if val := os.Getenv(strings.ToUpper(key)); val != "" {
-		return val
+// Defaults returns the configuration used for every setting that is not set
+// by the configuration file, the environment or the flags. The secrets have
+// no default.
+func Defaults() Config {
+	return Config{
+		Postgres: PostgresConfig{
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: 5 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+			MaxRetries:      5,
+			RetryDelay:      time.Second,
+			MaxRetryDelay:   30 * time.Second,
+			ConnectTimeout:  10 * time.Second,
+		},
+		SMTP: SMTPConfig{
+			DefaultLocale: "en",
+		},
+		Contact: ContactConfig{
+			ChallengeDifficulty: 18,
+			ChallengeTTL:        time.Hour,
+			MinFillTime:         3 * time.Second,
+			MaxLinks:            2,
+			EmailQuota:          3,
+			IPQuota:             5,
+			QuotaWindow:         time.Hour,
+		},
+		Newsletter: NewsletterConfig{
+			TokenTTL:       48 * time.Hour,
+			ResendCooldown: 10 * time.Minute,
+			BatchSize:      50,
+			BatchInterval:  time.Minute,
+		},
+		Analytics: AnalyticsConfig{
+			FlushInterval: time.Minute,
+		},
+		Sync: SyncConfig{
+			Ref: "HEAD",
+		},
+		Preview: PreviewConfig{
+			TTL: 7 * 24 * time.Hour,
+		},
+		App: AppConfig{
+			Environment: "development",
+			Version:     defaultVersion(),
+			Port:        5000,
+			MetricsPort: 2112,
+			Limiter: LimiterConfig{
+				Rps:      500,
+				Burst:    20,
+				Enabled:  true,
+				Backend:  "valkey",
+				Policies: DefaultRateLimitPolicies(),
+			},
+			Health: HealthConfig{
+				CacheTTL:     5 * time.Second,
+				CheckTimeout: 2 * time.Second,
+			},
+			Cache: CacheConfig{
+				MaxAge:               time.Minute,
+				StaleWhileRevalidate: 5 * time.Minute,
+				TTL:                  10 * time.Minute,
+				ArticlesTTL:          10 * time.Minute,
+			},
+			Tracing: TracingConfig{
+				Exporter:    "none",
+				SampleRatio: 1,
+			},
+			ShutdownTimeout: 30 * time.Second,
+			LogLevel:        slog.LevelInfo,
+		},
 	}
-
-	// Try lowercase
-	if val := os.Getenv(strings.ToLower(key)); val != "" {
-		return val
-	}
-
-	return ""
 }
 
-func InitConfig() Config {
-	var config Config
+// version is set when building, e.g.
+// go build -ldflags "-X personal_website/config.version=1.4.0" ./cmd
+var version string
 
-	memguard.CatchInterrupt()
-
-	flag.StringVar(&config.App.Environment, "env", os.Getenv("ENVIRONMENT"), "Environment (development|staging|production)")
-	flag.IntVar(&config.App.Port, "port", 5000, "API server port")
-	flag.IntVar(&config.App.MetricsPort, "metrics-port", 2112, "Metrics server port")
-	flag.IntVar(&config.App.Limiter.Rps, "rate-limiter", 500, "Rate limiter")
-	flag.IntVar(&config.App.Limiter.Burst, "rate-limiter-burst", 20, "Rate limiter burst")
-	flag.BoolVar(&config.App.Limiter.Enabled, "rate-limiter-enabled", true, "Enable rate limiter")
-	flag.StringVar(&config.App.ActivationUrl, "activation-url", "", "User activation base url")
-	flag.Parse()
+// defaultVersion is the version set when building, or the VCS revision the
+// binary was built from, or "dev".
+func defaultVersion() string {
+	if version != "" {
+		return version
+	}
 
-	if corsOrigins := getEnvCaseInsensitive("CORS_TRUSTED_ORIGINS"); corsOrigins != "" {
-		config.App.Cors.TrustedOrigins = strings.Fields(corsOrigins)
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" && setting.Value != "" {
+				return setting.Value[:min(len(setting.Value), 12)]
+			}
+		}
 	}
 
-	config.Postgres.User = readSecret("db_user")
-	config.Postgres.Password = readSecret("db_password")
-	config.Postgres.Database = readSecret("db_database_name")
-	config.Postgres.Host = readSecret("db_host")
-	config.Postgres.Port = readSecret("db_port")
-	config.Postgres.MaxOpenConns = 25
-	config.Postgres.MaxIdleConns = 25
-	config.Postgres.ConnMaxLifetime = 5 * time.Minute
-	config.Postgres.ConnMaxIdleTime = 5 * time.Minute
-	config.Postgres.MaxRetries = 5
-	config.Postgres.RetryDelay = 1 * time.Second
-	config.Postgres.MaxRetryDelay = 30 * time.Second
-	config.Postgres.ConnectTimeout = 10 * time.Second
-
-	config.Valkey.Host = readSecret("valkey_host")
-	config.Valkey.Port = readSecret("valkey_port")
-	config.Valkey.Password = readSecret("valkey_password")
-
-	config.SMTP.Username = readSecret("smtp_username")
-	config.SMTP.Password = readSecret("smtp_password")
-	config.SMTP.Host = readSecret("smtp_host")
-	config.SMTP.Port = readSecret("smtp_port")
-	config.SMTP.Recipient = readSecret("smtp_recipient")
-
-	config.Minio.Endpoint = readSecret("minio_endpoint")
-	config.Minio.AccessKey = readSecret("minio_access_key")
-	config.Minio.SecretKey = readSecret("minio_secret_key")
-	config.Minio.Bucket = readSecret("minio_bucket")
-	config.Minio.UseSSL = getEnvCaseInsensitive("MINIO_USE_SSL") == "true"
-
-	return config
+	return "dev"
 }
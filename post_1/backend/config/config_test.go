@@ -0,0 +1,36 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimitPolicies(t *testing.T) {
+	policies, err := ParseRateLimitPolicies("login=10/1m, contact=5/1h:2,")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]RateLimitPolicy{
+		"login":   {Requests: 10, Period: time.Minute, Burst: 10},
+		"contact": {Requests: 5, Period: time.Hour, Burst: 2},
+	}, policies)
+}
+
+func TestParseRateLimitPolicies_Invalid(t *testing.T) {
+	for _, value := range []string{
+		"login",
+		"=10/1m",
+		"login=10",
+		"login=ten/1m",
+		"login=0/1m",
+		"login=10/soon",
+		"login=10/1m:0",
+	} {
+		t.Run(value, func(t *testing.T) {
+			_, err := ParseRateLimitPolicies(value)
+			assert.Error(t, err)
+		})
+	}
+}
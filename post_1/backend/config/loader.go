@@ -0,0 +1,507 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultSecretsDir = "/run/secrets"
+
+// Options are the command line options that are not settings.
+type Options struct {
+	// ConfigFile is the YAML or TOML configuration file, set by -config or
+	// CONFIG_FILE
+	ConfigFile string
+	// PrintConfig asks to print the effective configuration and exit
+	PrintConfig bool
+
+	// The sources, kept to load the configuration again on reload
+	args       []string
+	lookupEnv  func(string) (string, bool)
+	secretsDir string
+}
+
+// setting binds a field of the configuration to its key in the configuration
+// file, its environment variable and its flag.
+type setting struct {
+	key   string
+	env   string
+	flag  string
+	usage string
+	value flag.Value
+	// secret settings are read from /run/secrets/<name> and the environment
+	// only, and are redacted when printed
+	secret   string
+	required bool
+}
+
+// flagArg is a flag given on the command line, applied after the
+// configuration file and the environment.
+type flagArg struct {
+	setting *setting
+	value   string
+}
+
+// flagValue records the flags of a setting instead of setting it right away.
+type flagValue struct {
+	setting *setting
+	args    *[]flagArg
+}
+
+func (v flagValue) String() string {
+	if v.setting == nil {
+		return ""
+	}
+	return v.setting.value.String()
+}
+
+func (v flagValue) Set(s string) error {
+	*v.args = append(*v.args, flagArg{setting: v.setting, value: s})
+	return nil
+}
+
+func (v flagValue) IsBoolFlag() bool {
+	if v.setting == nil {
+		return false
+	}
+	_, ok := v.setting.value.(boolValue)
+	return ok
+}
+
+type loader struct {
+	settings   []*setting
+	byKey      map[string]*setting
+	lookupEnv  func(string) (string, bool)
+	secretsDir string
+}
+
+// Load builds the configuration from, by increasing precedence:
+//
+//  1. the defaults, see Defaults
+//  2. the configuration file given by -config or CONFIG_FILE, in YAML or TOML
+//  3. the secret files in /run/secrets, for the secrets only
+//  4. the environment variables, where a secret can also be read from the file
+//     named by <NAME>_FILE, which wins over <NAME>
+//  5. the command line flags, which are not available for the secrets
+//
+// The configuration is then validated. The returned error lists every problem
+// found rather than stopping at the first one.
+func Load(args []string) (Config, Options, error) {
+	return load(args, os.LookupEnv, defaultSecretsDir)
+}
+
+func load(args []string, lookupEnv func(string) (string, bool), secretsDir string) (Config, Options, error) {
+	cfg := Defaults()
+	l := newLoader(&cfg, lookupEnv, secretsDir)
+
+	opts, flagArgs, err := l.parseFlags(args)
+	if err != nil {
+		return Config{}, opts, err
+	}
+	opts.args = args
+	opts.lookupEnv = lookupEnv
+	opts.secretsDir = secretsDir
+
+	var errs []error
+	if opts.ConfigFile != "" {
+		errs = append(errs, l.applyFile(opts.ConfigFile)...)
+	}
+	errs = append(errs, l.applySecretFiles()...)
+	errs = append(errs, l.applyEnv()...)
+	for _, arg := range flagArgs {
+		if err := arg.setting.value.Set(arg.value); err != nil {
+			errs = append(errs, fmt.Errorf("-%s: %w", arg.setting.flag, err))
+		}
+	}
+
+	errs = append(errs, l.missingSecrets()...)
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return Config{}, opts, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return cfg, opts, nil
+}
+
+func newLoader(cfg *Config, lookupEnv func(string) (string, bool), secretsDir string) *loader {
+	l := &loader{
+		byKey:      make(map[string]*setting),
+		lookupEnv:  lookupEnv,
+		secretsDir: secretsDir,
+	}
+	l.register(cfg)
+	return l
+}
+
+func (l *loader) add(s *setting) {
+	if s.env == "" {
+		if s.secret != "" {
+			s.env = strings.ToUpper(s.secret)
+		} else {
+			s.env = strings.ToUpper(strings.ReplaceAll(s.flag, "-", "_"))
+		}
+	}
+	l.settings = append(l.settings, s)
+	l.byKey[s.key] = s
+}
+
+// register declares every setting. Keep the file keys, environment variables
+// and flags of the existing settings stable, deployments depend on them.
+func (l *loader) register(cfg *Config) {
+	app := &cfg.App
+	l.add(&setting{key: "app.environment", flag: "env", env: "ENVIRONMENT", value: stringValue{&app.Environment}, usage: "Environment (development|staging|production|test)"})
+	l.add(&setting{key: "app.version", flag: "app-version", value: stringValue{&app.Version}, usage: "Version reported by the health checks and the traces"})
+	l.add(&setting{key: "app.port", flag: "port", value: intValue{&app.Port}, usage: "API server port"})
+	l.add(&setting{key: "app.metrics_port", flag: "metrics-port", value: intValue{&app.MetricsPort}, usage: "Metrics server port"})
+	l.add(&setting{key: "app.shutdown_timeout", flag: "shutdown-timeout", value: durationValue{&app.ShutdownTimeout}, usage: "Time given to the requests in flight to complete on shutdown"})
+	l.add(&setting{key: "app.activation_url", flag: "activation-url", value: stringValue{&app.ActivationUrl}, usage: "User activation base url"})
+	l.add(&setting{key: "app.log_level", flag: "log-level", value: levelValue{&app.LogLevel}, usage: "Minimum level of the logs (debug|info|warn|error)"})
+	l.add(&setting{key: "app.features", flag: "features", value: listValue{dst: &app.Features, comma: true}, usage: "Comma separated feature flags to enable, listed by /health for the frontend"})
+	l.add(&setting{key: "app.trusted_proxies", flag: "trusted-proxies", value: listValue{dst: &app.TrustedProxies}, usage: "Space separated CIDRs of the proxies trusted to set the client IP"})
+	l.add(&setting{key: "app.cors.trusted_origins", flag: "cors-trusted-origins", value: listValue{dst: &app.Cors.TrustedOrigins}, usage: "Space separated origins allowed by CORS"})
+	l.add(&setting{key: "app.limiter.rps", flag: "rate-limiter", value: intValue{&app.Limiter.Rps}, usage: "Rate limiter"})
+	l.add(&setting{key: "app.limiter.burst", flag: "rate-limiter-burst", value: intValue{&app.Limiter.Burst}, usage: "Rate limiter burst"})
+	l.add(&setting{key: "app.limiter.enabled", flag: "rate-limiter-enabled", value: boolValue{&app.Limiter.Enabled}, usage: "Enable rate limiter"})
+	l.add(&setting{key: "app.limiter.backend", flag: "rate-limiter-backend", value: stringValue{&app.Limiter.Backend}, usage: "Rate limiter storage (valkey|memory)"})
+	l.add(&setting{key: "app.limiter.policies", flag: "rate-limit-policies", value: policiesValue{&app.Limiter.Policies}, usage: "Rate limit policies overridden one by one, e.g. login=10/1m,contact=5/1h:2"})
+	l.add(&setting{key: "app.health.cache_ttl", flag: "health-cache-ttl", value: durationValue{&app.Health.CacheTTL}, usage: "Duration a health report is cached"})
+	l.add(&setting{key: "app.health.check_timeout", flag: "health-check-timeout", value: durationValue{&app.Health.CheckTimeout}, usage: "Timeout of each dependency health check"})
+	l.add(&setting{key: "app.cache.max_age", flag: "cache-max-age", value: durationValue{&app.Cache.MaxAge}, usage: "Duration the public article responses are reused by browsers and proxies"})
+	l.add(&setting{key: "app.cache.stale_while_revalidate", flag: "cache-stale-while-revalidate", value: durationValue{&app.Cache.StaleWhileRevalidate}, usage: "Duration a stale article response may be served while it is revalidated"})
+	l.add(&setting{key: "app.cache.ttl", flag: "cache-ttl", value: durationValue{&app.Cache.TTL}, usage: "Maximum duration an article response is kept in the shared Valkey cache"})
+	l.add(&setting{key: "app.cache.articles", flag: "cache-articles", value: boolValue{&app.Cache.Articles}, usage: "Cache the published articles read from Postgres in Valkey"})
+	l.add(&setting{key: "app.cache.articles_ttl", flag: "cache-articles-ttl", value: durationValue{&app.Cache.ArticlesTTL}, usage: "Maximum duration a published article is kept in the Valkey cache"})
+	l.add(&setting{key: "app.tracing.exporter", flag: "tracing-exporter", value: stringValue{&app.Tracing.Exporter}, usage: "Tracing exporter (otlp|none)"})
+	l.add(&setting{key: "app.tracing.endpoint", flag: "tracing-endpoint", env: "OTEL_EXPORTER_OTLP_ENDPOINT", value: stringValue{&app.Tracing.Endpoint}, usage: "OTLP/HTTP collector endpoint (host:port)"})
+	l.add(&setting{key: "app.tracing.insecure", flag: "tracing-insecure", value: boolValue{&app.Tracing.Insecure}, usage: "Send spans to the collector over plain HTTP"})
+	l.add(&setting{key: "app.tracing.sample_ratio", flag: "tracing-sample-ratio", value: floatValue{&app.Tracing.SampleRatio}, usage: "Ratio of the traces started by this service that are sampled"})
+
+	pg := &cfg.Postgres
+	l.add(&setting{key: "postgres.user", secret: "db_user", required: true, value: secretValue{&pg.User}})
+	l.add(&setting{key: "postgres.password", secret: "db_password", required: true, value: secretValue{&pg.Password}})
+	l.add(&setting{key: "postgres.database", secret: "db_database_name", required: true, value: secretValue{&pg.Database}})
+	l.add(&setting{key: "postgres.host", secret: "db_host", required: true, value: secretValue{&pg.Host}})
+	l.add(&setting{key: "postgres.port", secret: "db_port", required: true, value: secretValue{&pg.Port}})
+	l.add(&setting{key: "postgres.max_open_conns", flag: "db-max-open-conns", value: intValue{&pg.MaxOpenConns}, usage: "Maximum number of open Postgres connections"})
+	l.add(&setting{key: "postgres.max_idle_conns", flag: "db-max-idle-conns", value: intValue{&pg.MaxIdleConns}, usage: "Maximum number of idle Postgres connections"})
+	l.add(&setting{key: "postgres.conn_max_lifetime", flag: "db-conn-max-lifetime", value: durationValue{&pg.ConnMaxLifetime}, usage: "Maximum lifetime of a Postgres connection"})
+	l.add(&setting{key: "postgres.conn_max_idle_time", flag: "db-conn-max-idle-time", value: durationValue{&pg.ConnMaxIdleTime}, usage: "Maximum idle time of a Postgres connection"})
+	l.add(&setting{key: "postgres.max_retries", flag: "db-max-retries", value: intValue{&pg.MaxRetries}, usage: "Attempts to connect to Postgres at startup"})
+	l.add(&setting{key: "postgres.retry_delay", flag: "db-retry-delay", value: durationValue{&pg.RetryDelay}, usage: "Delay before the first connection retry, doubled after each attempt"})
+	l.add(&setting{key: "postgres.max_retry_delay", flag: "db-max-retry-delay", value: durationValue{&pg.MaxRetryDelay}, usage: "Maximum delay between two connection retries"})
+	l.add(&setting{key: "postgres.connect_timeout", flag: "db-connect-timeout", value: durationValue{&pg.ConnectTimeout}, usage: "Timeout of a connection attempt"})
+	l.add(&setting{key: "postgres.auto_migrate", flag: "db-auto-migrate", value: boolValue{&pg.AutoMigrate}, usage: "Apply the pending database migrations at startup"})
+
+	l.add(&setting{key: "valkey.host", secret: "valkey_host", required: true, value: secretValue{&cfg.Valkey.Host}})
+	l.add(&setting{key: "valkey.port", secret: "valkey_port", required: true, value: secretValue{&cfg.Valkey.Port}})
+	l.add(&setting{key: "valkey.password", secret: "valkey_password", value: secretValue{&cfg.Valkey.Password}})
+
+	smtp := &cfg.SMTP
+	l.add(&setting{key: "smtp.username", secret: "smtp_username", required: true, value: secretValue{&smtp.Username}})
+	l.add(&setting{key: "smtp.password", secret: "smtp_password", required: true, value: secretValue{&smtp.Password}})
+	l.add(&setting{key: "smtp.host", secret: "smtp_host", required: true, value: secretValue{&smtp.Host}})
+	l.add(&setting{key: "smtp.port", secret: "smtp_port", required: true, value: secretValue{&smtp.Port}})
+	l.add(&setting{key: "smtp.recipient", secret: "smtp_recipient", required: true, value: secretValue{&smtp.Recipient}})
+	l.add(&setting{key: "smtp.template_dir", flag: "email-template-dir", value: stringValue{&smtp.TemplateDir}, usage: "Directory with email templates overriding the embedded ones"})
+	l.add(&setting{key: "smtp.default_locale", flag: "email-default-locale", value: stringValue{&smtp.DefaultLocale}, usage: "Locale used for emails when no user preference matches"})
+
+	contact := &cfg.Contact
+	l.add(&setting{key: "contact.challenge_secret", secret: "contact_challenge_secret", value: secretValue{&contact.ChallengeSecret}})
+	l.add(&setting{key: "contact.challenge_difficulty", flag: "contact-challenge-difficulty", value: intValue{&contact.ChallengeDifficulty}, usage: "Number of leading zero bits required by the contact proof-of-work"})
+	l.add(&setting{key: "contact.challenge_ttl", flag: "contact-challenge-ttl", value: durationValue{&contact.ChallengeTTL}, usage: "Validity of a contact challenge"})
+	l.add(&setting{key: "contact.min_fill_time", flag: "contact-min-fill-time", value: durationValue{&contact.MinFillTime}, usage: "Minimum time between challenge issuance and submission"})
+	l.add(&setting{key: "contact.max_links", flag: "contact-max-links", value: intValue{&contact.MaxLinks}, usage: "Maximum number of links allowed in a contact message"})
+	l.add(&setting{key: "contact.blocked_keywords", flag: "contact-blocked-keywords", value: listValue{dst: &contact.BlockedKeywords, comma: true}, usage: "Comma separated keywords rejected in contact messages"})
+	l.add(&setting{key: "contact.email_quota", flag: "contact-email-quota", value: intValue{&contact.EmailQuota}, usage: "Contact messages allowed per sender email and quota window"})
+	l.add(&setting{key: "contact.ip_quota", flag: "contact-ip-quota", value: intValue{&contact.IPQuota}, usage: "Contact messages allowed per client IP and quota window"})
+	l.add(&setting{key: "contact.quota_window", flag: "contact-quota-window", value: durationValue{&contact.QuotaWindow}, usage: "Contact quota window"})
+
+	newsletter := &cfg.Newsletter
+	l.add(&setting{key: "newsletter.confirmation_url", flag: "newsletter-confirmation-url", value: stringValue{&newsletter.ConfirmationURL}, usage: "Newsletter subscription confirmation base url"})
+	l.add(&setting{key: "newsletter.unsubscribe_url", flag: "newsletter-unsubscribe-url", value: stringValue{&newsletter.UnsubscribeURL}, usage: "Newsletter unsubscribe base url"})
+	l.add(&setting{key: "newsletter.article_url", flag: "newsletter-article-url", value: stringValue{&newsletter.ArticleURL}, usage: "Base url of the articles linked in newsletter emails"})
+	l.add(&setting{key: "newsletter.unsubscribe_secret", secret: "newsletter_unsubscribe_secret", value: secretValue{&newsletter.UnsubscribeSecret}})
+	l.add(&setting{key: "newsletter.token_ttl", flag: "newsletter-token-ttl", value: durationValue{&newsletter.TokenTTL}, usage: "Validity of a newsletter confirmation token"})
+	l.add(&setting{key: "newsletter.resend_cooldown", flag: "newsletter-resend-cooldown", value: durationValue{&newsletter.ResendCooldown}, usage: "Minimum delay between two confirmation emails sent to an address, 0 disables it"})
+	l.add(&setting{key: "newsletter.batch_size", flag: "newsletter-batch-size", value: intValue{&newsletter.BatchSize}, usage: "Number of newsletter emails sent per batch"})
+	l.add(&setting{key: "newsletter.batch_interval", flag: "newsletter-batch-interval", value: durationValue{&newsletter.BatchInterval}, usage: "Pause between two newsletter batches, 0 sends them without pausing"})
+
+	l.add(&setting{key: "analytics.flush_interval", flag: "analytics-flush-interval", value: durationValue{&cfg.Analytics.FlushInterval}, usage: "Interval between two flushes of the article view counters to Postgres, 0 disables the flushes on this instance"})
+
+	l.add(&setting{key: "sync.repo_path", flag: "sync-repo-path", value: stringValue{&cfg.Sync.RepoPath}, usage: "Git repository of Markdown articles to sync, sync disabled when empty"})
+	l.add(&setting{key: "sync.ref", flag: "sync-ref", value: stringValue{&cfg.Sync.Ref}, usage: "Revision of the git repository synced"})
+
+	l.add(&setting{key: "preview.secret", secret: "preview_secret", value: secretValue{&cfg.Preview.Secret}})
+	l.add(&setting{key: "preview.ttl", flag: "preview-ttl", value: durationValue{&cfg.Preview.TTL}, usage: "Validity of an article preview link"})
+
+	minio := &cfg.Minio
+	l.add(&setting{key: "minio.endpoint", secret: "minio_endpoint", required: true, value: secretValue{&minio.Endpoint}})
+	l.add(&setting{key: "minio.access_key", secret: "minio_access_key", required: true, value: secretValue{&minio.AccessKey}})
+	l.add(&setting{key: "minio.secret_key", secret: "minio_secret_key", required: true, value: secretValue{&minio.SecretKey}})
+	l.add(&setting{key: "minio.bucket", secret: "minio_bucket", required: true, value: secretValue{&minio.Bucket}})
+	l.add(&setting{key: "minio.use_ssl", flag: "minio-use-ssl", value: boolValue{&minio.UseSSL}, usage: "Connect to MinIO over TLS"})
+}
+
+// parseFlags reads the options and records the flags of the settings, which
+// are applied last.
+func (l *loader) parseFlags(args []string) (Options, []flagArg, error) {
+	var opts Options
+	var flagArgs []flagArg
+
+	configFile, _ := l.lookupEnv("CONFIG_FILE")
+
+	flags := flag.NewFlagSet("api", flag.ContinueOnError)
+	flags.StringVar(&opts.ConfigFile, "config", configFile, "YAML or TOML configuration file")
+	flags.BoolVar(&opts.PrintConfig, "print-config", false, "Print the effective configuration, secrets redacted, and exit")
+	for _, s := range l.settings {
+		if s.flag != "" {
+			flags.Var(flagValue{setting: s, args: &flagArgs}, s.flag, fmt.Sprintf("%s (env %s)", s.usage, s.env))
+		}
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return opts, nil, err
+	}
+	if flags.NArg() > 0 {
+		return opts, nil, fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+
+	return opts, flagArgs, nil
+}
+
+// applyFile sets the settings of the configuration file, its format depends
+// on its extension.
+func (l *loader) applyFile(path string) []error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []error{fmt.Errorf("config file: %w", err)}
+	}
+
+	values := make(map[string]any)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	default:
+		err = fmt.Errorf("unsupported extension %q, expected .yaml, .yml or .toml", ext)
+	}
+	if err != nil {
+		return []error{fmt.Errorf("config file %s: %w", path, err)}
+	}
+
+	var errs []error
+	l.applyFileValues("", values, &errs)
+	return errs
+}
+
+func (l *loader) applyFileValues(prefix string, values map[string]any, errs *[]error) {
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		key := prefix + name
+		value := values[name]
+
+		s, ok := l.byKey[key]
+		if !ok {
+			if nested, isMap := value.(map[string]any); isMap {
+				l.applyFileValues(key+".", nested, errs)
+			} else {
+				*errs = append(*errs, fmt.Errorf("config file: %s: unknown setting", key))
+			}
+			continue
+		}
+
+		if err := setFileValue(s, value); err != nil {
+			*errs = append(*errs, fmt.Errorf("config file: %s: %w", key, err))
+		}
+	}
+}
+
+func setFileValue(s *setting, value any) error {
+	switch value := value.(type) {
+	case []any:
+		list, ok := s.value.(listValue)
+		if !ok {
+			return errors.New("must not be a list")
+		}
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			items = append(items, fmt.Sprint(item))
+		}
+		list.SetList(items)
+		return nil
+	case map[string]any:
+		policies, ok := s.value.(policiesValue)
+		if !ok {
+			return errors.New("must not be a table")
+		}
+		entries := make(map[string]string, len(value))
+		for name, spec := range value {
+			entries[name] = fmt.Sprint(spec)
+		}
+		return policies.SetMap(entries)
+	case nil:
+		return nil
+	case string:
+		// A printed configuration can be used as a file, its redacted secrets
+		// are then read from the other sources
+		if _, ok := s.value.(secretValue); ok && value == redacted {
+			return nil
+		}
+		return s.value.Set(value)
+	default:
+		return s.value.Set(fmt.Sprint(value))
+	}
+}
+
+// applySecretFiles reads the secrets mounted in the secrets directory, as done
+// by Docker and Kubernetes.
+func (l *loader) applySecretFiles() []error {
+	var errs []error
+	for _, s := range l.settings {
+		if s.secret == "" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(l.secretsDir, s.secret))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("secret %s: %w", s.secret, err))
+			continue
+		}
+		if err := s.value.Set(string(data)); err != nil {
+			errs = append(errs, fmt.Errorf("secret %s: %w", s.secret, err))
+		}
+	}
+	return errs
+}
+
+func (l *loader) applyEnv() []error {
+	var errs []error
+	for _, s := range l.settings {
+		if value, ok := l.getEnv(s.env); ok {
+			if err := s.value.Set(value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", s.env, err))
+			}
+		}
+
+		if s.secret == "" {
+			continue
+		}
+		if path, ok := l.getEnv(s.env + "_FILE"); ok {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s_FILE: %w", s.env, err))
+				continue
+			}
+			if err := s.value.Set(string(data)); err != nil {
+				errs = append(errs, fmt.Errorf("%s_FILE: %w", s.env, err))
+			}
+		}
+	}
+	return errs
+}
+
+// getEnv looks up a non empty environment variable, in upper or lower case.
+func (l *loader) getEnv(name string) (string, bool) {
+	for _, candidate := range []string{name, strings.ToLower(name)} {
+		if value, ok := l.lookupEnv(candidate); ok && value != "" {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func (l *loader) missingSecrets() []error {
+	var errs []error
+	for _, s := range l.settings {
+		if secret, ok := s.value.(secretValue); ok && s.required && !secret.isSet() {
+			errs = append(errs, fmt.Errorf("%s: required, set %s/%s, %s or %s_FILE", s.key, l.secretsDir, s.secret, s.env, s.env))
+		}
+	}
+	return errs
+}
+
+// destroySecrets wipes the secrets of a configuration that is not used.
+func (c *Config) destroySecrets() {
+	l := newLoader(c, os.LookupEnv, defaultSecretsDir)
+	for _, s := range l.settings {
+		if secret, ok := s.value.(secretValue); ok {
+			_ = secret.Set("")
+		}
+	}
+}
+
+// Print writes the configuration as YAML, in the format of the configuration
+// file. The secrets are redacted.
+func (c *Config) Print(w io.Writer) error {
+	l := newLoader(c, os.LookupEnv, defaultSecretsDir)
+
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, s := range l.settings {
+		parent := root
+		path := strings.Split(s.key, ".")
+		for _, name := range path[:len(path)-1] {
+			parent = childMapping(parent, name)
+		}
+		parent.Content = append(parent.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: path[len(path)-1]},
+			valueNode(s.value),
+		)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func childMapping(parent *yaml.Node, name string) *yaml.Node {
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == name {
+			return parent.Content[i+1]
+		}
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, child)
+	return child
+}
+
+func valueNode(value flag.Value) *yaml.Node {
+	switch value := value.(type) {
+	case listValue:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for _, item := range value.Items() {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: item, Tag: "!!str"})
+		}
+		return node
+	case policiesValue:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		for _, name := range slices.Sorted(maps.Keys(*value.dst)) {
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: name},
+				&yaml.Node{Kind: yaml.ScalarNode, Value: formatRateLimitPolicy((*value.dst)[name]), Tag: "!!str"},
+			)
+		}
+		return node
+	case stringValue, secretValue:
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: value.String(), Tag: "!!str"}
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: value.String()}
+	}
+}
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requiredSecrets sets every required secret, in the secrets directory.
+func requiredSecrets(t *testing.T) string {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"db_user", "db_password", "db_database_name", "db_host", "db_port",
+		"valkey_host", "valkey_port",
+		"smtp_username", "smtp_password", "smtp_host", "smtp_port", "smtp_recipient",
+		"minio_endpoint", "minio_access_key", "minio_secret_key", "minio_bucket",
+	} {
+		writeFile(t, dir, name, name+"-value\n")
+	}
+	return dir
+}
+
+func writeFile(t *testing.T, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func envLookup(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, opts, err := load(nil, envLookup(nil), requiredSecrets(t))
+	require.NoError(t, err)
+
+	assert.Empty(t, opts.ConfigFile)
+	assert.False(t, opts.PrintConfig)
+	assert.Equal(t, 5000, cfg.App.Port)
+	assert.Equal(t, 30*time.Second, cfg.App.ShutdownTimeout)
+	assert.NotEmpty(t, cfg.App.Version)
+	assert.Equal(t, 25, cfg.Postgres.MaxOpenConns)
+	assert.Equal(t, DefaultRateLimitPolicies(), cfg.App.Limiter.Policies)
+	assert.Equal(t, "db_user-value", cfg.Postgres.User.String())
+	assert.Nil(t, cfg.Valkey.Password)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	dir := requiredSecrets(t)
+	file := writeFile(t, t.TempDir(), "config.yaml", `
+app:
+  port: 6000
+  metrics_port: 6001
+  environment: staging
+  cors:
+    trusted_origins: [https://example.com, https://www.example.com]
+  limiter:
+    policies:
+      login: 10/1m
+postgres:
+  max_open_conns: 40
+`)
+
+	cfg, opts, err := load(
+		[]string{"-metrics-port", "7001", "-rate-limit-policies", "contact=1/1h"},
+		envLookup(map[string]string{
+			"CONFIG_FILE":       file,
+			"PORT":              "7000",
+			"METRICS_PORT":      "8001",
+			"db_max_idle_conns": "10",
+		}),
+		dir,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, file, opts.ConfigFile)
+	assert.Equal(t, "staging", cfg.App.Environment, "file over default")
+	assert.Equal(t, 7000, cfg.App.Port, "env over file")
+	assert.Equal(t, 7001, cfg.App.MetricsPort, "flag over env")
+	assert.Equal(t, 40, cfg.Postgres.MaxOpenConns)
+	assert.Equal(t, 10, cfg.Postgres.MaxIdleConns, "lower case env")
+	assert.Equal(t, []string{"https://example.com", "https://www.example.com"}, cfg.App.Cors.TrustedOrigins)
+
+	policies := cfg.App.Limiter.Policies
+	assert.Equal(t, RateLimitPolicy{Requests: 10, Period: time.Minute, Burst: 10}, policies["login"])
+	assert.Equal(t, RateLimitPolicy{Requests: 1, Period: time.Hour, Burst: 1}, policies["contact"])
+	assert.Equal(t, DefaultRateLimitPolicies()["registration"], policies["registration"])
+	assert.Equal(t, 5, DefaultRateLimitPolicies()["login"].Requests, "defaults not modified")
+}
+
+func TestLoad_TOML(t *testing.T) {
+	file := writeFile(t, t.TempDir(), "config.toml", `
+[app]
+port = 6000
+trusted_proxies = ["10.0.0.0/8"]
+
+[contact]
+blocked_keywords = ["crypto offer", "seo"]
+`)
+
+	cfg, _, err := load([]string{"-config", file}, envLookup(nil), requiredSecrets(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, 6000, cfg.App.Port)
+	assert.Equal(t, []string{"10.0.0.0/8"}, cfg.App.TrustedProxies)
+	assert.Equal(t, []string{"crypto offer", "seo"}, cfg.Contact.BlockedKeywords)
+}
+
+func TestLoad_Secrets(t *testing.T) {
+	dir := requiredSecrets(t)
+	passwordFile := writeFile(t, t.TempDir(), "password", "from-file\n")
+
+	cfg, _, err := load(nil, envLookup(map[string]string{
+		"DB_USER":          "from-env",
+		"DB_PASSWORD":      "from-env",
+		"DB_PASSWORD_FILE": passwordFile,
+		"valkey_password":  "valkey-secret",
+	}), dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "from-env", cfg.Postgres.User.String(), "env over secrets directory")
+	assert.Equal(t, "from-file", cfg.Postgres.Password.String(), "_FILE over env")
+	assert.Equal(t, "valkey-secret", cfg.Valkey.Password.String())
+
+	_, _, err = load([]string{"-db-password", "secret"}, envLookup(nil), dir)
+	assert.Error(t, err, "no flag for secrets")
+}
+
+func TestLoad_ReportsEveryError(t *testing.T) {
+	file := writeFile(t, t.TempDir(), "config.yaml", `
+app:
+  port: http
+  limiter:
+    backend: redis
+  unknown: true
+`)
+
+	_, _, err := load(
+		[]string{"-config", file, "-tracing-sample-ratio", "2", "-shutdown-timeout", "0s"},
+		envLookup(map[string]string{"TRUSTED_PROXIES": "10.0.0.0/33"}),
+		t.TempDir(),
+	)
+	require.Error(t, err)
+
+	for _, message := range []string{
+		`config file: app.port: invalid integer "http"`,
+		"config file: app.unknown: unknown setting",
+		"app.limiter.backend: must be one of",
+		"app.tracing.sample_ratio: must be between 0 and 1",
+		"app.shutdown_timeout: must be a positive duration",
+		"app.trusted_proxies: invalid trusted proxy",
+		"postgres.user: required",
+		"minio.bucket: required",
+	} {
+		assert.Contains(t, err.Error(), message)
+	}
+}
+
+func TestLoad_AcceptsZeroIntervals(t *testing.T) {
+	cfg, _, err := load(
+		[]string{"-newsletter-batch-interval", "0s", "-analytics-flush-interval", "0s"},
+		envLookup(nil),
+		requiredSecrets(t),
+	)
+	require.NoError(t, err)
+	assert.Zero(t, cfg.Newsletter.BatchInterval, "the batches are sent without pausing")
+	assert.Zero(t, cfg.Analytics.FlushInterval, "another instance flushes the views")
+
+	_, _, err = load([]string{"-analytics-flush-interval", "-1s"}, envLookup(nil), requiredSecrets(t))
+	assert.ErrorContains(t, err, "analytics.flush_interval: must not be negative")
+}
+
+func TestLoad_UnsupportedFile(t *testing.T) {
+	file := writeFile(t, t.TempDir(), "config.json", `{}`)
+
+	_, _, err := load([]string{"-config", file}, envLookup(nil), requiredSecrets(t))
+	assert.ErrorContains(t, err, `unsupported extension ".json"`)
+}
+
+func TestPrint_RedactsSecrets(t *testing.T) {
+	cfg, _, err := load([]string{"-port", "6000"}, envLookup(nil), requiredSecrets(t))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, cfg.Print(&out))
+
+	assert.NotContains(t, out.String(), "-value")
+	assert.Contains(t, out.String(), "password: '[REDACTED]'")
+	assert.Contains(t, out.String(), "port: 6000")
+
+	// The printed configuration is a valid configuration file
+	file := writeFile(t, t.TempDir(), "config.yaml", out.String())
+	reloaded, _, err := load([]string{"-config", file}, envLookup(nil), requiredSecrets(t))
+	require.NoError(t, err)
+	assert.Equal(t, cfg.App, reloaded.App)
+	assert.Equal(t, "db_password-value", reloaded.Postgres.Password.String())
+	assert.Equal(t, cfg.Contact.ChallengeTTL, reloaded.Contact.ChallengeTTL)
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadableKeys are the settings applied by Reload. The others are read once
+// at startup, a change is only reported as requiring a restart.
+var reloadableKeys = []string{
+	"app.log_level",
+	"app.features",
+	"app.cors.trusted_origins",
+	"app.limiter.rps",
+	"app.limiter.burst",
+	"app.limiter.enabled",
+	"app.limiter.policies",
+	"app.cache.max_age",
+	"app.cache.stale_while_revalidate",
+}
+
+// reloadDebounce groups the events of an editor saving the configuration
+// file, which often writes it in several steps.
+const reloadDebounce = 500 * time.Millisecond
+
+// Change is a setting whose value differs in the reloaded configuration.
+type Change struct {
+	Key string
+	Old string
+	New string
+	// Applied is false for the settings that require a restart
+	Applied bool
+}
+
+// Live is the configuration of the running process. Reload swaps it
+// atomically, so that a request reads the settings of a single snapshot.
+type Live struct {
+	current  atomic.Pointer[Config]
+	logLevel *slog.LevelVar
+	opts     Options
+	mu       sync.Mutex
+}
+
+// NewLive wraps the configuration loaded with opts. A Live built without the
+// options of Load cannot be reloaded.
+func NewLive(cfg *Config, opts Options) *Live {
+	l := &Live{
+		logLevel: new(slog.LevelVar),
+		opts:     opts,
+	}
+	l.logLevel.Set(cfg.App.LogLevel)
+	l.current.Store(cfg)
+	return l
+}
+
+// Config returns the current snapshot, which must not be modified.
+func (l *Live) Config() *Config {
+	return l.current.Load()
+}
+
+// App returns the application settings of the current snapshot.
+func (l *Live) App() *AppConfig {
+	return &l.current.Load().App
+}
+
+// LogLevel follows app.log_level, for the handler of the logger.
+func (l *Live) LogLevel() *slog.LevelVar {
+	return l.logLevel
+}
+
+// Reload loads the configuration again from its sources. An invalid
+// configuration is rejected and the current one kept. Otherwise the
+// reloadable settings are swapped in and every change is returned.
+func (l *Live) Reload() ([]Change, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.opts.lookupEnv == nil {
+		return nil, nil
+	}
+
+	fresh, _, err := load(l.opts.args, l.opts.lookupEnv, l.opts.secretsDir)
+	if err != nil {
+		return nil, err
+	}
+	// The secrets are never reloaded, the services keep the startup ones
+	defer fresh.destroySecrets()
+
+	current := l.current.Load()
+	changes := diff(current, &fresh)
+	if !slices.ContainsFunc(changes, func(c Change) bool { return c.Applied }) {
+		return changes, nil
+	}
+
+	next := *current
+	next.App.LogLevel = fresh.App.LogLevel
+	next.App.Features = fresh.App.Features
+	next.App.Cors.TrustedOrigins = fresh.App.Cors.TrustedOrigins
+	next.App.Limiter.Rps = fresh.App.Limiter.Rps
+	next.App.Limiter.Burst = fresh.App.Limiter.Burst
+	next.App.Limiter.Enabled = fresh.App.Limiter.Enabled
+	next.App.Limiter.Policies = fresh.App.Limiter.Policies
+	next.App.Cache.MaxAge = fresh.App.Cache.MaxAge
+	next.App.Cache.StaleWhileRevalidate = fresh.App.Cache.StaleWhileRevalidate
+
+	l.current.Store(&next)
+	l.logLevel.Set(next.App.LogLevel)
+	return changes, nil
+}
+
+// diff compares the settings in their printed form, so that the secrets are
+// not compared and never logged.
+func diff(current *Config, fresh *Config) []Change {
+	before := newLoader(current, os.LookupEnv, defaultSecretsDir)
+	after := newLoader(fresh, os.LookupEnv, defaultSecretsDir)
+
+	var changes []Change
+	for i, s := range before.settings {
+		oldValue, newValue := s.value.String(), after.settings[i].value.String()
+		if oldValue != newValue {
+			changes = append(changes, Change{
+				Key:     s.key,
+				Old:     oldValue,
+				New:     newValue,
+				Applied: slices.Contains(reloadableKeys, s.key),
+			})
+		}
+	}
+	return changes
+}
+
+// Watch reloads the configuration on SIGHUP and when the configuration file
+// changes, until ctx is done.
+func (l *Live) Watch(ctx context.Context, logger *slog.Logger) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	var fileEvents <-chan fsnotify.Event
+	var fileErrors <-chan error
+	if l.opts.ConfigFile != "" {
+		watcher, err := watchFile(l.opts.ConfigFile)
+		if err != nil {
+			logger.Warn("Failed to watch the configuration file, reload with SIGHUP", "file", l.opts.ConfigFile, "error", err)
+		} else {
+			defer watcher.Close()
+			fileEvents, fileErrors = watcher.Events, watcher.Errors
+		}
+	}
+
+	debounce := time.NewTimer(reloadDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangup:
+			l.reloadAndLog(logger, "signal")
+		case event := <-fileEvents:
+			if isConfigFileEvent(event, l.opts.ConfigFile) {
+				debounce.Reset(reloadDebounce)
+			}
+		case err := <-fileErrors:
+			logger.Warn("Configuration file watcher failed", "error", err)
+		case <-debounce.C:
+			l.reloadAndLog(logger, "file")
+		}
+	}
+}
+
+func (l *Live) reloadAndLog(logger *slog.Logger, trigger string) {
+	changes, err := l.Reload()
+	if err != nil {
+		logger.Error("Configuration reload rejected, keeping the current configuration", "trigger", trigger, "error", err)
+		return
+	}
+
+	if len(changes) == 0 {
+		logger.Info("Configuration reloaded, nothing changed", "trigger", trigger)
+		return
+	}
+	for _, change := range changes {
+		if change.Applied {
+			logger.Info("Configuration setting changed", "trigger", trigger, "key", change.Key, "old", change.Old, "new", change.New)
+		} else {
+			logger.Warn("Configuration setting changed but requires a restart", "trigger", trigger, "key", change.Key, "old", change.Old, "new", change.New)
+		}
+	}
+}
+
+// watchFile watches the directory of the file rather than the file itself:
+// editors and Kubernetes replace the file, which ends a watch on it.
+func watchFile(path string) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	return watcher, nil
+}
+
+func isConfigFileEvent(event fsnotify.Event, path string) bool {
+	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+		return false
+	}
+	// Kubernetes swaps the ..data symlink of a mounted ConfigMap
+	name := filepath.Base(event.Name)
+	return filepath.Clean(event.Name) == filepath.Clean(path) || name == "..data"
+}
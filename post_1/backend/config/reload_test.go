@@ -0,0 +1,113 @@
+package config
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReload_SwapsReloadableSettings(t *testing.T) {
+	dir := t.TempDir()
+	file := writeFile(t, dir, "config.yaml", `
+app:
+  port: 6000
+  cors:
+    trusted_origins: [https://example.com]
+`)
+
+	cfg, opts, err := load([]string{"-config", file}, envLookup(nil), requiredSecrets(t))
+	require.NoError(t, err)
+	live := NewLive(&cfg, opts)
+	before := live.Config()
+
+	writeFile(t, dir, "config.yaml", `
+app:
+  port: 6001
+  log_level: debug
+  cors:
+    trusted_origins: [https://example.com, https://www.example.com]
+  limiter:
+    rps: 100
+`)
+
+	changes, err := live.Reload()
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []Change{
+		{Key: "app.port", Old: "6000", New: "6001", Applied: false},
+		{Key: "app.log_level", Old: "info", New: "debug", Applied: true},
+		{Key: "app.cors.trusted_origins", Old: "https://example.com", New: "https://example.com https://www.example.com", Applied: true},
+		{Key: "app.limiter.rps", Old: "500", New: "100", Applied: true},
+	}, changes)
+
+	app := live.App()
+	assert.Equal(t, 6000, app.Port, "requires a restart")
+	assert.Equal(t, []string{"https://example.com", "https://www.example.com"}, app.Cors.TrustedOrigins)
+	assert.Equal(t, 100, app.Limiter.Rps)
+	assert.Equal(t, slog.LevelDebug, live.LogLevel().Level())
+	assert.Equal(t, "db_user-value", live.Config().Postgres.User.String(), "secrets kept")
+
+	assert.Equal(t, []string{"https://example.com"}, before.App.Cors.TrustedOrigins, "previous snapshot unchanged")
+}
+
+func TestReload_RejectsInvalidConfiguration(t *testing.T) {
+	dir := t.TempDir()
+	file := writeFile(t, dir, "config.yaml", "app:\n  limiter:\n    rps: 100\n")
+
+	cfg, opts, err := load([]string{"-config", file}, envLookup(nil), requiredSecrets(t))
+	require.NoError(t, err)
+	live := NewLive(&cfg, opts)
+
+	writeFile(t, dir, "config.yaml", "app:\n  limiter:\n    rps: 50\n    burst: -1\n")
+
+	_, err = live.Reload()
+	assert.ErrorContains(t, err, "app.limiter.burst: must be positive")
+	assert.Equal(t, 100, live.App().Limiter.Rps)
+}
+
+func TestReload_FlagsKeepPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	file := writeFile(t, dir, "config.yaml", "app:\n  limiter:\n    rps: 100\n")
+
+	cfg, opts, err := load([]string{"-config", file, "-rate-limiter", "10"}, envLookup(nil), requiredSecrets(t))
+	require.NoError(t, err)
+	live := NewLive(&cfg, opts)
+
+	writeFile(t, dir, "config.yaml", "app:\n  limiter:\n    rps: 200\n")
+
+	changes, err := live.Reload()
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, 10, live.App().Limiter.Rps)
+}
+
+func TestReload_SwapsCacheSettings(t *testing.T) {
+	dir := t.TempDir()
+	file := writeFile(t, dir, "config.yaml", "app:\n  cache:\n    max_age: 1m\n")
+
+	cfg, opts, err := load([]string{"-config", file}, envLookup(nil), requiredSecrets(t))
+	require.NoError(t, err)
+	live := NewLive(&cfg, opts)
+
+	writeFile(t, dir, "config.yaml", "app:\n  cache:\n    max_age: 10m\n    stale_while_revalidate: 0s\n")
+
+	changes, err := live.Reload()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []Change{
+		{Key: "app.cache.max_age", Old: "1m0s", New: "10m0s", Applied: true},
+		{Key: "app.cache.stale_while_revalidate", Old: "5m0s", New: "0s", Applied: true},
+	}, changes)
+	assert.Equal(t, 10*time.Minute, live.App().Cache.MaxAge)
+	assert.Zero(t, live.App().Cache.StaleWhileRevalidate)
+}
+
+func TestIsConfigFileEvent(t *testing.T) {
+	assert.True(t, isConfigFileEvent(fsnotify.Event{Name: "/etc/api/config.yaml", Op: fsnotify.Write}, "/etc/api/config.yaml"))
+	assert.True(t, isConfigFileEvent(fsnotify.Event{Name: "/etc/api/..data", Op: fsnotify.Create}, "/etc/api/config.yaml"))
+	assert.False(t, isConfigFileEvent(fsnotify.Event{Name: "/etc/api/other.yaml", Op: fsnotify.Write}, "/etc/api/config.yaml"))
+	assert.False(t, isConfigFileEvent(fsnotify.Event{Name: "/etc/api/config.yaml", Op: fsnotify.Chmod}, "/etc/api/config.yaml"))
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"personal_website/pkg/clientip"
+)
+
+var (
+	environments    = []string{"development", "staging", "production", "test"}
+	limiterBackends = []string{"valkey", "memory"}
+	traceExporters  = []string{"none", "otlp"}
+)
+
+// Validate checks the settings that do not depend on the secrets, and returns
+// every problem found joined in a single error.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	app := &c.App
+	v.oneOf("app.environment", app.Environment, environments)
+	v.check(app.Version != "", "app.version", "must not be empty")
+	v.port("app.port", app.Port)
+	v.port("app.metrics_port", app.MetricsPort)
+	v.check(app.Port != app.MetricsPort, "app.metrics_port", "must differ from app.port")
+	v.positive("app.shutdown_timeout", app.ShutdownTimeout)
+	v.url("app.activation_url", app.ActivationUrl)
+	if _, err := clientip.NewResolver(app.TrustedProxies); err != nil {
+		v.add("app.trusted_proxies", err.Error())
+	}
+	v.check(app.Limiter.Rps > 0, "app.limiter.rps", "must be positive")
+	v.check(app.Limiter.Burst > 0, "app.limiter.burst", "must be positive")
+	v.oneOf("app.limiter.backend", app.Limiter.Backend, limiterBackends)
+	v.positive("app.health.cache_ttl", app.Health.CacheTTL)
+	v.positive("app.health.check_timeout", app.Health.CheckTimeout)
+	v.check(app.Cache.MaxAge >= 0, "app.cache.max_age", "must not be negative")
+	v.check(app.Cache.StaleWhileRevalidate >= 0, "app.cache.stale_while_revalidate", "must not be negative")
+	v.positive("app.cache.ttl", app.Cache.TTL)
+	v.positive("app.cache.articles_ttl", app.Cache.ArticlesTTL)
+	v.oneOf("app.tracing.exporter", app.Tracing.Exporter, traceExporters)
+	v.check(app.Tracing.SampleRatio >= 0 && app.Tracing.SampleRatio <= 1, "app.tracing.sample_ratio", "must be between 0 and 1")
+
+	pg := &c.Postgres
+	v.check(pg.MaxOpenConns > 0, "postgres.max_open_conns", "must be positive")
+	v.check(pg.MaxIdleConns >= 0 && pg.MaxIdleConns <= pg.MaxOpenConns, "postgres.max_idle_conns", "must be between 0 and postgres.max_open_conns")
+	v.positive("postgres.conn_max_lifetime", pg.ConnMaxLifetime)
+	v.positive("postgres.conn_max_idle_time", pg.ConnMaxIdleTime)
+	v.check(pg.MaxRetries >= 0, "postgres.max_retries", "must not be negative")
+	v.positive("postgres.retry_delay", pg.RetryDelay)
+	v.check(pg.MaxRetryDelay >= pg.RetryDelay, "postgres.max_retry_delay", "must not be shorter than postgres.retry_delay")
+	v.positive("postgres.connect_timeout", pg.ConnectTimeout)
+
+	v.check(c.SMTP.DefaultLocale != "", "smtp.default_locale", "must not be empty")
+
+	contact := &c.Contact
+	v.check(contact.ChallengeDifficulty >= 0 && contact.ChallengeDifficulty <= 32, "contact.challenge_difficulty", "must be between 0 and 32")
+	v.positive("contact.challenge_ttl", contact.ChallengeTTL)
+	v.check(contact.MinFillTime >= 0 && contact.MinFillTime < contact.ChallengeTTL, "contact.min_fill_time", "must be shorter than contact.challenge_ttl")
+	v.check(contact.MaxLinks >= 0, "contact.max_links", "must not be negative")
+	v.check(contact.EmailQuota > 0, "contact.email_quota", "must be positive")
+	v.check(contact.IPQuota > 0, "contact.ip_quota", "must be positive")
+	v.positive("contact.quota_window", contact.QuotaWindow)
+
+	newsletter := &c.Newsletter
+	v.url("newsletter.confirmation_url", newsletter.ConfirmationURL)
+	v.url("newsletter.unsubscribe_url", newsletter.UnsubscribeURL)
+	v.url("newsletter.article_url", newsletter.ArticleURL)
+	v.positive("newsletter.token_ttl", newsletter.TokenTTL)
+	v.check(newsletter.ResendCooldown >= 0, "newsletter.resend_cooldown", "must not be negative")
+	v.check(newsletter.BatchSize > 0, "newsletter.batch_size", "must be positive")
+	v.check(newsletter.BatchInterval >= 0, "newsletter.batch_interval", "must not be negative")
+
+	v.check(c.Analytics.FlushInterval >= 0, "analytics.flush_interval", "must not be negative")
+
+	v.check(c.Sync.Ref != "" && !strings.HasPrefix(c.Sync.Ref, "-"), "sync.ref", "must be a git revision")
+
+	v.positive("preview.ttl", c.Preview.TTL)
+
+	return errors.Join(v.errs...)
+}
+
+type validator struct {
+	errs []error
+}
+
+func (v *validator) add(key string, message string) {
+	v.errs = append(v.errs, fmt.Errorf("%s: %s", key, message))
+}
+
+func (v *validator) check(ok bool, key string, message string) {
+	if !ok {
+		v.add(key, message)
+	}
+}
+
+func (v *validator) oneOf(key string, value string, allowed []string) {
+	v.check(slices.Contains(allowed, value), key, fmt.Sprintf("must be one of %v, got %q", allowed, value))
+}
+
+func (v *validator) port(key string, port int) {
+	v.check(port > 0 && port <= 65535, key, "must be between 1 and 65535")
+}
+
+func (v *validator) positive(key string, d time.Duration) {
+	v.check(d > 0, key, "must be a positive duration")
+}
+
+// url accepts an empty value, the features using the URL are then disabled.
+func (v *validator) url(key string, value string) {
+	if value == "" {
+		return
+	}
+	u, err := url.Parse(value)
+	v.check(err == nil && u.Scheme != "" && u.Host != "", key, fmt.Sprintf("must be an absolute URL, got %q", value))
+}
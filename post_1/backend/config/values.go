@@ -0,0 +1,240 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/awnumar/memguard"
+)
+
+// The values below implement flag.Value on a field of the configuration, so
+// that the configuration file, the environment and the flags all set the
+// settings from their text form.
+
+const redacted = "[REDACTED]"
+
+type stringValue struct{ dst *string }
+
+func (v stringValue) String() string {
+	if v.dst == nil {
+		return ""
+	}
+	return *v.dst
+}
+
+func (v stringValue) Set(s string) error {
+	*v.dst = s
+	return nil
+}
+
+type intValue struct{ dst *int }
+
+func (v intValue) String() string {
+	if v.dst == nil {
+		return ""
+	}
+	return strconv.Itoa(*v.dst)
+}
+
+func (v intValue) Set(s string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("invalid integer %q", s)
+	}
+	*v.dst = n
+	return nil
+}
+
+type boolValue struct{ dst *bool }
+
+func (v boolValue) String() string {
+	if v.dst == nil {
+		return ""
+	}
+	return strconv.FormatBool(*v.dst)
+}
+
+func (v boolValue) Set(s string) error {
+	b, err := strconv.ParseBool(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("invalid boolean %q", s)
+	}
+	*v.dst = b
+	return nil
+}
+
+func (v boolValue) IsBoolFlag() bool { return true }
+
+type floatValue struct{ dst *float64 }
+
+func (v floatValue) String() string {
+	if v.dst == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v.dst, 'g', -1, 64)
+}
+
+func (v floatValue) Set(s string) error {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %q", s)
+	}
+	*v.dst = f
+	return nil
+}
+
+type durationValue struct{ dst *time.Duration }
+
+func (v durationValue) String() string {
+	if v.dst == nil {
+		return ""
+	}
+	return v.dst.String()
+}
+
+func (v durationValue) Set(s string) error {
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("invalid duration %q, expected e.g. 30s or 5m", s)
+	}
+	*v.dst = d
+	return nil
+}
+
+type levelValue struct{ dst *slog.Level }
+
+func (v levelValue) String() string {
+	if v.dst == nil {
+		return ""
+	}
+	return strings.ToLower(v.dst.String())
+}
+
+func (v levelValue) Set(s string) error {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return fmt.Errorf("invalid log level %q, expected debug, info, warn or error", s)
+	}
+	*v.dst = level
+	return nil
+}
+
+// listValue is a list separated by whitespace, or by commas when the items
+// may contain spaces.
+type listValue struct {
+	dst   *[]string
+	comma bool
+}
+
+func (v listValue) String() string {
+	if v.dst == nil {
+		return ""
+	}
+	if v.comma {
+		return strings.Join(*v.dst, ",")
+	}
+	return strings.Join(*v.dst, " ")
+}
+
+func (v listValue) Set(s string) error {
+	if v.comma {
+		v.SetList(strings.Split(s, ","))
+	} else {
+		v.SetList(strings.Fields(s))
+	}
+	return nil
+}
+
+// SetList sets the items of a list written as a list in the configuration
+// file.
+func (v listValue) SetList(items []string) {
+	*v.dst = nil
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			*v.dst = append(*v.dst, item)
+		}
+	}
+}
+
+func (v listValue) Items() []string {
+	if v.dst == nil {
+		return nil
+	}
+	return *v.dst
+}
+
+// policiesValue overrides the rate limit policies one by one, the policies
+// not mentioned keep their previous value.
+type policiesValue struct{ dst *map[string]RateLimitPolicy }
+
+func (v policiesValue) String() string {
+	if v.dst == nil {
+		return ""
+	}
+	entries := make([]string, 0, len(*v.dst))
+	for _, name := range slices.Sorted(maps.Keys(*v.dst)) {
+		entries = append(entries, name+"="+formatRateLimitPolicy((*v.dst)[name]))
+	}
+	return strings.Join(entries, ",")
+}
+
+func (v policiesValue) Set(s string) error {
+	overrides, err := ParseRateLimitPolicies(s)
+	if err != nil {
+		return err
+	}
+
+	// Copy before merging, the previous map may be shared with the defaults
+	policies := maps.Clone(*v.dst)
+	if policies == nil {
+		policies = make(map[string]RateLimitPolicy, len(overrides))
+	}
+	maps.Copy(policies, overrides)
+	*v.dst = policies
+	return nil
+}
+
+// SetMap sets the policies written as a table in the configuration file,
+// e.g. login: 10/1m.
+func (v policiesValue) SetMap(entries map[string]string) error {
+	specs := make([]string, 0, len(entries))
+	for _, name := range slices.Sorted(maps.Keys(entries)) {
+		specs = append(specs, name+"="+entries[name])
+	}
+	return v.Set(strings.Join(specs, ","))
+}
+
+func formatRateLimitPolicy(policy RateLimitPolicy) string {
+	return fmt.Sprintf("%d/%s:%d", policy.Requests, policy.Period, policy.Burst)
+}
+
+// secretValue keeps a secret in a memguard buffer. Its text form is redacted,
+// so that secrets are never printed.
+type secretValue struct{ dst **memguard.LockedBuffer }
+
+func (v secretValue) String() string {
+	if v.dst == nil || !v.isSet() {
+		return ""
+	}
+	return redacted
+}
+
+func (v secretValue) Set(s string) error {
+	if *v.dst != nil {
+		(*v.dst).Destroy()
+		*v.dst = nil
+	}
+	if s = strings.TrimSpace(s); s != "" {
+		*v.dst = memguard.NewBufferFromBytes([]byte(s))
+	}
+	return nil
+}
+
+func (v secretValue) isSet() bool {
+	return *v.dst != nil && (*v.dst).Size() > 0
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// DayLayout formats the days of the view statistics.
+const DayLayout = "2006-01-02"
+
+// ArticleView is a view of an article by a visitor, identified by a salted
+// hash of its IP address and user agent. The salt changes every day, so the
+// hashes of two days cannot be linked.
+type ArticleView struct {
+	ArticleID   int32
+	Day         time.Time
+	VisitorHash string
+}
+
+// ArticleViews counts the unique visitors of an article on a day.
+type ArticleViews struct {
+	ArticleID int32
+	Day       time.Time
+	Views     int64
+}
+
+// TopArticle is an article ranked by its number of views over a date range.
+type TopArticle struct {
+	ArticleID int32
+	Title     string
+	Slug      string
+	Views     int64
+}
+
+// DateRange is a range of days, both bounds included.
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// ViewDay returns the UTC day of t, which the views are counted by.
+func ViewDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// Days returns the number of days in the range.
+func (r DateRange) Days() int {
+	return int(ViewDay(r.To).Sub(ViewDay(r.From))/(24*time.Hour)) + 1
+}
@@ -7,8 +7,12 @@ type Article struct {
 	Title       string
 	Slug        string
 	Content     string
+	Tags        []string
 	CreatedAt   time.Time
 	PublishedAt time.Time
+	UpdatedAt   time.Time
 	DeletedAt   time.Time
 	IsPublished bool
+	// Version is incremented on every change, it starts at 1
+	Version int32
 }
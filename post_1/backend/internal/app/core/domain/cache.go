@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// CachedResponse is a rendered response of the public API, shared between
+// instances.
+type CachedResponse struct {
+	Body         []byte
+	ETag         string
+	LastModified time.Time
+	// ArticleID is the article served, its views are counted on cache hits
+	// too. It is zero for the lists.
+	ArticleID int32
+	// Location is where a redirect points to, the response is a redirect when
+	// it is set
+	Location string
+	// Generation is the generation of the cache the response was rendered in.
+	// A response rendered before the cache was invalidated is not stored.
+	Generation int64
+}
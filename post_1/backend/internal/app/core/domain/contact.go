@@ -1,8 +1,44 @@
 package domain
 
+import "time"
+
 // ContactMessage defines the data structure needed by the mailer service.
 type ContactMessage struct {
 	Name    string
 	Email   string
 	Message string
 }
+
+// ContactSubmission is a contact form message along with the signals used to
+// tell humans and bots apart.
+type ContactSubmission struct {
+	ContactMessage
+	// Honeypot is a form field hidden from humans; bots tend to fill it.
+	Honeypot string
+	// Challenge is the signed token issued by the challenge endpoint.
+	Challenge string
+	// Nonce is the client's proof-of-work solution for Challenge.
+	Nonce    string
+	ClientIP string
+}
+
+// ContactChallenge is a stateless proof-of-work challenge. The client must
+// find a nonce such that sha256(Token + ":" + nonce) starts with Difficulty
+// zero bits.
+type ContactChallenge struct {
+	Token      string
+	Difficulty int
+	ExpiresAt  time.Time
+}
+
+// SpamReason explains why a contact submission was silently discarded.
+type SpamReason string
+
+const (
+	SpamReasonNone           SpamReason = ""
+	SpamReasonHoneypot       SpamReason = "honeypot"
+	SpamReasonTooFast        SpamReason = "too_fast"
+	SpamReasonReplay         SpamReason = "challenge_replay"
+	SpamReasonTooManyLinks   SpamReason = "too_many_links"
+	SpamReasonBlockedKeyword SpamReason = "blocked_keyword"
+)
@@ -14,11 +14,15 @@ func (e DomainError) Error() string {
 }
 
 const (
-	ErrorTypeValidation ErrorType = "validation"
-	ErrorTypeNotFound   ErrorType = "not_found"
-	ErrorTypeConflict   ErrorType = "conflict"
-	ErrorTypeAuth       ErrorType = "authentication"
-	ErrorTypeInternal   ErrorType = "internal"
+	ErrorTypeValidation           ErrorType = "validation"
+	ErrorTypeNotFound             ErrorType = "not_found"
+	ErrorTypeConflict             ErrorType = "conflict"
+	ErrorTypeAuth                 ErrorType = "authentication"
+	ErrorTypeInternal             ErrorType = "internal"
+	ErrorTypeRateLimit            ErrorType = "rate_limit"
+	ErrorTypePrecondition         ErrorType = "precondition"
+	ErrorTypePreconditionRequired ErrorType = "precondition_required"
+	ErrorTypeRedirect             ErrorType = "redirect"
 )
 
 const (
@@ -46,6 +50,11 @@ var (
 		Message: "user not found",
 		Type:    ErrorTypeNotFound,
 	}
+	ErrPermissionNotFound = DomainError{
+		Code:    "permission_not_found",
+		Message: "permission not found",
+		Type:    ErrorTypeNotFound,
+	}
 	ErrArticleNotFound = DomainError{
 		Code:    "article_not_found",
 		Message: "article not found",
@@ -61,6 +70,66 @@ var (
 		Message: "article must be soft deleted before permanent deletion",
 		Type:    ErrorTypeConflict,
 	}
+	ErrArticleSlugRetired = DomainError{
+		Code:    "article_slug_retired",
+		Message: "the slug was used by another article and redirects to it",
+		Type:    ErrorTypeConflict,
+	}
+	ErrPreviewLinkNotFound = DomainError{
+		Code:    "preview_link_not_found",
+		Message: "preview link not found",
+		Type:    ErrorTypeNotFound,
+	}
+	ErrInvalidPreviewToken = DomainError{
+		Code:    "invalid_preview_token",
+		Message: "the preview link is invalid, expired or revoked",
+		Type:    ErrorTypeNotFound,
+	}
+	ErrSeriesNotFound = DomainError{
+		Code:    "series_not_found",
+		Message: "series not found",
+		Type:    ErrorTypeNotFound,
+	}
+	ErrSeriesAlreadyExists = DomainError{
+		Code:    "series_already_exists",
+		Message: "a series with this slug already exists",
+		Type:    ErrorTypeConflict,
+	}
+	ErrArticleInAnotherSeries = DomainError{
+		Code:    "article_in_another_series",
+		Message: "an article already belongs to another series",
+		Type:    ErrorTypeConflict,
+	}
+	ErrArticleVersionMismatch = DomainError{
+		Code:    "article_version_mismatch",
+		Message: "the article was modified since it was loaded, reload it and try again",
+		Type:    ErrorTypePrecondition,
+	}
+	ErrArticleVersionRequired = DomainError{
+		Code:    "article_version_required",
+		Message: "the version of the article is required, send its ETag in the If-Match header",
+		Type:    ErrorTypePreconditionRequired,
+	}
+	ErrArticleInTrash = DomainError{
+		Code:    "article_in_trash",
+		Message: "an article with this slug is in the trash, restore or delete it first",
+		Type:    ErrorTypeConflict,
+	}
+	ErrSyncDisabled = DomainError{
+		Code:    "sync_disabled",
+		Message: "content sync is not configured",
+		Type:    ErrorTypeNotFound,
+	}
+	ErrSyncInProgress = DomainError{
+		Code:    "sync_in_progress",
+		Message: "a content sync is already running",
+		Type:    ErrorTypeConflict,
+	}
+	ErrSyncEmptySource = DomainError{
+		Code:    "sync_empty_source",
+		Message: "the content repository has no Markdown file, refusing to delete every synced article",
+		Type:    ErrorTypeValidation,
+	}
 	ErrInvalidCredentials = DomainError{
 		Code:    "invalid_credentials",
 		Message: InvalidCredentialsErrorMsg,
@@ -96,6 +165,46 @@ var (
 		Message: "failed to read resume file",
 		Type:    ErrorTypeInternal,
 	}
+	ErrResumeVersionNotFound = DomainError{
+		Code:    "resume_version_not_found",
+		Message: "resume version not found",
+		Type:    ErrorTypeNotFound,
+	}
+	ErrInvalidResumeFile = DomainError{
+		Code:    "invalid_resume_file",
+		Message: "the resume must be a PDF file",
+		Type:    ErrorTypeValidation,
+	}
+	ErrInvalidContactChallenge = DomainError{
+		Code:    "invalid_contact_challenge",
+		Message: "the contact challenge is missing, invalid or expired",
+		Type:    ErrorTypeValidation,
+	}
+	ErrContactQuotaExceeded = DomainError{
+		Code:    "contact_quota_exceeded",
+		Message: "too many messages sent, please try again later",
+		Type:    ErrorTypeRateLimit,
+	}
+	ErrSubscriberAlreadyConfirmed = DomainError{
+		Code:    "subscriber_already_confirmed",
+		Message: "subscriber is already confirmed",
+		Type:    ErrorTypeConflict,
+	}
+	ErrSubscriberNotFound = DomainError{
+		Code:    "subscriber_not_found",
+		Message: "subscriber not found",
+		Type:    ErrorTypeNotFound,
+	}
+	ErrInvalidNewsletterToken = DomainError{
+		Code:    "invalid_newsletter_token",
+		Message: "invalid or expired newsletter token",
+		Type:    ErrorTypeValidation,
+	}
+	ErrInvalidDateRange = DomainError{
+		Code:    "invalid_date_range",
+		Message: "the date range must start before it ends and span at most 366 days",
+		Type:    ErrorTypeValidation,
+	}
 	ErrInternal = DomainError{
 		Code:    "internal_error",
 		Message: "an internal error occurred",
@@ -123,6 +232,28 @@ var (
 	}
 )
 
+// ArticleMovedError is returned for a retired slug of a published article,
+// which is found under Slug now. It is answered with a redirect.
+type ArticleMovedError struct {
+	DomainError
+	Slug string
+}
+
+func NewArticleMovedError(slug string) ArticleMovedError {
+	return ArticleMovedError{
+		DomainError: DomainError{
+			Code:    "article_moved",
+			Message: "the article moved to another slug",
+			Type:    ErrorTypeRedirect,
+		},
+		Slug: slug,
+	}
+}
+
+func (e ArticleMovedError) Unwrap() error {
+	return e.DomainError
+}
+
 func NewInternalError(underlying error) DomainError {
 	return DomainError{
 		Code:       "internal_error",
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+type Subscriber struct {
+	ID          int32
+	Email       string
+	Language    string
+	Confirmed   bool
+	CreatedAt   time.Time
+	ConfirmedAt time.Time
+	// ConfirmationToken is only set when a confirmation email must be sent.
+	ConfirmationToken *Token
+}
+
+// ArticleNotification defines the data needed by the mailer to announce a new
+// article to a subscriber.
+type ArticleNotification struct {
+	Recipient      string
+	Locale         string
+	Title          string
+	ArticleURL     string
+	UnsubscribeURL string
+}
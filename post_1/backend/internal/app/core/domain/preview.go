@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// PreviewLink shares an article with a reviewer before it is published,
+// without an account. Token is the signed token of the link, only set for
+// the links still active.
+type PreviewLink struct {
+	ID        int32
+	ArticleID int32
+	CreatedBy int
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	RevokedAt time.Time
+	Token     string
+}
+
+// Active reports whether the link still gives access to the article at now.
+func (l PreviewLink) Active(now time.Time) bool {
+	return l.RevokedAt.IsZero() && now.Before(l.ExpiresAt)
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// RateLimit allows Requests per Period on average, with bursts of up to Burst
+// requests.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+// Interval is the time needed to earn back one request.
+func (l RateLimit) Interval() time.Duration {
+	return l.Period / time.Duration(max(l.Requests, 1))
+}
+
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
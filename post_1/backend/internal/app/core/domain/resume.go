@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"io"
+	"time"
+)
+
+type ResumeVersion struct {
+	ID          int32
+	ObjectName  string
+	FileName    string
+	ContentType string
+	Size        int64
+	Checksum    string
+	IsCurrent   bool
+	UploadedBy  int
+	CreatedAt   time.Time
+}
+
+// ResumeUpload is a new resume file to store as a version. Content is read
+// once, Size is -1 when unknown.
+type ResumeUpload struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	Content     io.Reader
+	UploadedBy  int
+	MakeCurrent bool
+}
+
+// ResumeFile is an opened resume version. Content must be closed by the
+// caller.
+type ResumeFile struct {
+	Version      ResumeVersion
+	Content      io.ReadSeekCloser
+	Size         int64
+	ETag         string
+	LastModified time.Time
+}
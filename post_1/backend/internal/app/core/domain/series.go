@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// Series links the parts of a multi-part article. Articles holds its
+// articles in their order, only the published ones when it is read for the
+// public.
+type Series struct {
+	ID          int32
+	Title       string
+	Slug        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Articles    []Article
+}
+
+// SeriesPart places an article in its series. Position starts at 1, Previous
+// and Next are nil for the first and the last part.
+type SeriesPart struct {
+	Series   Series
+	Position int
+	Previous *Article
+	Next     *Article
+}
+
+// Part returns the place of the article among the articles of the series, and
+// whether it is one of them.
+func (s Series) Part(articleID int32) (SeriesPart, bool) {
+	for i, article := range s.Articles {
+		if article.ID != articleID {
+			continue
+		}
+
+		part := SeriesPart{Series: s, Position: i + 1}
+		if i > 0 {
+			part.Previous = &s.Articles[i-1]
+		}
+		if i < len(s.Articles)-1 {
+			part.Next = &s.Articles[i+1]
+		}
+		return part, true
+	}
+	return SeriesPart{}, false
+}
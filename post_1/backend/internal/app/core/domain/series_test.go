@@ -0,0 +1,56 @@
+package domain
+
+import "testing"
+
+func TestSeries_Part(t *testing.T) {
+	series := Series{
+		ID:    1,
+		Title: "Go tutorial",
+		Articles: []Article{
+			{ID: 10, Slug: "part-one"},
+			{ID: 20, Slug: "part-two"},
+			{ID: 30, Slug: "part-three"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		articleID int32
+		position  int
+		previous  string
+		next      string
+	}{
+		{name: "first part", articleID: 10, position: 1, next: "part-two"},
+		{name: "middle part", articleID: 20, position: 2, previous: "part-one", next: "part-three"},
+		{name: "last part", articleID: 30, position: 3, previous: "part-two"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			part, ok := series.Part(tt.articleID)
+			if !ok {
+				t.Fatalf("Series.Part(%d) not found", tt.articleID)
+			}
+			if part.Position != tt.position {
+				t.Errorf("Position = %d, want %d", part.Position, tt.position)
+			}
+			if got := slugOf(part.Previous); got != tt.previous {
+				t.Errorf("Previous = %q, want %q", got, tt.previous)
+			}
+			if got := slugOf(part.Next); got != tt.next {
+				t.Errorf("Next = %q, want %q", got, tt.next)
+			}
+		})
+	}
+
+	if _, ok := series.Part(40); ok {
+		t.Error("Series.Part() found an article that is not in the series")
+	}
+}
+
+func slugOf(article *Article) string {
+	if article == nil {
+		return ""
+	}
+	return article.Slug
+}
@@ -0,0 +1,70 @@
+package domain
+
+import "time"
+
+// SyncAction is what a content sync does to an article.
+type SyncAction string
+
+const (
+	SyncCreate    SyncAction = "create"
+	SyncUpdate    SyncAction = "update"
+	SyncDelete    SyncAction = "delete"
+	SyncUnchanged SyncAction = "unchanged"
+	SyncFailed    SyncAction = "failed"
+)
+
+// SourceFile is a Markdown file of the content repository. Err is set when
+// the file is not a valid article, the article is then empty.
+type SourceFile struct {
+	Path    string
+	Hash    string
+	Article Article
+	Err     error
+}
+
+// ContentSnapshot is the tree of the content repository at a commit.
+type ContentSnapshot struct {
+	Commit string
+	Files  []SourceFile
+}
+
+// ArticleSource records the file an article was last synced from. The
+// articles without a source were written through the API and are never
+// deleted by a sync.
+type ArticleSource struct {
+	ArticleID   int32
+	Slug        string
+	Path        string
+	ContentHash string
+	CommitSHA   string
+	IsDeleted   bool
+	SyncedAt    time.Time
+}
+
+// SyncChange is the action taken, or planned by a dry run, for a file or an
+// article.
+type SyncChange struct {
+	Action    SyncAction
+	Path      string
+	Slug      string
+	ArticleID int32
+	Error     string
+}
+
+// SyncReport is the result of a content sync.
+type SyncReport struct {
+	Commit  string
+	DryRun  bool
+	Changes []SyncChange
+}
+
+// Count returns the number of changes with the action.
+func (r SyncReport) Count(action SyncAction) int {
+	count := 0
+	for _, change := range r.Changes {
+		if change.Action == action {
+			count++
+		}
+	}
+	return count
+}
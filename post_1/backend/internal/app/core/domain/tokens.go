@@ -13,6 +13,7 @@ const (
 	ScopeActivation TokenScope = iota
 	ScopeAuthentication
 	ScopeRefresh
+	ScopeNewsletter
 )
 
 func (t TokenScope) String() (string, error) {
@@ -23,6 +24,8 @@ func (t TokenScope) String() (string, error) {
 		return "authentication", nil
 	case ScopeRefresh:
 		return "refresh", nil
+	case ScopeNewsletter:
+		return "newsletter", nil
 	default:
 		return "", errors.New("Incorrect token scope")
 	}
@@ -9,6 +9,7 @@ type User struct {
 	Email     string
 	Password  password
 	Activated bool
+	Language  string
 }
 
 func (u *User) IsAnonymous() bool {
@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+)
+
+// AdminService gathers the operations run by the operators from the command
+// line, so that they never have to edit the database by hand.
+type AdminService interface {
+	// CreateUser creates an activated user with the given permissions, all or
+	// nothing
+	CreateUser(ctx context.Context, user domain.User, permissions domain.Permissions) (int, error)
+
+	// GrantPermission reports false when the user already had the permission.
+	// The user gets it on its next login.
+	GrantPermission(ctx context.Context, email string, code string) (bool, error)
+
+	// RevokePermission reports false when the user did not have the
+	// permission. The sessions of the user are revoked, so that it applies
+	// immediately.
+	RevokePermission(ctx context.Context, email string, code string) (bool, error)
+
+	// DeactivateUser deactivates the user and revokes its sessions
+	DeactivateUser(ctx context.Context, email string) error
+
+	// RevokeSessions logs the user out of every device
+	RevokeSessions(ctx context.Context, email string) error
+
+	// ImportArticle creates or updates the article of the slug, published when
+	// publish is set and as a draft otherwise. It reports whether the article
+	// was created.
+	ImportArticle(ctx context.Context, article domain.Article, publish bool) (bool, error)
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+)
+
+type AnalyticsRepository interface {
+	// AddArticleViews adds the views to the daily totals. Views of deleted
+	// articles are ignored.
+	AddArticleViews(ctx context.Context, views []domain.ArticleViews) error
+	// ListArticleViews returns the days of the range with at least one view.
+	ListArticleViews(ctx context.Context, articleID int32, dateRange domain.DateRange) ([]domain.ArticleViews, error)
+	ListTopArticles(ctx context.Context, dateRange domain.DateRange, limit int32) ([]domain.TopArticle, error)
+}
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+)
+
+// AnalyticsService counts the article views without storing who viewed them.
+type AnalyticsService interface {
+	// RecordArticleView counts a view of the article, at most once per visitor
+	// and day, and reports whether it was counted. The client IP and user agent
+	// are only used to compute the daily visitor hash.
+	RecordArticleView(ctx context.Context, articleID int32, clientIP string, userAgent string) (bool, error)
+
+	// ArticleViews returns the views of the article for every day of the range.
+	ArticleViews(ctx context.Context, articleID int32, dateRange domain.DateRange) ([]domain.ArticleViews, error)
+
+	// TopArticles returns up to limit articles with the most views over the range.
+	TopArticles(ctx context.Context, dateRange domain.DateRange, limit int32) ([]domain.TopArticle, error)
+
+	// Flush persists the views counted since the last flush.
+	Flush(ctx context.Context) error
+
+	// Close stops the periodic flush and flushes a last time.
+	Close(ctx context.Context) error
+}
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+	"time"
+)
+
+// ArticleCacheRepository keeps the published articles read from the database,
+// shared between instances. It is invalidated by every change of the articles.
+//
+// The getters return the current generation of the cache along with a miss,
+// to pass along to the setters: what was read before the cache was
+// invalidated is not stored.
+type ArticleCacheRepository interface {
+	// GetArticle returns the published article cached under its slug.
+	GetArticle(ctx context.Context, slug string) (domain.Article, bool, int64, error)
+	SetArticle(ctx context.Context, article domain.Article, generation int64, ttl time.Duration) error
+
+	// GetArticleList returns the cached list of the published articles.
+	GetArticleList(ctx context.Context) ([]domain.Article, bool, int64, error)
+	SetArticleList(ctx context.Context, articles []domain.Article, generation int64, ttl time.Duration) error
+
+	// InvalidateArticles drops every cached article and list.
+	InvalidateArticles(ctx context.Context) error
+}
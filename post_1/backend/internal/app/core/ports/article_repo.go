@@ -3,19 +3,38 @@ package ports
 import (
 	"context"
 	"personal_website/internal/app/core/domain"
+	"time"
 )
 
 type ArticleRepository interface {
 	CreateArticle(ctx context.Context, article domain.Article) error
 	GetArticleByID(ctx context.Context, id int32) (domain.Article, error)
 	GetArticleBySlug(ctx context.Context, slug string) (domain.Article, error)
-	UpdateArticle(ctx context.Context, article domain.Article) error
-	PublishArticle(ctx context.Context, id int32) error
-	UnpublishArticle(ctx context.Context, id int32) error
+	// UpdateArticle keeps the tags of the article when Tags is nil. The
+	// changes below are only applied to the given version, or to any version
+	// when it is zero, and return the new version.
+	UpdateArticle(ctx context.Context, article domain.Article) (int32, error)
+	PublishArticle(ctx context.Context, id int32, version int32) (int32, error)
+	UnpublishArticle(ctx context.Context, id int32, version int32) (int32, error)
 	ListArticles(ctx context.Context) ([]domain.Article, error)
 	ListAllArticles(ctx context.Context) ([]domain.Article, error)
 	ListDeletedArticles(ctx context.Context) ([]domain.Article, error)
-	SoftDeleteArticle(ctx context.Context, id int32) error
+	SoftDeleteArticle(ctx context.Context, id int32, version int32) error
 	DeleteArticle(ctx context.Context, id int32) error
 	RestoreArticle(ctx context.Context, id int32) error
+	// UpsertArticleBySlug creates the article, or updates the one with the
+	// same slug, and returns its ID and whether it was created. It is
+	// unpublished unless IsPublished is set, a zero PublishedAt keeps the
+	// publication date.
+	UpsertArticleBySlug(ctx context.Context, article domain.Article) (int32, bool, error)
+	// ListAllArticlesWithContent returns the articles not in the trash, with
+	// their content, ordered by slug
+	ListAllArticlesWithContent(ctx context.Context) ([]domain.Article, error)
+	// ListArticleSources returns the sources of the synced articles, including
+	// the ones in the trash
+	ListArticleSources(ctx context.Context) ([]domain.ArticleSource, error)
+	SaveArticleSource(ctx context.Context, source domain.ArticleSource) error
+	// GetArticlesLastModified returns the time of the last change of any
+	// article, including the unpublished and deleted ones
+	GetArticlesLastModified(ctx context.Context) (time.Time, error)
 }
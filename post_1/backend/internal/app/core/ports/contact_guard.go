@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+)
+
+// ContactGuard protects the contact form against spam.
+type ContactGuard interface {
+	// IssueChallenge returns a new signed proof-of-work challenge.
+	IssueChallenge(ctx context.Context) (domain.ContactChallenge, error)
+
+	// Inspect checks a submission and returns a non-empty reason when it must
+	// be silently discarded. Invalid challenges and exceeded quotas are
+	// reported as errors.
+	Inspect(ctx context.Context, submission domain.ContactSubmission) (domain.SpamReason, error)
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+)
+
+// ContentSource reads the articles of the content repository.
+type ContentSource interface {
+	// Snapshot returns the Markdown files of the configured revision
+	Snapshot(ctx context.Context) (domain.ContentSnapshot, error)
+}
+
+// ContentSyncService makes the articles match the content repository, which
+// is the source of truth of the synced articles.
+type ContentSyncService interface {
+	// Sync creates, updates and soft deletes the articles so that they match
+	// the repository. A dry run only reports the changes.
+	Sync(ctx context.Context, dryRun bool) (domain.SyncReport, error)
+}
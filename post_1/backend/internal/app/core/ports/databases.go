@@ -8,11 +8,29 @@ type PostgresDatabase interface {
 	UserRepo() UserRepository
 	PermissionRepo() PermissionRepository
 	ArticleRepo() ArticleRepository
+	SubscriberRepo() SubscriberRepository
+	ResumeRepo() ResumeRepository
+	AnalyticsRepo() AnalyticsRepository
+	PreviewLinkRepo() PreviewLinkRepository
+	SeriesRepo() SeriesRepository
 	Begin(ctx context.Context) (Transaction, error)
+	Ping(ctx context.Context) error
+	// SchemaVersion returns the migration version of the database, with an
+	// error when it is not the version expected by the binary
+	SchemaVersion(ctx context.Context) (uint, error)
 	Close()
 }
 
 type ValkeyDatabase interface {
 	SessionRepo() SessionRepository
+	QuotaRepo() QuotaRepository
+	RateLimiter() RateLimiter
+	ViewCounterRepo() ViewCounterRepository
+	ResponseCacheRepo() ResponseCacheRepository
+	ArticleCacheRepo() ArticleCacheRepository
+	// InvalidateArticleCaches drops the cached articles and the public
+	// responses rendering them at once.
+	InvalidateArticleCaches(ctx context.Context) error
+	Ping(ctx context.Context) error
 	Close()
 }
@@ -5,7 +5,16 @@ import "context"
 type Datastore interface {
 	UserRepo() UserRepository
 	SessionRepo() SessionRepository
+	QuotaRepo() QuotaRepository
+	RateLimiter() RateLimiter
 	PermissionRepo() PermissionRepository
 	ArticleRepo() ArticleRepository
+	SubscriberRepo() SubscriberRepository
+	ResumeRepo() ResumeRepository
+	AnalyticsRepo() AnalyticsRepository
+	PreviewLinkRepo() PreviewLinkRepository
+	SeriesRepo() SeriesRepository
+	ViewCounterRepo() ViewCounterRepository
+	ResponseCacheRepo() ResponseCacheRepository
 	Begin(ctx context.Context) (Transaction, error)
 }
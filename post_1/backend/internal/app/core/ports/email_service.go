@@ -8,8 +8,10 @@ import (
 
 type EmailService interface {
 	SendContactEmail(ctx context.Context, form domain.ContactMessage) error
-	SendActivationEmail(ctx context.Context, activationToken string, recipientEmail string, baseURL string) error
+	SendActivationEmail(ctx context.Context, activationToken string, recipientEmail string, baseURL string, locale string) error
 	SendNewUserNotification(ctx context.Context, user *domain.User) error
+	SendNewsletterConfirmation(ctx context.Context, recipientEmail string, confirmationURL string, locale string) error
+	SendArticleNotification(ctx context.Context, notification domain.ArticleNotification) error
 }
 
 type EmailSender interface {
@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+)
+
+// NewsletterService encapsulates the newsletter subscription workflow
+type NewsletterService interface {
+	// Subscribe registers an unconfirmed subscriber and sends the double
+	// opt-in email. Already confirmed subscribers and addresses sent a
+	// confirmation during the resend cooldown are silently ignored.
+	Subscribe(ctx context.Context, email string, language string) error
+
+	// Confirm confirms the subscriber owning the opt-in token.
+	Confirm(ctx context.Context, tokenPlaintext string) (domain.Subscriber, error)
+
+	// Unsubscribe removes the subscriber identified by a signed unsubscribe
+	// token. The token does not expire: it stays valid for as long as the
+	// subscriber exists, like the link of every email they received.
+	Unsubscribe(ctx context.Context, token string) error
+
+	ListSubscribers(ctx context.Context) ([]domain.Subscriber, error)
+
+	// NotifyArticlePublished queues an email announcing the article to every
+	// confirmed subscriber. Emails are sent in the background.
+	NotifyArticlePublished(ctx context.Context, articleID int32)
+
+	// Close stops accepting articles and waits until the queued ones are
+	// announced or ctx ends.
+	Close(ctx context.Context) error
+}
@@ -7,4 +7,8 @@ import (
 
 type PermissionRepository interface {
 	GetPermissions(ctx context.Context, user *domain.User) (domain.Permissions, error)
+	// GrantPermission reports false when the user already had the permission
+	GrantPermission(ctx context.Context, userID int, code string) (bool, error)
+	// RevokePermission reports false when the user did not have the permission
+	RevokePermission(ctx context.Context, userID int, code string) (bool, error)
 }
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+)
+
+type PreviewLinkRepository interface {
+	CreatePreviewLink(ctx context.Context, link domain.PreviewLink) (domain.PreviewLink, error)
+	GetPreviewLink(ctx context.Context, id int32) (domain.PreviewLink, error)
+	// ListPreviewLinks returns the links of the article, the newest first,
+	// including the expired and revoked ones
+	ListPreviewLinks(ctx context.Context, articleID int32) ([]domain.PreviewLink, error)
+	RevokePreviewLink(ctx context.Context, articleID int32, id int32) error
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+)
+
+// PreviewService shares the articles before they are published through
+// signed, expiring links.
+type PreviewService interface {
+	// CreatePreviewLink creates a link to the article, valid for the configured
+	// TTL, and returns it with its token.
+	CreatePreviewLink(ctx context.Context, articleID int32, userID int) (domain.PreviewLink, error)
+
+	// ListPreviewLinks returns the links of the article, with the tokens of the
+	// active ones.
+	ListPreviewLinks(ctx context.Context, articleID int32) ([]domain.PreviewLink, error)
+
+	// RevokePreviewLink stops the link from giving access to the article.
+	RevokePreviewLink(ctx context.Context, articleID int32, id int32) error
+
+	// GetPreviewArticle returns the article of an active link, published or
+	// not.
+	GetPreviewArticle(ctx context.Context, token string) (domain.Article, error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// QuotaRepository keeps fixed-window counters shared between instances.
+type QuotaRepository interface {
+	// IncrementQuota increments the counter stored under key and returns its new
+	// value. The counter is reset once window has elapsed since its first increment.
+	IncrementQuota(ctx context.Context, key string, window time.Duration) (int64, error)
+}
@@ -0,0 +1,10 @@
+package ports
+
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+)
+
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitResult, error)
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+	"time"
+)
+
+// ResponseCacheRepository keeps the public article responses, shared between
+// instances. It is invalidated by every change of the articles.
+type ResponseCacheRepository interface {
+	// GetResponse returns the response cached under key and whether it was
+	// found. A missing response holds the current generation of the cache, to
+	// pass along to SetResponse.
+	GetResponse(ctx context.Context, key string) (domain.CachedResponse, bool, error)
+
+	// SetResponse caches the response for ttl at most, unless the cache was
+	// invalidated since its generation.
+	SetResponse(ctx context.Context, key string, response domain.CachedResponse, ttl time.Duration) error
+
+	// InvalidateResponses drops every cached response.
+	InvalidateResponses(ctx context.Context) error
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+)
+
+type ResumeRepository interface {
+	CreateResumeVersion(ctx context.Context, version domain.ResumeVersion) (domain.ResumeVersion, error)
+	GetResumeVersionByID(ctx context.Context, id int32) (domain.ResumeVersion, error)
+	GetCurrentResumeVersion(ctx context.Context) (domain.ResumeVersion, error)
+	ListResumeVersions(ctx context.Context) ([]domain.ResumeVersion, error)
+	SetCurrentResumeVersion(ctx context.Context, id int32) error
+}
@@ -1,8 +1,14 @@
 package ports
 
-import "context"
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+)
 
 type ResumeService interface {
-	GetResume(ctx context.Context, objectName string) ([]byte, error)
+	OpenCurrentResume(ctx context.Context) (domain.ResumeFile, error)
+	UploadResume(ctx context.Context, upload domain.ResumeUpload) (domain.ResumeVersion, error)
+	ListVersions(ctx context.Context) ([]domain.ResumeVersion, error)
+	SetCurrentVersion(ctx context.Context, id int32) (domain.ResumeVersion, error)
 	CheckConnection(ctx context.Context) error
 }
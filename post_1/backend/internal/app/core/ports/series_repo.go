@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+)
+
+// SeriesRepository reads the series with their articles. The public reads
+// only return the published articles, and the series with at least one.
+type SeriesRepository interface {
+	// CreateSeries creates the series with the articles in their order
+	CreateSeries(ctx context.Context, series domain.Series, articleIDs []int32) (domain.Series, error)
+	ListSeries(ctx context.Context) ([]domain.Series, error)
+	GetSeriesBySlug(ctx context.Context, slug string) (domain.Series, error)
+	// GetArticleSeries returns the series of the article with its published
+	// articles, or ErrSeriesNotFound when the article is in none
+	GetArticleSeries(ctx context.Context, articleID int32) (domain.Series, error)
+	// ListAllSeries and GetSeriesByID include the articles not published and
+	// the ones in the trash
+	ListAllSeries(ctx context.Context) ([]domain.Series, error)
+	GetSeriesByID(ctx context.Context, id int32) (domain.Series, error)
+	// SetSeriesArticles replaces the articles of the series by the given ones,
+	// in their order
+	SetSeriesArticles(ctx context.Context, id int32, articleIDs []int32) error
+	DeleteSeries(ctx context.Context, id int32) error
+}
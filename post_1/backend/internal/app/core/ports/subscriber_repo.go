@@ -0,0 +1,33 @@
+package ports
+
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+)
+
+type SubscriberRepository interface {
+	// UpsertSubscriber creates an unconfirmed subscriber or refreshes the
+	// confirmation token of an existing unconfirmed one. It returns
+	// domain.ErrSubscriberAlreadyConfirmed when the email is already confirmed.
+	UpsertSubscriber(ctx context.Context, subscriber domain.Subscriber) (int32, error)
+	ConfirmSubscriber(ctx context.Context, tokenHash []byte) (domain.Subscriber, error)
+	DeleteSubscriber(ctx context.Context, id int32) error
+	ListSubscribers(ctx context.Context) ([]domain.Subscriber, error)
+	// ListConfirmedSubscribers returns up to limit confirmed subscribers with an
+	// ID greater than afterID, ordered by ID.
+	ListConfirmedSubscribers(ctx context.Context, afterID int32, limit int32) ([]domain.Subscriber, error)
+	// ClaimArticleNotification records that an article is being announced and
+	// reports false when its announcement already completed.
+	ClaimArticleNotification(ctx context.Context, articleID int32) (bool, error)
+	// CompleteArticleNotification records that every subscriber was emailed.
+	CompleteArticleNotification(ctx context.Context, articleID int32) error
+	// ListPendingArticleNotifications returns the articles claimed but not
+	// completed, e.g. because of a restart or of a failed email.
+	ListPendingArticleNotifications(ctx context.Context) ([]int32, error)
+	// ClaimArticleDelivery records that the article is emailed to the
+	// subscriber and reports false when it already was.
+	ClaimArticleDelivery(ctx context.Context, articleID int32, subscriberID int32) (bool, error)
+	// ReleaseArticleDelivery forgets a delivery whose email failed, so that
+	// the next attempt retries it.
+	ReleaseArticleDelivery(ctx context.Context, articleID int32, subscriberID int32) error
+}
@@ -2,6 +2,7 @@ package ports
 
 type Transaction interface {
 	UserRepo() UserRepository
+	PermissionRepo() PermissionRepository
 	Commit() error
 	Rollback() error
 }
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+	"time"
+)
+
+// ViewCounterRepository deduplicates the article views and counts them until
+// they are flushed to the AnalyticsRepository. It is shared between instances.
+type ViewCounterRepository interface {
+	// VisitorSalt returns the random salt of the visitor hashes of day. It is
+	// created on first use and forgotten once the day is over.
+	VisitorSalt(ctx context.Context, day time.Time) ([]byte, error)
+
+	// RecordView counts the view unless the visitor already viewed the article
+	// that day, and reports whether it was counted.
+	RecordView(ctx context.Context, view domain.ArticleView) (bool, error)
+
+	// DrainViews returns and resets the views counted since the last drain.
+	DrainViews(ctx context.Context) ([]domain.ArticleViews, error)
+
+	// RestoreViews adds back drained views that could not be persisted.
+	RestoreViews(ctx context.Context, views []domain.ArticleViews) error
+}
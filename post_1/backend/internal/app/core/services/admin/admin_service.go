@@ -0,0 +1,120 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/app/core/ports"
+)
+
+type adminService struct {
+	datastore ports.Datastore
+}
+
+func NewAdminService(datastore ports.Datastore) *adminService {
+	return &adminService{
+		datastore: datastore,
+	}
+}
+
+func (a *adminService) CreateUser(ctx context.Context, user domain.User, permissions domain.Permissions) (int, error) {
+	tx, err := a.datastore.Begin(ctx)
+	if err != nil {
+		return 0, domain.NewInternalError(err)
+	}
+	defer tx.Rollback()
+
+	user.ID, err = tx.UserRepo().CreateUser(ctx, user)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.UserRepo().ActivateUser(ctx, &user); err != nil {
+		return 0, err
+	}
+
+	for _, code := range permissions {
+		if _, err := tx.PermissionRepo().GrantPermission(ctx, user.ID, code); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, domain.NewInternalError(err)
+	}
+
+	return user.ID, nil
+}
+
+func (a *adminService) GrantPermission(ctx context.Context, email string, code string) (bool, error) {
+	user, err := a.findUser(ctx, email)
+	if err != nil {
+		return false, err
+	}
+
+	return a.datastore.PermissionRepo().GrantPermission(ctx, user.ID, code)
+}
+
+func (a *adminService) RevokePermission(ctx context.Context, email string, code string) (bool, error) {
+	user, err := a.findUser(ctx, email)
+	if err != nil {
+		return false, err
+	}
+
+	revoked, err := a.datastore.PermissionRepo().RevokePermission(ctx, user.ID, code)
+	if err != nil || !revoked {
+		return revoked, err
+	}
+
+	// The sessions hold the permissions of the user at login
+	if err := a.datastore.SessionRepo().DeleteAllSessionsForUser(ctx, user.ID, domain.ScopeAuthentication); err != nil {
+		return true, domain.NewInternalError(err)
+	}
+	return true, nil
+}
+
+func (a *adminService) DeactivateUser(ctx context.Context, email string) error {
+	user, err := a.findUser(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if err := a.datastore.UserRepo().DeactivateUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	if err := a.datastore.SessionRepo().DeleteAllSessionsForUser(ctx, user.ID, domain.ScopeAuthentication); err != nil {
+		return domain.NewInternalError(err)
+	}
+	return nil
+}
+
+func (a *adminService) RevokeSessions(ctx context.Context, email string) error {
+	user, err := a.findUser(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if err := a.datastore.SessionRepo().DeleteAllSessionsForUser(ctx, user.ID, domain.ScopeAuthentication); err != nil {
+		return domain.NewInternalError(err)
+	}
+	return nil
+}
+
+func (a *adminService) ImportArticle(ctx context.Context, article domain.Article, publish bool) (bool, error) {
+	// The article is created or updated and published in one statement, so
+	// that an import that failed can be run again
+	article.IsPublished = publish
+	_, created, err := a.datastore.ArticleRepo().UpsertArticleBySlug(ctx, article)
+	return created, err
+}
+
+// findUser looks the user up by email. GetUserByEmail answers invalid
+// credentials for an unknown email, which is meant for the login.
+func (a *adminService) findUser(ctx context.Context, email string) (domain.User, error) {
+	user, err := a.datastore.UserRepo().GetUserByEmail(ctx, email)
+	if errors.Is(err, domain.ErrInvalidCredentials) {
+		return domain.User{}, domain.ErrUserNotFound
+	}
+	return user, err
+}
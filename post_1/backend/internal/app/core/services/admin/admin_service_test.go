@@ -0,0 +1,245 @@
+package admin
+
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/app/core/ports"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockUserRepo struct {
+	ports.UserRepository
+	users map[string]*domain.User
+}
+
+func (m *mockUserRepo) CreateUser(ctx context.Context, user domain.User) (int, error) {
+	if _, ok := m.users[user.Email]; ok {
+		return 0, domain.ErrUserAlreadyExists
+	}
+	user.ID = len(m.users) + 1
+	m.users[user.Email] = &user
+	return user.ID, nil
+}
+
+func (m *mockUserRepo) ActivateUser(ctx context.Context, user *domain.User) error {
+	m.users[user.Email].Activated = true
+	return nil
+}
+
+func (m *mockUserRepo) GetUserByEmail(ctx context.Context, email string) (domain.User, error) {
+	user, ok := m.users[email]
+	if !ok {
+		return domain.User{}, domain.ErrInvalidCredentials
+	}
+	return *user, nil
+}
+
+func (m *mockUserRepo) DeactivateUser(ctx context.Context, id int) error {
+	for _, user := range m.users {
+		if user.ID == id {
+			user.Activated = false
+		}
+	}
+	return nil
+}
+
+type mockPermissionRepo struct {
+	ports.PermissionRepository
+	granted map[int]domain.Permissions
+}
+
+func (m *mockPermissionRepo) GrantPermission(ctx context.Context, userID int, code string) (bool, error) {
+	if code == "unknown" {
+		return false, domain.ErrPermissionNotFound
+	}
+	if m.granted[userID].Include(code) {
+		return false, nil
+	}
+	m.granted[userID] = append(m.granted[userID], code)
+	return true, nil
+}
+
+func (m *mockPermissionRepo) RevokePermission(ctx context.Context, userID int, code string) (bool, error) {
+	if !m.granted[userID].Include(code) {
+		return false, nil
+	}
+	m.granted[userID] = slices.DeleteFunc(m.granted[userID], func(c string) bool { return c == code })
+	return true, nil
+}
+
+type mockSessionRepo struct {
+	ports.SessionRepository
+	revoked []int
+}
+
+func (m *mockSessionRepo) DeleteAllSessionsForUser(ctx context.Context, userID int, scope domain.TokenScope) error {
+	m.revoked = append(m.revoked, userID)
+	return nil
+}
+
+type mockArticleRepo struct {
+	ports.ArticleRepository
+	articles []domain.Article
+}
+
+func (m *mockArticleRepo) UpsertArticleBySlug(ctx context.Context, article domain.Article) (int32, bool, error) {
+	for i, existing := range m.articles {
+		if existing.Slug == article.Slug {
+			article.ID = existing.ID
+			m.articles[i] = article
+			return article.ID, false, nil
+		}
+	}
+	article.ID = int32(len(m.articles) + 1)
+	m.articles = append(m.articles, article)
+	return article.ID, true, nil
+}
+
+type mockTransaction struct {
+	datastore *mockDatastore
+	committed bool
+}
+
+func (m *mockTransaction) UserRepo() ports.UserRepository { return m.datastore.userRepo }
+func (m *mockTransaction) PermissionRepo() ports.PermissionRepository {
+	return m.datastore.permissionRepo
+}
+func (m *mockTransaction) Rollback() error { return nil }
+
+func (m *mockTransaction) Commit() error {
+	m.committed = true
+	return nil
+}
+
+type mockDatastore struct {
+	ports.Datastore
+	userRepo       *mockUserRepo
+	permissionRepo *mockPermissionRepo
+	sessionRepo    *mockSessionRepo
+	articleRepo    *mockArticleRepo
+	tx             *mockTransaction
+}
+
+func newMockDatastore() *mockDatastore {
+	d := &mockDatastore{
+		userRepo:       &mockUserRepo{users: make(map[string]*domain.User)},
+		permissionRepo: &mockPermissionRepo{granted: make(map[int]domain.Permissions)},
+		sessionRepo:    &mockSessionRepo{},
+		articleRepo:    &mockArticleRepo{},
+	}
+	d.tx = &mockTransaction{datastore: d}
+	return d
+}
+
+func (m *mockDatastore) UserRepo() ports.UserRepository             { return m.userRepo }
+func (m *mockDatastore) PermissionRepo() ports.PermissionRepository { return m.permissionRepo }
+func (m *mockDatastore) SessionRepo() ports.SessionRepository       { return m.sessionRepo }
+func (m *mockDatastore) ArticleRepo() ports.ArticleRepository       { return m.articleRepo }
+
+func (m *mockDatastore) Begin(ctx context.Context) (ports.Transaction, error) {
+	return m.tx, nil
+}
+
+func TestCreateUser_ActivatesAndGrantsPermissions(t *testing.T) {
+	datastore := newMockDatastore()
+	service := NewAdminService(datastore)
+
+	id, err := service.CreateUser(context.Background(), domain.User{Email: "admin@example.com"}, domain.Permissions{"articles:read", "articles:write"})
+	require.NoError(t, err)
+
+	assert.True(t, datastore.tx.committed)
+	assert.True(t, datastore.userRepo.users["admin@example.com"].Activated)
+	assert.Equal(t, domain.Permissions{"articles:read", "articles:write"}, datastore.permissionRepo.granted[id])
+}
+
+func TestCreateUser_UnknownPermissionIsNotCommitted(t *testing.T) {
+	datastore := newMockDatastore()
+	service := NewAdminService(datastore)
+
+	_, err := service.CreateUser(context.Background(), domain.User{Email: "admin@example.com"}, domain.Permissions{"unknown"})
+
+	assert.ErrorIs(t, err, domain.ErrPermissionNotFound)
+	assert.False(t, datastore.tx.committed)
+}
+
+func TestGrantPermission(t *testing.T) {
+	datastore := newMockDatastore()
+	service := NewAdminService(datastore)
+	id, err := service.CreateUser(context.Background(), domain.User{Email: "admin@example.com"}, nil)
+	require.NoError(t, err)
+
+	granted, err := service.GrantPermission(context.Background(), "admin@example.com", "articles:write")
+	require.NoError(t, err)
+	assert.True(t, granted)
+
+	granted, err = service.GrantPermission(context.Background(), "admin@example.com", "articles:write")
+	require.NoError(t, err)
+	assert.False(t, granted, "already granted")
+
+	assert.Equal(t, domain.Permissions{"articles:write"}, datastore.permissionRepo.granted[id])
+	assert.Empty(t, datastore.sessionRepo.revoked, "applies on the next login")
+}
+
+func TestGrantPermission_UnknownUser(t *testing.T) {
+	service := NewAdminService(newMockDatastore())
+
+	_, err := service.GrantPermission(context.Background(), "nobody@example.com", "articles:write")
+
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestRevokePermission_RevokesSessions(t *testing.T) {
+	datastore := newMockDatastore()
+	service := NewAdminService(datastore)
+	id, err := service.CreateUser(context.Background(), domain.User{Email: "admin@example.com"}, domain.Permissions{"articles:write"})
+	require.NoError(t, err)
+
+	revoked, err := service.RevokePermission(context.Background(), "admin@example.com", "articles:write")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	assert.Equal(t, []int{id}, datastore.sessionRepo.revoked)
+
+	revoked, err = service.RevokePermission(context.Background(), "admin@example.com", "articles:write")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+	assert.Equal(t, []int{id}, datastore.sessionRepo.revoked, "nothing to revoke")
+}
+
+func TestDeactivateUser_RevokesSessions(t *testing.T) {
+	datastore := newMockDatastore()
+	service := NewAdminService(datastore)
+	id, err := service.CreateUser(context.Background(), domain.User{Email: "admin@example.com"}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, service.DeactivateUser(context.Background(), "admin@example.com"))
+
+	assert.False(t, datastore.userRepo.users["admin@example.com"].Activated)
+	assert.Equal(t, []int{id}, datastore.sessionRepo.revoked)
+}
+
+func TestImportArticle(t *testing.T) {
+	datastore := newMockDatastore()
+	service := NewAdminService(datastore)
+
+	created, err := service.ImportArticle(context.Background(), domain.Article{Slug: "draft"}, false)
+	require.NoError(t, err)
+	assert.True(t, created)
+	created, err = service.ImportArticle(context.Background(), domain.Article{Slug: "published"}, true)
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	assert.False(t, datastore.articleRepo.articles[0].IsPublished)
+	assert.True(t, datastore.articleRepo.articles[1].IsPublished)
+
+	// Running the import again updates the article and publishes it
+	created, err = service.ImportArticle(context.Background(), domain.Article{Slug: "draft", Title: "Updated"}, true)
+	require.NoError(t, err)
+	assert.False(t, created)
+	require.Len(t, datastore.articleRepo.articles, 2)
+	assert.Equal(t, "Updated", datastore.articleRepo.articles[0].Title)
+	assert.True(t, datastore.articleRepo.articles[0].IsPublished)
+}
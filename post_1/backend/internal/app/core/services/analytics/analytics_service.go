@@ -0,0 +1,211 @@
+package analytics
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"personal_website/config"
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/app/core/ports"
+	"sync"
+	"time"
+)
+
+// maxRangeDays bounds the date ranges of the statistics to a year.
+const maxRangeDays = 366
+
+type analyticsService struct {
+	config    *config.AnalyticsConfig
+	datastore ports.Datastore
+	logger    *slog.Logger
+	now       func() time.Time
+
+	mu      sync.Mutex
+	saltDay time.Time
+	salt    []byte
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewAnalyticsService creates the service and starts the background worker
+// flushing the view counters every FlushInterval. The counters not flushed
+// yet stay in Valkey, so stopping the process loses no views, and Close
+// flushes them for the statistics to be up to date.
+func NewAnalyticsService(cfg *config.AnalyticsConfig, datastore ports.Datastore, logger *slog.Logger) *analyticsService {
+	s := &analyticsService{
+		config:    cfg,
+		datastore: datastore,
+		logger:    logger,
+		now:       time.Now,
+	}
+
+	if cfg.FlushInterval > 0 {
+		s.stop = make(chan struct{})
+		s.done = make(chan struct{})
+		go s.flusher()
+	}
+
+	return s
+}
+
+func (s *analyticsService) RecordArticleView(ctx context.Context, articleID int32, clientIP string, userAgent string) (bool, error) {
+	day := domain.ViewDay(s.now())
+
+	salt, err := s.visitorSalt(ctx, day)
+	if err != nil {
+		return false, err
+	}
+
+	return s.datastore.ViewCounterRepo().RecordView(ctx, domain.ArticleView{
+		ArticleID:   articleID,
+		Day:         day,
+		VisitorHash: visitorHash(salt, clientIP, userAgent),
+	})
+}
+
+func (s *analyticsService) ArticleViews(ctx context.Context, articleID int32, dateRange domain.DateRange) ([]domain.ArticleViews, error) {
+	dateRange, err := normalizeRange(dateRange)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.datastore.ArticleRepo().GetArticleByID(ctx, articleID); err != nil {
+		return nil, err
+	}
+
+	stored, err := s.datastore.AnalyticsRepo().ListArticleViews(ctx, articleID, dateRange)
+	if err != nil {
+		return nil, err
+	}
+
+	// Days without views are not stored, fill them with zeros
+	viewsByDay := make(map[time.Time]int64, len(stored))
+	for _, views := range stored {
+		viewsByDay[views.Day] = views.Views
+	}
+
+	days := make([]domain.ArticleViews, 0, dateRange.Days())
+	for day := dateRange.From; !day.After(dateRange.To); day = day.AddDate(0, 0, 1) {
+		days = append(days, domain.ArticleViews{
+			ArticleID: articleID,
+			Day:       day,
+			Views:     viewsByDay[day],
+		})
+	}
+
+	return days, nil
+}
+
+func (s *analyticsService) TopArticles(ctx context.Context, dateRange domain.DateRange, limit int32) ([]domain.TopArticle, error) {
+	dateRange, err := normalizeRange(dateRange)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.datastore.AnalyticsRepo().ListTopArticles(ctx, dateRange, limit)
+}
+
+func (s *analyticsService) Flush(ctx context.Context) error {
+	views, err := s.datastore.ViewCounterRepo().DrainViews(ctx)
+	if err != nil {
+		return err
+	}
+	if len(views) == 0 {
+		return nil
+	}
+
+	err = s.datastore.AnalyticsRepo().AddArticleViews(ctx, views)
+	if err != nil {
+		// Put the views back so that the next flush retries them
+		if restoreErr := s.datastore.ViewCounterRepo().RestoreViews(ctx, views); restoreErr != nil {
+			s.logger.ErrorContext(ctx, "Failed to restore article views, they are lost", "count", len(views), "error", restoreErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Close stops the background worker, then flushes the views counted since its
+// last flush. It does nothing when the worker was not started.
+func (s *analyticsService) Close(ctx context.Context) error {
+	if s.stop == nil {
+		return nil
+	}
+
+	s.stopOnce.Do(func() { close(s.stop) })
+
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return s.Flush(ctx)
+}
+
+func (s *analyticsService) flusher() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.stop:
+			return
+		}
+
+		ctx := context.Background()
+		if err := s.Flush(ctx); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to flush article views", "error", err)
+		}
+	}
+}
+
+// visitorSalt returns the salt of day, shared by every instance through
+// Valkey and cached until the day changes.
+func (s *analyticsService) visitorSalt(ctx context.Context, day time.Time) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.salt != nil && s.saltDay.Equal(day) {
+		return s.salt, nil
+	}
+
+	salt, err := s.datastore.ViewCounterRepo().VisitorSalt(ctx, day)
+	if err != nil {
+		return nil, err
+	}
+
+	s.salt = salt
+	s.saltDay = day
+	return salt, nil
+}
+
+// visitorHash identifies a visitor for a day without keeping its IP address:
+// once the salt of the day expires, the hash cannot be recomputed.
+func visitorHash(salt []byte, clientIP string, userAgent string) string {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(clientIP))
+	mac.Write([]byte{0})
+	mac.Write([]byte(userAgent))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func normalizeRange(dateRange domain.DateRange) (domain.DateRange, error) {
+	dateRange = domain.DateRange{
+		From: domain.ViewDay(dateRange.From),
+		To:   domain.ViewDay(dateRange.To),
+	}
+
+	if dateRange.To.Before(dateRange.From) || dateRange.Days() > maxRangeDays {
+		return domain.DateRange{}, domain.ErrInvalidDateRange
+	}
+	return dateRange, nil
+}
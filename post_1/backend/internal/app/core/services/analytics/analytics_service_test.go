@@ -0,0 +1,233 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"personal_website/config"
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/app/core/ports"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockViewCounterRepo struct {
+	ports.ViewCounterRepository
+	salts    map[time.Time][]byte
+	visitors map[string]bool
+	pending  map[domain.ArticleView]int64
+	restored []domain.ArticleViews
+}
+
+func newMockViewCounterRepo() *mockViewCounterRepo {
+	return &mockViewCounterRepo{
+		salts:    make(map[time.Time][]byte),
+		visitors: make(map[string]bool),
+		pending:  make(map[domain.ArticleView]int64),
+	}
+}
+
+func (m *mockViewCounterRepo) VisitorSalt(ctx context.Context, day time.Time) ([]byte, error) {
+	if _, ok := m.salts[day]; !ok {
+		m.salts[day] = []byte(day.Format(domain.DayLayout))
+	}
+	return m.salts[day], nil
+}
+
+func (m *mockViewCounterRepo) RecordView(ctx context.Context, view domain.ArticleView) (bool, error) {
+	key := fmt.Sprintf("%s:%d:%s", view.Day.Format(domain.DayLayout), view.ArticleID, view.VisitorHash)
+	if m.visitors[key] {
+		return false, nil
+	}
+	m.visitors[key] = true
+	m.pending[domain.ArticleView{ArticleID: view.ArticleID, Day: view.Day}]++
+	return true, nil
+}
+
+func (m *mockViewCounterRepo) DrainViews(ctx context.Context) ([]domain.ArticleViews, error) {
+	var views []domain.ArticleViews
+	for key, count := range m.pending {
+		views = append(views, domain.ArticleViews{ArticleID: key.ArticleID, Day: key.Day, Views: count})
+	}
+	m.pending = make(map[domain.ArticleView]int64)
+	return views, nil
+}
+
+func (m *mockViewCounterRepo) RestoreViews(ctx context.Context, views []domain.ArticleViews) error {
+	m.restored = append(m.restored, views...)
+	return nil
+}
+
+type mockAnalyticsRepo struct {
+	ports.AnalyticsRepository
+	added  []domain.ArticleViews
+	stored []domain.ArticleViews
+	addErr error
+}
+
+func (m *mockAnalyticsRepo) AddArticleViews(ctx context.Context, views []domain.ArticleViews) error {
+	if m.addErr != nil {
+		return m.addErr
+	}
+	m.added = append(m.added, views...)
+	return nil
+}
+
+func (m *mockAnalyticsRepo) ListArticleViews(ctx context.Context, articleID int32, dateRange domain.DateRange) ([]domain.ArticleViews, error) {
+	return m.stored, nil
+}
+
+type mockArticleRepo struct {
+	ports.ArticleRepository
+}
+
+func (m *mockArticleRepo) GetArticleByID(ctx context.Context, id int32) (domain.Article, error) {
+	if id != 1 {
+		return domain.Article{}, domain.ErrArticleNotFound
+	}
+	return domain.Article{ID: id}, nil
+}
+
+type mockDatastore struct {
+	ports.Datastore
+	viewCounter   *mockViewCounterRepo
+	analyticsRepo *mockAnalyticsRepo
+}
+
+func (m *mockDatastore) ViewCounterRepo() ports.ViewCounterRepository { return m.viewCounter }
+func (m *mockDatastore) AnalyticsRepo() ports.AnalyticsRepository     { return m.analyticsRepo }
+func (m *mockDatastore) ArticleRepo() ports.ArticleRepository         { return &mockArticleRepo{} }
+
+func newTestService(datastore *mockDatastore, now *time.Time) *analyticsService {
+	service := NewAnalyticsService(&config.AnalyticsConfig{}, datastore, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	service.now = func() time.Time { return *now }
+	return service
+}
+
+func TestRecordArticleView_CountsVisitorsOncePerDay(t *testing.T) {
+	viewCounter := newMockViewCounterRepo()
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	service := newTestService(&mockDatastore{viewCounter: viewCounter}, &now)
+	ctx := context.Background()
+
+	counted, err := service.RecordArticleView(ctx, 1, "198.51.100.1", "Firefox")
+	require.NoError(t, err)
+	assert.True(t, counted)
+
+	counted, err = service.RecordArticleView(ctx, 1, "198.51.100.1", "Firefox")
+	require.NoError(t, err)
+	assert.False(t, counted, "same visitor on the same day")
+
+	counted, err = service.RecordArticleView(ctx, 1, "198.51.100.1", "Chrome")
+	require.NoError(t, err)
+	assert.True(t, counted, "another user agent is another visitor")
+
+	counted, err = service.RecordArticleView(ctx, 2, "198.51.100.1", "Firefox")
+	require.NoError(t, err)
+	assert.True(t, counted, "another article")
+
+	now = now.Add(24 * time.Hour)
+	counted, err = service.RecordArticleView(ctx, 1, "198.51.100.1", "Firefox")
+	require.NoError(t, err)
+	assert.True(t, counted, "same visitor on the next day")
+	assert.Len(t, viewCounter.salts, 2)
+
+	for key := range viewCounter.visitors {
+		assert.NotContains(t, key, "198.51.100.1")
+	}
+}
+
+func TestVisitorHash_DependsOnSalt(t *testing.T) {
+	hash := visitorHash([]byte("salt"), "198.51.100.1", "Firefox")
+
+	assert.Equal(t, hash, visitorHash([]byte("salt"), "198.51.100.1", "Firefox"))
+	assert.NotEqual(t, hash, visitorHash([]byte("other salt"), "198.51.100.1", "Firefox"))
+	assert.NotEqual(t, hash, visitorHash([]byte("salt"), "198.51.100.1F", "irefox"))
+}
+
+func TestFlush_PersistsPendingViews(t *testing.T) {
+	viewCounter := newMockViewCounterRepo()
+	analyticsRepo := &mockAnalyticsRepo{}
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	service := newTestService(&mockDatastore{viewCounter: viewCounter, analyticsRepo: analyticsRepo}, &now)
+	ctx := context.Background()
+
+	_, err := service.RecordArticleView(ctx, 1, "198.51.100.1", "Firefox")
+	require.NoError(t, err)
+	_, err = service.RecordArticleView(ctx, 1, "198.51.100.2", "Firefox")
+	require.NoError(t, err)
+
+	require.NoError(t, service.Flush(ctx))
+	assert.Equal(t, []domain.ArticleViews{{ArticleID: 1, Day: domain.ViewDay(now), Views: 2}}, analyticsRepo.added)
+
+	require.NoError(t, service.Flush(ctx))
+	assert.Len(t, analyticsRepo.added, 1, "nothing left to flush")
+}
+
+func TestFlush_RestoresViewsOnFailure(t *testing.T) {
+	viewCounter := newMockViewCounterRepo()
+	analyticsRepo := &mockAnalyticsRepo{addErr: errors.New("connection refused")}
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	service := newTestService(&mockDatastore{viewCounter: viewCounter, analyticsRepo: analyticsRepo}, &now)
+	ctx := context.Background()
+
+	_, err := service.RecordArticleView(ctx, 1, "198.51.100.1", "Firefox")
+	require.NoError(t, err)
+
+	err = service.Flush(ctx)
+	require.Error(t, err)
+	assert.Equal(t, []domain.ArticleViews{{ArticleID: 1, Day: domain.ViewDay(now), Views: 1}}, viewCounter.restored)
+}
+
+func TestClose_FlushesPendingViews(t *testing.T) {
+	viewCounter := newMockViewCounterRepo()
+	analyticsRepo := &mockAnalyticsRepo{}
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	service := NewAnalyticsService(&config.AnalyticsConfig{FlushInterval: time.Hour}, &mockDatastore{viewCounter: viewCounter, analyticsRepo: analyticsRepo}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	service.now = func() time.Time { return now }
+
+	_, err := service.RecordArticleView(context.Background(), 1, "198.51.100.1", "Firefox")
+	require.NoError(t, err)
+
+	require.NoError(t, service.Close(context.Background()))
+	assert.Equal(t, []domain.ArticleViews{{ArticleID: 1, Day: domain.ViewDay(now), Views: 1}}, analyticsRepo.added)
+}
+
+func TestArticleViews_FillsDaysWithoutViews(t *testing.T) {
+	may := func(day int) time.Time { return time.Date(2024, 5, day, 0, 0, 0, 0, time.UTC) }
+	analyticsRepo := &mockAnalyticsRepo{stored: []domain.ArticleViews{{ArticleID: 1, Day: may(2), Views: 7}}}
+	now := may(10)
+	service := newTestService(&mockDatastore{analyticsRepo: analyticsRepo}, &now)
+
+	views, err := service.ArticleViews(context.Background(), 1, domain.DateRange{From: may(1), To: may(3).Add(15 * time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ArticleViews{
+		{ArticleID: 1, Day: may(1), Views: 0},
+		{ArticleID: 1, Day: may(2), Views: 7},
+		{ArticleID: 1, Day: may(3), Views: 0},
+	}, views)
+}
+
+func TestArticleViews_Errors(t *testing.T) {
+	may := func(day int) time.Time { return time.Date(2024, 5, day, 0, 0, 0, 0, time.UTC) }
+	now := may(10)
+	service := newTestService(&mockDatastore{analyticsRepo: &mockAnalyticsRepo{}}, &now)
+	ctx := context.Background()
+
+	_, err := service.ArticleViews(ctx, 1, domain.DateRange{From: may(3), To: may(1)})
+	assert.ErrorIs(t, err, domain.ErrInvalidDateRange)
+
+	_, err = service.ArticleViews(ctx, 1, domain.DateRange{From: may(1).AddDate(-1, 0, 0), To: may(1)})
+	assert.ErrorIs(t, err, domain.ErrInvalidDateRange)
+
+	_, err = service.ArticleViews(ctx, 2, domain.DateRange{From: may(1), To: may(3)})
+	assert.ErrorIs(t, err, domain.ErrArticleNotFound)
+
+	_, err = service.TopArticles(ctx, domain.DateRange{From: may(3), To: may(1)}, 10)
+	assert.ErrorIs(t, err, domain.ErrInvalidDateRange)
+}
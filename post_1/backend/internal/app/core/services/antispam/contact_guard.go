@@ -0,0 +1,173 @@
+package antispam
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"math/bits"
+	"personal_website/config"
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/app/core/ports"
+	"personal_website/pkg/signing"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const challengeVersion = "v1"
+
+var linkPattern = regexp.MustCompile(`(?i)https?://|www\.`)
+
+type contactGuard struct {
+	config *config.ContactConfig
+	quotas ports.QuotaRepository
+	signer *signing.Signer
+	now    func() time.Time
+}
+
+func NewContactGuard(cfg *config.ContactConfig, quotas ports.QuotaRepository) (*contactGuard, error) {
+	var secret []byte
+	if cfg.ChallengeSecret != nil {
+		secret = cfg.ChallengeSecret.Bytes()
+	}
+
+	signer, err := signing.NewSigner(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate contact challenge secret: %w", err)
+	}
+
+	return &contactGuard{
+		config: cfg,
+		quotas: quotas,
+		signer: signer,
+		now:    time.Now,
+	}, nil
+}
+
+// IssueChallenge builds a token of the form v1.<issued_at>.<difficulty>.<id>.<signature>.
+// Nothing is stored: the signature lets Inspect trust the issue time and the
+// difficulty, which also gives us the time spent filling the form.
+func (g *contactGuard) IssueChallenge(ctx context.Context) (domain.ContactChallenge, error) {
+	issuedAt := g.now()
+	payload := strings.Join([]string{
+		challengeVersion,
+		strconv.FormatInt(issuedAt.Unix(), 10),
+		strconv.Itoa(g.config.ChallengeDifficulty),
+		rand.Text(),
+	}, ".")
+
+	return domain.ContactChallenge{
+		Token:      g.signer.Sign(payload),
+		Difficulty: g.config.ChallengeDifficulty,
+		ExpiresAt:  issuedAt.Add(g.config.ChallengeTTL),
+	}, nil
+}
+
+func (g *contactGuard) Inspect(ctx context.Context, submission domain.ContactSubmission) (domain.SpamReason, error) {
+	if strings.TrimSpace(submission.Honeypot) != "" {
+		return domain.SpamReasonHoneypot, nil
+	}
+
+	issuedAt, id, err := g.verifyChallenge(submission.Challenge, submission.Nonce)
+	if err != nil {
+		return domain.SpamReasonNone, err
+	}
+
+	if g.now().Sub(issuedAt) < g.config.MinFillTime {
+		return domain.SpamReasonTooFast, nil
+	}
+
+	uses, err := g.quotas.IncrementQuota(ctx, "contact:challenge:"+id, g.config.ChallengeTTL)
+	if err != nil {
+		return domain.SpamReasonNone, err
+	}
+	if uses > 1 {
+		return domain.SpamReasonReplay, nil
+	}
+
+	if err := g.checkQuota(ctx, "contact:ip:"+submission.ClientIP, g.config.IPQuota); err != nil {
+		return domain.SpamReasonNone, err
+	}
+	if err := g.checkQuota(ctx, "contact:email:"+strings.ToLower(submission.Email), g.config.EmailQuota); err != nil {
+		return domain.SpamReasonNone, err
+	}
+
+	return g.inspectContent(submission.ContactMessage), nil
+}
+
+func (g *contactGuard) verifyChallenge(token, nonce string) (time.Time, string, error) {
+	payload, ok := g.signer.Verify(token)
+	if !ok {
+		return time.Time{}, "", domain.ErrInvalidContactChallenge
+	}
+
+	parts := strings.Split(payload, ".")
+	if len(parts) != 4 || parts[0] != challengeVersion {
+		return time.Time{}, "", domain.ErrInvalidContactChallenge
+	}
+
+	unix, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return time.Time{}, "", domain.ErrInvalidContactChallenge
+	}
+	issuedAt := time.Unix(unix, 0)
+	if g.now().After(issuedAt.Add(g.config.ChallengeTTL)) {
+		return time.Time{}, "", domain.ErrInvalidContactChallenge
+	}
+
+	difficulty, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return time.Time{}, "", domain.ErrInvalidContactChallenge
+	}
+
+	hash := sha256.Sum256([]byte(token + ":" + nonce))
+	if leadingZeroBits(hash[:]) < difficulty {
+		return time.Time{}, "", domain.ErrInvalidContactChallenge
+	}
+
+	return issuedAt, parts[3], nil
+}
+
+func (g *contactGuard) checkQuota(ctx context.Context, key string, limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+
+	count, err := g.quotas.IncrementQuota(ctx, key, g.config.QuotaWindow)
+	if err != nil {
+		return err
+	}
+	if count > int64(limit) {
+		return domain.ErrContactQuotaExceeded
+	}
+
+	return nil
+}
+
+func (g *contactGuard) inspectContent(message domain.ContactMessage) domain.SpamReason {
+	if len(linkPattern.FindAllStringIndex(message.Message, -1)) > g.config.MaxLinks {
+		return domain.SpamReasonTooManyLinks
+	}
+
+	content := strings.ToLower(message.Name + " " + message.Message)
+	for _, keyword := range g.config.BlockedKeywords {
+		if strings.Contains(content, strings.ToLower(keyword)) {
+			return domain.SpamReasonBlockedKeyword
+		}
+	}
+
+	return domain.SpamReasonNone
+}
+
+func leadingZeroBits(hash []byte) int {
+	count := 0
+	for _, b := range hash {
+		if b != 0 {
+			return count + bits.LeadingZeros8(b)
+		}
+		count += 8
+	}
+	return count
+}
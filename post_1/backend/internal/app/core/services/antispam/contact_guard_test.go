@@ -0,0 +1,208 @@
+package antispam
+
+import (
+	"context"
+	"crypto/sha256"
+	"personal_website/config"
+	"personal_website/internal/app/core/domain"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/awnumar/memguard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockQuotaRepository struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func (m *mockQuotaRepository) IncrementQuota(ctx context.Context, key string, window time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.counts == nil {
+		m.counts = make(map[string]int64)
+	}
+	m.counts[key]++
+	return m.counts[key], nil
+}
+
+func newTestGuard(t *testing.T) (*contactGuard, *time.Time) {
+	t.Helper()
+
+	guard, err := NewContactGuard(&config.ContactConfig{
+		ChallengeSecret:     memguard.NewBufferFromBytes([]byte("test-secret")),
+		ChallengeDifficulty: 8,
+		ChallengeTTL:        time.Hour,
+		MinFillTime:         3 * time.Second,
+		MaxLinks:            1,
+		BlockedKeywords:     []string{"Casino"},
+		EmailQuota:          2,
+		IPQuota:             3,
+		QuotaWindow:         time.Hour,
+	}, &mockQuotaRepository{})
+	require.NoError(t, err)
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	guard.now = func() time.Time { return now }
+
+	return guard, &now
+}
+
+func solve(token string, difficulty int) string {
+	for i := 0; ; i++ {
+		nonce := strconv.Itoa(i)
+		hash := sha256.Sum256([]byte(token + ":" + nonce))
+		if leadingZeroBits(hash[:]) >= difficulty {
+			return nonce
+		}
+	}
+}
+
+func wrongNonce(token string, difficulty int) string {
+	for i := 0; ; i++ {
+		nonce := strconv.Itoa(i)
+		hash := sha256.Sum256([]byte(token + ":" + nonce))
+		if leadingZeroBits(hash[:]) < difficulty {
+			return nonce
+		}
+	}
+}
+
+func validSubmission(t *testing.T, guard *contactGuard, now *time.Time) domain.ContactSubmission {
+	t.Helper()
+
+	challenge, err := guard.IssueChallenge(context.Background())
+	require.NoError(t, err)
+	*now = now.Add(10 * time.Second)
+
+	return domain.ContactSubmission{
+		ContactMessage: domain.ContactMessage{
+			Name:    "John Doe",
+			Email:   "john.doe@example.com",
+			Message: "Hello, I enjoyed your last article.",
+		},
+		Challenge: challenge.Token,
+		Nonce:     solve(challenge.Token, challenge.Difficulty),
+		ClientIP:  "192.0.2.1",
+	}
+}
+
+func TestInspect_AcceptsValidSubmission(t *testing.T) {
+	guard, now := newTestGuard(t)
+
+	reason, err := guard.Inspect(context.Background(), validSubmission(t, guard, now))
+	require.NoError(t, err)
+	assert.Equal(t, domain.SpamReasonNone, reason)
+}
+
+func TestInspect_DiscardsSpam(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(s *domain.ContactSubmission, now *time.Time)
+		reason domain.SpamReason
+	}{
+		{
+			name:   "honeypot filled",
+			modify: func(s *domain.ContactSubmission, _ *time.Time) { s.Honeypot = "https://spam.example.com" },
+			reason: domain.SpamReasonHoneypot,
+		},
+		{
+			name:   "submitted too fast",
+			modify: func(_ *domain.ContactSubmission, now *time.Time) { *now = now.Add(-9 * time.Second) },
+			reason: domain.SpamReasonTooFast,
+		},
+		{
+			name: "too many links",
+			modify: func(s *domain.ContactSubmission, _ *time.Time) {
+				s.Message = "Visit https://a.example.com and www.b.example.com"
+			},
+			reason: domain.SpamReasonTooManyLinks,
+		},
+		{
+			name:   "blocked keyword",
+			modify: func(s *domain.ContactSubmission, _ *time.Time) { s.Message = "Best online casino bonus" },
+			reason: domain.SpamReasonBlockedKeyword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard, now := newTestGuard(t)
+			submission := validSubmission(t, guard, now)
+			tt.modify(&submission, now)
+
+			reason, err := guard.Inspect(context.Background(), submission)
+			require.NoError(t, err)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+}
+
+func TestInspect_DiscardsReplayedChallenge(t *testing.T) {
+	guard, now := newTestGuard(t)
+	submission := validSubmission(t, guard, now)
+
+	reason, err := guard.Inspect(context.Background(), submission)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SpamReasonNone, reason)
+
+	reason, err = guard.Inspect(context.Background(), submission)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SpamReasonReplay, reason)
+}
+
+func TestInspect_RejectsInvalidChallenge(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(s *domain.ContactSubmission, now *time.Time)
+	}{
+		{
+			name:   "missing challenge",
+			modify: func(s *domain.ContactSubmission, _ *time.Time) { s.Challenge = "" },
+		},
+		{
+			name:   "tampered signature",
+			modify: func(s *domain.ContactSubmission, _ *time.Time) { s.Challenge += "0" },
+		},
+		{
+			name:   "wrong nonce",
+			modify: func(s *domain.ContactSubmission, _ *time.Time) { s.Nonce = wrongNonce(s.Challenge, 8) },
+		},
+		{
+			name:   "expired",
+			modify: func(_ *domain.ContactSubmission, now *time.Time) { *now = now.Add(2 * time.Hour) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard, now := newTestGuard(t)
+			submission := validSubmission(t, guard, now)
+			tt.modify(&submission, now)
+
+			_, err := guard.Inspect(context.Background(), submission)
+			assert.ErrorIs(t, err, domain.ErrInvalidContactChallenge)
+		})
+	}
+}
+
+func TestInspect_EnforcesQuotas(t *testing.T) {
+	guard, now := newTestGuard(t)
+
+	for i := 0; i < 2; i++ {
+		_, err := guard.Inspect(context.Background(), validSubmission(t, guard, now))
+		require.NoError(t, err)
+	}
+
+	_, err := guard.Inspect(context.Background(), validSubmission(t, guard, now))
+	assert.ErrorIs(t, err, domain.ErrContactQuotaExceeded, "email quota")
+
+	submission := validSubmission(t, guard, now)
+	submission.Email = "someone.else@example.com"
+	_, err = guard.Inspect(context.Background(), submission)
+	assert.ErrorIs(t, err, domain.ErrContactQuotaExceeded, "ip quota")
+}
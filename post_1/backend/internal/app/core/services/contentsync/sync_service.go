@@ -0,0 +1,275 @@
+package contentsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/app/core/ports"
+	domain_validation "personal_website/internal/app/core/validation"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type syncService struct {
+	source    ports.ContentSource
+	datastore ports.Datastore
+	logger    *slog.Logger
+
+	// mu prevents two syncs of this instance from racing on the same articles
+	mu sync.Mutex
+}
+
+// NewSyncService creates the service, a nil source disables the sync.
+func NewSyncService(source ports.ContentSource, datastore ports.Datastore, logger *slog.Logger) *syncService {
+	return &syncService{
+		source:    source,
+		datastore: datastore,
+		logger:    logger,
+	}
+}
+
+// Sync compares the files of the repository to the articles by content hash.
+// A file keeps its article by path: changing its slug renames the article,
+// and a file moved with its slug keeps its article too. The repository wins:
+// a file restores its synced article from the trash, and an article created
+// through the API with the same slug as a new file is taken over by the sync.
+// The articles whose file could not be read are left untouched.
+func (s *syncService) Sync(ctx context.Context, dryRun bool) (domain.SyncReport, error) {
+	if s.source == nil {
+		return domain.SyncReport{}, domain.ErrSyncDisabled
+	}
+	if !s.mu.TryLock() {
+		return domain.SyncReport{}, domain.ErrSyncInProgress
+	}
+	defer s.mu.Unlock()
+
+	snapshot, err := s.source.Snapshot(ctx)
+	if err != nil {
+		return domain.SyncReport{}, domain.NewInternalError(fmt.Errorf("reading the content repository: %w", err))
+	}
+
+	repo := s.datastore.ArticleRepo()
+	sources, err := repo.ListArticleSources(ctx)
+	if err != nil {
+		return domain.SyncReport{}, err
+	}
+	if len(snapshot.Files) == 0 && len(sources) > 0 {
+		return domain.SyncReport{}, domain.ErrSyncEmptySource
+	}
+
+	articles, err := repo.ListAllArticles(ctx)
+	if err != nil {
+		return domain.SyncReport{}, err
+	}
+
+	state := &syncState{
+		commit:        snapshot.Commit,
+		dryRun:        dryRun,
+		sourcesByPath: make(map[string]domain.ArticleSource, len(sources)),
+		sourcesBySlug: make(map[string]domain.ArticleSource, len(sources)),
+		paths:         make(map[string]bool, len(snapshot.Files)),
+		existing:      make(map[string]bool, len(articles)),
+		seenSlugs:     make(map[string]string),
+		claimed:       make(map[int32]bool),
+	}
+	for _, article := range articles {
+		state.existing[article.Slug] = true
+	}
+	for _, source := range sources {
+		state.sourcesByPath[source.Path] = source
+		state.sourcesBySlug[source.Slug] = source
+	}
+	for _, file := range snapshot.Files {
+		state.paths[file.Path] = true
+	}
+
+	files := snapshot.Files
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	// The files already synced go first, so that the slugs they give up can be
+	// taken by the new files
+	changes := make([]domain.SyncChange, len(files))
+	for _, synced := range []bool{true, false} {
+		for i, file := range files {
+			if _, ok := state.sourcesByPath[file.Path]; ok == synced {
+				changes[i] = s.syncFile(ctx, file, state)
+			}
+		}
+	}
+
+	report := domain.SyncReport{Commit: snapshot.Commit, DryRun: dryRun, Changes: changes}
+	failedPaths := make(map[string]bool)
+	for _, change := range changes {
+		if change.Action == domain.SyncFailed {
+			failedPaths[change.Path] = true
+		}
+	}
+
+	for _, source := range sources {
+		if state.claimed[source.ArticleID] || source.IsDeleted || failedPaths[source.Path] {
+			continue
+		}
+
+		change := domain.SyncChange{Action: domain.SyncDelete, Path: source.Path, Slug: source.Slug, ArticleID: source.ArticleID}
+		if !dryRun {
+			if err := repo.SoftDeleteArticle(ctx, source.ArticleID, 0); err != nil {
+				change = s.failed(ctx, change, err)
+			}
+		}
+		report.Changes = append(report.Changes, change)
+	}
+
+	s.logger.InfoContext(ctx, "Content synced",
+		"commit", report.Commit,
+		"dry_run", dryRun,
+		"created", report.Count(domain.SyncCreate),
+		"updated", report.Count(domain.SyncUpdate),
+		"deleted", report.Count(domain.SyncDelete),
+		"failed", report.Count(domain.SyncFailed),
+	)
+	return report, nil
+}
+
+// syncState is what a sync knows of the articles while it applies the files.
+type syncState struct {
+	commit string
+	dryRun bool
+
+	sourcesByPath map[string]domain.ArticleSource
+	sourcesBySlug map[string]domain.ArticleSource
+	// paths are the files of the snapshot
+	paths map[string]bool
+	// existing are the slugs of the articles not in the trash
+	existing map[string]bool
+	// seenSlugs maps the slugs of the files applied to their path
+	seenSlugs map[string]string
+	// claimed are the articles of the files applied, which are not deleted
+	claimed map[int32]bool
+}
+
+func (s *syncService) syncFile(ctx context.Context, file domain.SourceFile, state *syncState) domain.SyncChange {
+	change := domain.SyncChange{Path: file.Path, Slug: file.Article.Slug}
+	if file.Err != nil {
+		change.Action = domain.SyncFailed
+		change.Error = file.Err.Error()
+		return change
+	}
+
+	validator := domain_validation.NewArticleValidator()
+	if !validator.ValidateArticle(file.Article).Valid() {
+		change.Action = domain.SyncFailed
+		change.Error = strings.Join(validator.Messages(), "; ")
+		return change
+	}
+
+	slug := file.Article.Slug
+	if previous, ok := state.seenSlugs[slug]; ok {
+		change.Action = domain.SyncFailed
+		change.Error = fmt.Sprintf("the slug is already used by %s", previous)
+		return change
+	}
+	state.seenSlugs[slug] = file.Path
+
+	source, synced := state.sourcesByPath[file.Path]
+	if !synced {
+		// A file moved with its slug keeps its article, unless another file
+		// took the article's former path
+		moved, ok := state.sourcesBySlug[slug]
+		if ok && !state.paths[moved.Path] && !state.claimed[moved.ArticleID] {
+			source, synced = moved, true
+		} else if ok && state.existing[slug] {
+			// The file of the article gave up this slug, but failed to
+			// rename it
+			change.Action = domain.SyncFailed
+			change.Error = fmt.Sprintf("the slug is already used by %s", moved.Path)
+			return change
+		}
+	}
+	if synced {
+		state.claimed[source.ArticleID] = true
+	}
+
+	change.ArticleID = source.ArticleID
+	switch {
+	case synced && !source.IsDeleted && source.ContentHash == file.Hash:
+		change.Action = domain.SyncUnchanged
+		if source.Path == file.Path || state.dryRun {
+			return change
+		}
+		// The file was moved, the commit that changed the article is kept
+		source.Path = file.Path
+		if err := s.datastore.ArticleRepo().SaveArticleSource(ctx, source); err != nil {
+			return s.failed(ctx, change, err)
+		}
+		return change
+	case state.existing[slug] || synced:
+		change.Action = domain.SyncUpdate
+	default:
+		change.Action = domain.SyncCreate
+	}
+
+	renamed := synced && source.Slug != slug
+	if state.dryRun {
+		if renamed {
+			delete(state.existing, source.Slug)
+		}
+		state.existing[slug] = true
+		return change
+	}
+
+	repo := s.datastore.ArticleRepo()
+	if synced && source.IsDeleted {
+		if err := repo.RestoreArticle(ctx, source.ArticleID); err != nil {
+			return s.failed(ctx, change, err)
+		}
+	}
+
+	// The slug changed in the file: the article is renamed, and its former
+	// slug redirects to it
+	if renamed {
+		_, err := repo.UpdateArticle(ctx, domain.Article{
+			ID:      source.ArticleID,
+			Title:   file.Article.Title,
+			Slug:    slug,
+			Content: file.Article.Content,
+			Tags:    file.Article.Tags,
+		})
+		if err != nil {
+			return s.failed(ctx, change, err)
+		}
+		delete(state.existing, source.Slug)
+	}
+
+	id, _, err := repo.UpsertArticleBySlug(ctx, file.Article)
+	if err != nil {
+		return s.failed(ctx, change, err)
+	}
+	change.ArticleID = id
+	state.existing[slug] = true
+
+	err = repo.SaveArticleSource(ctx, domain.ArticleSource{
+		ArticleID:   id,
+		Path:        file.Path,
+		ContentHash: file.Hash,
+		CommitSHA:   state.commit,
+	})
+	if err != nil {
+		return s.failed(ctx, change, err)
+	}
+	return change
+}
+
+// failed reports the error of the change, the internal errors are logged and
+// their cause is not exposed.
+func (s *syncService) failed(ctx context.Context, change domain.SyncChange, err error) domain.SyncChange {
+	var domainErr domain.DomainError
+	if errors.As(err, &domainErr) && domainErr.Type == domain.ErrorTypeInternal {
+		s.logger.ErrorContext(ctx, "Failed to sync article", "path", change.Path, "slug", change.Slug, "action", change.Action, "error", domainErr.Underlying)
+	}
+	change.Action = domain.SyncFailed
+	change.Error = err.Error()
+	return change
+}
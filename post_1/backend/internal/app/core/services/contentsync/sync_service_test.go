@@ -0,0 +1,327 @@
+package contentsync
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/app/core/ports"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSource struct {
+	snapshot domain.ContentSnapshot
+}
+
+func (m *mockSource) Snapshot(ctx context.Context) (domain.ContentSnapshot, error) {
+	return m.snapshot, nil
+}
+
+type storedArticle struct {
+	article domain.Article
+	deleted bool
+	source  *domain.ArticleSource
+}
+
+// mockArticleRepo keeps the articles by ID, like the upsert by slug of
+// Postgres.
+type mockArticleRepo struct {
+	ports.ArticleRepository
+	articles map[int32]*storedArticle
+	nextID   int32
+	upserts  int
+}
+
+func newMockArticleRepo() *mockArticleRepo {
+	return &mockArticleRepo{articles: make(map[int32]*storedArticle), nextID: 1}
+}
+
+func (m *mockArticleRepo) add(article domain.Article, source *domain.ArticleSource) int32 {
+	id := m.nextID
+	m.nextID++
+	article.ID = id
+	if source != nil {
+		source.ArticleID = id
+	}
+	m.articles[id] = &storedArticle{article: article, source: source}
+	return id
+}
+
+func (m *mockArticleRepo) bySlug(slug string) *storedArticle {
+	for _, stored := range m.articles {
+		if stored.article.Slug == slug {
+			return stored
+		}
+	}
+	return nil
+}
+
+func (m *mockArticleRepo) ListAllArticles(ctx context.Context) ([]domain.Article, error) {
+	var articles []domain.Article
+	for _, stored := range m.articles {
+		if !stored.deleted {
+			articles = append(articles, stored.article)
+		}
+	}
+	return articles, nil
+}
+
+func (m *mockArticleRepo) ListArticleSources(ctx context.Context) ([]domain.ArticleSource, error) {
+	var sources []domain.ArticleSource
+	for _, stored := range m.articles {
+		if stored.source != nil {
+			source := *stored.source
+			source.Slug = stored.article.Slug
+			source.IsDeleted = stored.deleted
+			sources = append(sources, source)
+		}
+	}
+	return sources, nil
+}
+
+func (m *mockArticleRepo) SaveArticleSource(ctx context.Context, source domain.ArticleSource) error {
+	m.articles[source.ArticleID].source = &source
+	return nil
+}
+
+func (m *mockArticleRepo) UpsertArticleBySlug(ctx context.Context, article domain.Article) (int32, bool, error) {
+	m.upserts++
+	stored := m.bySlug(article.Slug)
+	if stored == nil {
+		return m.add(article, nil), true, nil
+	}
+	if stored.deleted {
+		return 0, false, domain.ErrArticleInTrash
+	}
+	article.ID = stored.article.ID
+	stored.article = article
+	return article.ID, false, nil
+}
+
+func (m *mockArticleRepo) UpdateArticle(ctx context.Context, article domain.Article) (int32, error) {
+	if other := m.bySlug(article.Slug); other != nil && other.article.ID != article.ID {
+		return 0, domain.ErrArticleAlreadyExists
+	}
+	stored := m.articles[article.ID]
+	stored.article.Title = article.Title
+	stored.article.Slug = article.Slug
+	stored.article.Content = article.Content
+	return stored.article.Version + 1, nil
+}
+
+func (m *mockArticleRepo) SoftDeleteArticle(ctx context.Context, id int32, version int32) error {
+	m.articles[id].deleted = true
+	return nil
+}
+
+func (m *mockArticleRepo) RestoreArticle(ctx context.Context, id int32) error {
+	m.articles[id].deleted = false
+	return nil
+}
+
+type mockDatastore struct {
+	ports.Datastore
+	articleRepo *mockArticleRepo
+}
+
+func (m *mockDatastore) ArticleRepo() ports.ArticleRepository { return m.articleRepo }
+
+func newTestService(source ports.ContentSource, repo *mockArticleRepo) *syncService {
+	return NewSyncService(source, &mockDatastore{articleRepo: repo}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func file(path, slug, hash string) domain.SourceFile {
+	return domain.SourceFile{Path: path, Hash: hash, Article: domain.Article{
+		Title:   "Article " + slug,
+		Slug:    slug,
+		Content: "The content of the article, long enough to be a valid one.",
+	}}
+}
+
+func actions(report domain.SyncReport) map[string]domain.SyncAction {
+	result := make(map[string]domain.SyncAction)
+	for _, change := range report.Changes {
+		result[change.Path] = change.Action
+	}
+	return result
+}
+
+func TestSync_CreatesUpdatesAndDeletes(t *testing.T) {
+	repo := newMockArticleRepo()
+	repo.add(domain.Article{Slug: "unchanged"}, &domain.ArticleSource{Path: "unchanged.md", ContentHash: "h1", CommitSHA: "old"})
+	repo.add(domain.Article{Slug: "edited"}, &domain.ArticleSource{Path: "edited.md", ContentHash: "h2", CommitSHA: "old"})
+	removedID := repo.add(domain.Article{Slug: "removed"}, &domain.ArticleSource{Path: "removed.md", ContentHash: "h3", CommitSHA: "old"})
+	apiID := repo.add(domain.Article{Slug: "written-in-the-api"}, nil)
+
+	source := &mockSource{snapshot: domain.ContentSnapshot{Commit: "new", Files: []domain.SourceFile{
+		file("unchanged.md", "unchanged", "h1"),
+		file("edited.md", "edited", "h2-edited"),
+		file("new.md", "new", "h4"),
+	}}}
+	service := newTestService(source, repo)
+
+	report, err := service.Sync(context.Background(), false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "new", report.Commit)
+	assert.Equal(t, map[string]domain.SyncAction{
+		"unchanged.md": domain.SyncUnchanged,
+		"edited.md":    domain.SyncUpdate,
+		"new.md":       domain.SyncCreate,
+		"removed.md":   domain.SyncDelete,
+	}, actions(report))
+
+	assert.True(t, repo.articles[removedID].deleted)
+	assert.False(t, repo.articles[apiID].deleted, "articles without source are not deleted")
+	assert.Equal(t, "old", repo.bySlug("unchanged").source.CommitSHA)
+	assert.Equal(t, "new", repo.bySlug("edited").source.CommitSHA)
+	assert.Equal(t, "h2-edited", repo.bySlug("edited").source.ContentHash)
+	assert.Equal(t, "new", repo.bySlug("new").source.CommitSHA)
+	assert.Equal(t, 2, repo.upserts)
+}
+
+func TestSync_DryRunChangesNothing(t *testing.T) {
+	repo := newMockArticleRepo()
+	removedID := repo.add(domain.Article{Slug: "removed"}, &domain.ArticleSource{Path: "removed.md", ContentHash: "h1"})
+	repo.add(domain.Article{Slug: "existing"}, nil)
+
+	source := &mockSource{snapshot: domain.ContentSnapshot{Commit: "new", Files: []domain.SourceFile{
+		file("existing.md", "existing", "h2"),
+		file("new.md", "new", "h3"),
+	}}}
+	service := newTestService(source, repo)
+
+	report, err := service.Sync(context.Background(), true)
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, map[string]domain.SyncAction{
+		"existing.md": domain.SyncUpdate,
+		"new.md":      domain.SyncCreate,
+		"removed.md":  domain.SyncDelete,
+	}, actions(report))
+	assert.Zero(t, repo.upserts)
+	assert.False(t, repo.articles[removedID].deleted)
+	assert.Nil(t, repo.bySlug("existing").source)
+}
+
+func TestSync_RestoresDeletedArticle(t *testing.T) {
+	repo := newMockArticleRepo()
+	id := repo.add(domain.Article{Slug: "back"}, &domain.ArticleSource{Path: "back.md", ContentHash: "h1"})
+	repo.articles[id].deleted = true
+
+	source := &mockSource{snapshot: domain.ContentSnapshot{Commit: "new", Files: []domain.SourceFile{file("back.md", "back", "h1")}}}
+	service := newTestService(source, repo)
+
+	report, err := service.Sync(context.Background(), false)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]domain.SyncAction{"back.md": domain.SyncUpdate}, actions(report))
+	assert.False(t, repo.articles[id].deleted)
+}
+
+func TestSync_KeepsArticlesOfFailedFiles(t *testing.T) {
+	repo := newMockArticleRepo()
+	id := repo.add(domain.Article{Slug: "broken"}, &domain.ArticleSource{Path: "broken.md", ContentHash: "h1"})
+
+	source := &mockSource{snapshot: domain.ContentSnapshot{Commit: "new", Files: []domain.SourceFile{
+		{Path: "broken.md", Hash: "h2", Err: errors.New("invalid front matter")},
+		file("first.md", "same-slug", "h3"),
+		file("second.md", "same-slug", "h4"),
+	}}}
+	service := newTestService(source, repo)
+
+	report, err := service.Sync(context.Background(), false)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]domain.SyncAction{
+		"broken.md": domain.SyncFailed,
+		"first.md":  domain.SyncCreate,
+		"second.md": domain.SyncFailed,
+	}, actions(report))
+	assert.False(t, repo.articles[id].deleted, "the article of an unreadable file is kept")
+	assert.Equal(t, 2, report.Count(domain.SyncFailed))
+}
+
+func TestSync_RejectsInvalidArticles(t *testing.T) {
+	repo := newMockArticleRepo()
+
+	invalid := file("invalid.md", "invalid", "h1")
+	invalid.Article.Content = "Too short"
+	service := newTestService(&mockSource{snapshot: domain.ContentSnapshot{Commit: "new", Files: []domain.SourceFile{invalid}}}, repo)
+
+	report, err := service.Sync(context.Background(), false)
+	require.NoError(t, err)
+
+	require.Len(t, report.Changes, 1)
+	assert.Equal(t, domain.SyncFailed, report.Changes[0].Action)
+	assert.Equal(t, "content must be at least 50 characters", report.Changes[0].Error)
+	assert.Zero(t, repo.upserts)
+}
+
+func TestSync_RenamesArticleWhenTheSlugChanges(t *testing.T) {
+	repo := newMockArticleRepo()
+	id := repo.add(domain.Article{Slug: "old-slug"}, &domain.ArticleSource{Path: "post.md", ContentHash: "h1"})
+	takenID := repo.add(domain.Article{Slug: "taken"}, &domain.ArticleSource{Path: "taken.md", ContentHash: "h2"})
+
+	source := &mockSource{snapshot: domain.ContentSnapshot{Commit: "new", Files: []domain.SourceFile{
+		file("post.md", "new-slug", "h1-edited"),
+		// A new file takes the slug the other file gave up
+		file("a-new.md", "taken", "h3"),
+		file("taken.md", "renamed", "h2-edited"),
+	}}}
+	service := newTestService(source, repo)
+
+	report, err := service.Sync(context.Background(), false)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]domain.SyncAction{
+		"post.md":  domain.SyncUpdate,
+		"a-new.md": domain.SyncCreate,
+		"taken.md": domain.SyncUpdate,
+	}, actions(report))
+	assert.Equal(t, "new-slug", repo.articles[id].article.Slug, "the article is renamed, not replaced")
+	assert.False(t, repo.articles[id].deleted)
+	assert.Equal(t, "renamed", repo.articles[takenID].article.Slug)
+	assert.NotEqual(t, takenID, repo.bySlug("taken").article.ID)
+	assert.Len(t, repo.articles, 3)
+}
+
+func TestSync_KeepsArticleOfMovedFile(t *testing.T) {
+	repo := newMockArticleRepo()
+	id := repo.add(domain.Article{Slug: "moved"}, &domain.ArticleSource{Path: "drafts/moved.md", ContentHash: "h1", CommitSHA: "old"})
+
+	source := &mockSource{snapshot: domain.ContentSnapshot{Commit: "new", Files: []domain.SourceFile{
+		file("posts/moved.md", "moved", "h1"),
+	}}}
+	service := newTestService(source, repo)
+
+	report, err := service.Sync(context.Background(), false)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]domain.SyncAction{"posts/moved.md": domain.SyncUnchanged}, actions(report))
+	assert.False(t, repo.articles[id].deleted)
+	assert.Equal(t, "posts/moved.md", repo.articles[id].source.Path)
+	assert.Equal(t, "old", repo.articles[id].source.CommitSHA)
+}
+
+func TestSync_RefusesEmptySource(t *testing.T) {
+	repo := newMockArticleRepo()
+	repo.add(domain.Article{Slug: "synced"}, &domain.ArticleSource{Path: "synced.md", ContentHash: "h1"})
+
+	service := newTestService(&mockSource{snapshot: domain.ContentSnapshot{Commit: "new"}}, repo)
+
+	_, err := service.Sync(context.Background(), false)
+	assert.ErrorIs(t, err, domain.ErrSyncEmptySource)
+}
+
+func TestSync_Disabled(t *testing.T) {
+	service := newTestService(nil, newMockArticleRepo())
+
+	_, err := service.Sync(context.Background(), false)
+	assert.ErrorIs(t, err, domain.ErrSyncDisabled)
+}
@@ -1,36 +1,56 @@
 package mailer
 
 import (
-	"bytes"
 	"context"
 	"embed"
 	"fmt"
-	"html/template"
+	"io/fs"
 	"log/slog"
 	"net/smtp"
 	"personal_website/config"
 	"personal_website/internal/app/core/domain"
 	"personal_website/internal/app/core/ports"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //go:embed templates/*.html
 var templateFS embed.FS
 
+var tracer = otel.Tracer("personal_website/internal/app/core/services/mailer")
+
 type EmailService struct {
 	emailSender ports.EmailSender
 	config      *config.SMTPConfig
 	logger      *slog.Logger
-	templates   *template.Template
+	templates   *templateStore
 }
 
 func NewService(cfg *config.SMTPConfig, emailSender ports.EmailSender, logger *slog.Logger) (*EmailService, error) {
-	templates, err := template.ParseFS(templateFS, "templates/*.html")
+	embedded, err := fs.Sub(templateFS, "templates")
+	if err != nil {
+		return &EmailService{}, err
+	}
+
+	templates, err := loadTemplates(embedded, cfg.TemplateDir, cfg.DefaultLocale)
 	if err != nil {
 		logger.Error("Failed to parse email templates", "error", err)
 		return &EmailService{}, err
 	}
 
+	if err := templates.validate(); err != nil {
+		logger.Error("Email template validation failed", "error", err)
+		return &EmailService{}, err
+	}
+
+	if cfg.TemplateDir != "" {
+		logger.Info("Email templates loaded with overrides", "template_dir", cfg.TemplateDir)
+	}
+
 	return &EmailService{
 		config:      cfg,
 		emailSender: emailSender,
@@ -69,6 +89,23 @@ func (s *EmailService) setupSMTP() (*smtpConfig, error) {
 	}, nil
 }
 
+// sendMail sends msg through the SMTP server inside a client span, the
+// EmailSender port being unaware of the context.
+func (s *EmailService) sendMail(ctx context.Context, smtpCfg *smtpConfig, auth smtp.Auth, recipient string, msg []byte) error {
+	_, span := tracer.Start(ctx, "smtp send",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("server.address", smtpCfg.serverAddr)),
+	)
+	defer span.End()
+
+	err := s.emailSender.SendMail(smtpCfg.serverAddr, auth, smtpCfg.verifiedSender, []string{recipient}, msg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
 func (s *EmailService) buildHTMLEmail(to, subject, htmlContent string) []byte {
 	return []byte("From: " + "" + "\r\n" +
 		"To: " + to + "\r\n" +
@@ -82,55 +119,48 @@ func (s *EmailService) buildHTMLEmail(to, subject, htmlContent string) []byte {
 func (s *EmailService) SendContactEmail(ctx context.Context, form domain.ContactMessage) error {
 	smtpCfg, err := s.setupSMTP()
 	if err != nil {
-		s.logger.Error("SMTP configuration incomplete", "error", err)
+		s.logger.ErrorContext(ctx, "SMTP configuration incomplete", "error", err)
 		return domain.ErrEmailConfigurationMissing
 	}
 
 	recipient := s.config.Recipient.String()
 	if recipient == "" {
 		err := fmt.Errorf("missing recipient configuration")
-		s.logger.Error("Recipient configuration incomplete", "error", err)
+		s.logger.ErrorContext(ctx, "Recipient configuration incomplete", "error", err)
 		return domain.ErrEmailConfigurationMissing
 	}
 
-	s.logger.Info("Preparing to send contact email",
+	s.logger.InfoContext(ctx, "Preparing to send contact email",
 		"server", smtpCfg.serverAddr,
 		"recipient", recipient,
 		"sender_email", form.Email,
 	)
 
-	templateData := struct {
-		Name      string
-		Email     string
-		Message   string
-		Timestamp string
-	}{
+	templateData := contactTemplateData{
 		Name:      form.Name,
 		Email:     form.Email,
 		Message:   form.Message,
 		Timestamp: time.Now().Format("2006-01-02 15:04:05 MST"),
 	}
 
-	var htmlBuffer bytes.Buffer
-	if s.templates != nil {
-		err = s.templates.ExecuteTemplate(&htmlBuffer, "contact.html", templateData)
-		if err != nil {
-			s.logger.Error("Failed to execute contact template", "error", err)
-			return domain.ErrEmailTemplateFailed
-		}
-	} else {
+	if s.templates == nil {
+		return domain.ErrEmailTemplateFailed
+	}
+
+	subject, body, err := s.templates.render("contact", s.config.DefaultLocale, templateData)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to execute contact template", "error", err)
 		return domain.ErrEmailTemplateFailed
 	}
 
-	subject := fmt.Sprintf("New Contact Form Submission from %s", form.Name)
-	msg := s.buildHTMLEmail(recipient, subject, htmlBuffer.String())
+	msg := s.buildHTMLEmail(recipient, subject, body)
 
 	auth := smtp.PlainAuth("", smtpCfg.username, smtpCfg.password, smtpCfg.host)
-	s.logger.Info("Attempting to send contact email", "server", smtpCfg.serverAddr, "to", recipient)
+	s.logger.InfoContext(ctx, "Attempting to send contact email", "server", smtpCfg.serverAddr, "to", recipient)
 
-	err = s.emailSender.SendMail(smtpCfg.serverAddr, auth, smtpCfg.verifiedSender, []string{recipient}, msg)
+	err = s.sendMail(ctx, smtpCfg, auth, recipient, msg)
 	if err != nil {
-		s.logger.Error("Failed to send contact email via SMTP",
+		s.logger.ErrorContext(ctx, "Failed to send contact email via SMTP",
 			"error", err,
 			"server", smtpCfg.serverAddr,
 			"from", smtpCfg.verifiedSender,
@@ -140,55 +170,50 @@ func (s *EmailService) SendContactEmail(ctx context.Context, form domain.Contact
 		return domain.ErrEmailSendFailed
 	}
 
-	s.logger.Info("Contact email sent successfully", "recipient", recipient, "from", smtpCfg.verifiedSender, "reply_to", form.Email)
+	s.logger.InfoContext(ctx, "Contact email sent successfully", "recipient", recipient, "from", smtpCfg.verifiedSender, "reply_to", form.Email)
 	return nil
 }
 
-func (s *EmailService) SendActivationEmail(ctx context.Context, activationToken string, recipientEmail string, baseURL string) error {
+func (s *EmailService) SendActivationEmail(ctx context.Context, activationToken string, recipientEmail string, baseURL string, locale string) error {
 	smtpCfg, err := s.setupSMTP()
 	if err != nil {
-		s.logger.Error("SMTP configuration incomplete", "error", err)
+		s.logger.ErrorContext(ctx, "SMTP configuration incomplete", "error", err)
 		return domain.ErrEmailConfigurationMissing
 	}
 
-	s.logger.Info("Preparing to send activation email",
+	s.logger.InfoContext(ctx, "Preparing to send activation email",
 		"server", smtpCfg.serverAddr,
 		"recipient", recipientEmail,
 		"base_url", baseURL,
+		"locale", locale,
 	)
 
 	activationURL := fmt.Sprintf("%s?token=%s", baseURL, activationToken)
 
-	templateData := struct {
-		Token         string
-		Email         string
-		ActivationURL string
-	}{
+	templateData := activationTemplateData{
 		Token:         activationToken,
 		Email:         recipientEmail,
 		ActivationURL: activationURL,
 	}
 
-	var htmlBuffer bytes.Buffer
-	if s.templates != nil {
-		err = s.templates.ExecuteTemplate(&htmlBuffer, "activation.html", templateData)
-		if err != nil {
-			s.logger.Error("Failed to execute activation template", "error", err)
-			return domain.ErrEmailTemplateFailed
-		}
-	} else {
+	if s.templates == nil {
 		return domain.ErrEmailTemplateFailed
 	}
 
-	subject := "Activate Your Account"
-	msg := s.buildHTMLEmail(recipientEmail, subject, htmlBuffer.String())
+	subject, body, err := s.templates.render("activation", locale, templateData)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to execute activation template", "error", err)
+		return domain.ErrEmailTemplateFailed
+	}
+
+	msg := s.buildHTMLEmail(recipientEmail, subject, body)
 
 	auth := smtp.PlainAuth("", smtpCfg.username, smtpCfg.password, smtpCfg.host)
-	s.logger.Info("Attempting to send activation email", "server", smtpCfg.serverAddr, "to", recipientEmail)
+	s.logger.InfoContext(ctx, "Attempting to send activation email", "server", smtpCfg.serverAddr, "to", recipientEmail)
 
-	err = s.emailSender.SendMail(smtpCfg.serverAddr, auth, smtpCfg.verifiedSender, []string{recipientEmail}, msg)
+	err = s.sendMail(ctx, smtpCfg, auth, recipientEmail, msg)
 	if err != nil {
-		s.logger.Error("Failed to send activation email via SMTP",
+		s.logger.ErrorContext(ctx, "Failed to send activation email via SMTP",
 			"error", err,
 			"server", smtpCfg.serverAddr,
 			"from", smtpCfg.verifiedSender,
@@ -197,62 +222,55 @@ func (s *EmailService) SendActivationEmail(ctx context.Context, activationToken
 		return domain.ErrEmailSendFailed
 	}
 
-	s.logger.Info("Activation email sent successfully", "recipient", recipientEmail, "from", smtpCfg.verifiedSender, "activation_url", activationURL)
+	s.logger.InfoContext(ctx, "Activation email sent successfully", "recipient", recipientEmail, "from", smtpCfg.verifiedSender, "activation_url", activationURL)
 	return nil
 }
 
 func (s *EmailService) SendNewUserNotification(ctx context.Context, user *domain.User) error {
 	smtpCfg, err := s.setupSMTP()
 	if err != nil {
-		s.logger.Error("SMTP configuration incomplete", "error", err)
+		s.logger.ErrorContext(ctx, "SMTP configuration incomplete", "error", err)
 		return domain.ErrEmailConfigurationMissing
 	}
 
 	recipient := s.config.Recipient.String()
 	if recipient == "" {
 		err := fmt.Errorf("missing recipient configuration")
-		s.logger.Error("Recipient configuration incomplete", "error", err)
+		s.logger.ErrorContext(ctx, "Recipient configuration incomplete", "error", err)
 		return domain.ErrEmailConfigurationMissing
 	}
 
-	s.logger.Info("Preparing to send new user notification email",
+	s.logger.InfoContext(ctx, "Preparing to send new user notification email",
 		"server", smtpCfg.serverAddr,
 		"recipient", recipient,
 		"new_user_email", user.Email,
 	)
 
-	templateData := struct {
-		Username         string
-		Email            string
-		RegistrationDate string
-		NotificationTime string
-	}{
+	templateData := newUserTemplateData{
 		Username:         user.Name,
 		Email:            user.Email,
 		RegistrationDate: user.CreatedAt.Format("2006-01-02 15:04:05 MST"),
 		NotificationTime: time.Now().Format("2006-01-02 15:04:05 MST"),
 	}
 
-	var htmlBuffer bytes.Buffer
-	if s.templates != nil {
-		err = s.templates.ExecuteTemplate(&htmlBuffer, "new_user.html", templateData)
-		if err != nil {
-			s.logger.Error("Failed to execute new user template", "error", err)
-			return domain.ErrEmailTemplateFailed
-		}
-	} else {
+	if s.templates == nil {
 		return domain.ErrEmailTemplateFailed
 	}
 
-	subject := fmt.Sprintf("New User Activated: %s", user.Name)
-	msg := s.buildHTMLEmail(recipient, subject, htmlBuffer.String())
+	subject, body, err := s.templates.render("new_user", s.config.DefaultLocale, templateData)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to execute new user template", "error", err)
+		return domain.ErrEmailTemplateFailed
+	}
+
+	msg := s.buildHTMLEmail(recipient, subject, body)
 
 	auth := smtp.PlainAuth("", smtpCfg.username, smtpCfg.password, smtpCfg.host)
-	s.logger.Info("Attempting to send new user notification email", "server", smtpCfg.serverAddr, "to", recipient)
+	s.logger.InfoContext(ctx, "Attempting to send new user notification email", "server", smtpCfg.serverAddr, "to", recipient)
 
-	err = s.emailSender.SendMail(smtpCfg.serverAddr, auth, smtpCfg.verifiedSender, []string{recipient}, msg)
+	err = s.sendMail(ctx, smtpCfg, auth, recipient, msg)
 	if err != nil {
-		s.logger.Error("Failed to send new user notification email via SMTP",
+		s.logger.ErrorContext(ctx, "Failed to send new user notification email via SMTP",
 			"error", err,
 			"server", smtpCfg.serverAddr,
 			"from", smtpCfg.verifiedSender,
@@ -261,6 +279,89 @@ func (s *EmailService) SendNewUserNotification(ctx context.Context, user *domain
 		return domain.ErrEmailSendFailed
 	}
 
-	s.logger.Info("New user notification email sent successfully", "recipient", recipient, "from", smtpCfg.verifiedSender, "new_user", user.Email)
+	s.logger.InfoContext(ctx, "New user notification email sent successfully", "recipient", recipient, "from", smtpCfg.verifiedSender, "new_user", user.Email)
+	return nil
+}
+
+func (s *EmailService) SendNewsletterConfirmation(ctx context.Context, recipientEmail string, confirmationURL string, locale string) error {
+	smtpCfg, err := s.setupSMTP()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "SMTP configuration incomplete", "error", err)
+		return domain.ErrEmailConfigurationMissing
+	}
+
+	templateData := newsletterConfirmationTemplateData{
+		Email:           recipientEmail,
+		ConfirmationURL: confirmationURL,
+	}
+
+	if s.templates == nil {
+		return domain.ErrEmailTemplateFailed
+	}
+
+	subject, body, err := s.templates.render("newsletter_confirmation", locale, templateData)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to execute newsletter confirmation template", "error", err)
+		return domain.ErrEmailTemplateFailed
+	}
+
+	msg := s.buildHTMLEmail(recipientEmail, subject, body)
+
+	auth := smtp.PlainAuth("", smtpCfg.username, smtpCfg.password, smtpCfg.host)
+	s.logger.InfoContext(ctx, "Attempting to send newsletter confirmation email", "server", smtpCfg.serverAddr, "to", recipientEmail)
+
+	err = s.sendMail(ctx, smtpCfg, auth, recipientEmail, msg)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to send newsletter confirmation email via SMTP",
+			"error", err,
+			"server", smtpCfg.serverAddr,
+			"recipient", recipientEmail,
+		)
+		return domain.ErrEmailSendFailed
+	}
+
+	s.logger.InfoContext(ctx, "Newsletter confirmation email sent successfully", "recipient", recipientEmail)
+	return nil
+}
+
+func (s *EmailService) SendArticleNotification(ctx context.Context, notification domain.ArticleNotification) error {
+	smtpCfg, err := s.setupSMTP()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "SMTP configuration incomplete", "error", err)
+		return domain.ErrEmailConfigurationMissing
+	}
+
+	templateData := newArticleTemplateData{
+		Title:          notification.Title,
+		ArticleURL:     notification.ArticleURL,
+		UnsubscribeURL: notification.UnsubscribeURL,
+	}
+
+	if s.templates == nil {
+		return domain.ErrEmailTemplateFailed
+	}
+
+	subject, body, err := s.templates.render("new_article", notification.Locale, templateData)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to execute new article template", "error", err)
+		return domain.ErrEmailTemplateFailed
+	}
+
+	// List-Unsubscribe lets mail clients offer their own unsubscribe button
+	msg := append([]byte("List-Unsubscribe: <"+notification.UnsubscribeURL+">\r\n"),
+		s.buildHTMLEmail(notification.Recipient, subject, body)...)
+
+	auth := smtp.PlainAuth("", smtpCfg.username, smtpCfg.password, smtpCfg.host)
+
+	err = s.sendMail(ctx, smtpCfg, auth, notification.Recipient, msg)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to send new article email via SMTP",
+			"error", err,
+			"server", smtpCfg.serverAddr,
+			"recipient", notification.Recipient,
+		)
+		return domain.ErrEmailSendFailed
+	}
+
 	return nil
 }
@@ -82,7 +82,7 @@ func TestSendActivationEmail(t *testing.T) {
 	recipientEmail := "user@example.com"
 	baseURL := "http://localhost:8080"
 
-	err := sender.SendActivationEmail(context.Background(), activationToken, recipientEmail, baseURL)
+	err := sender.SendActivationEmail(context.Background(), activationToken, recipientEmail, baseURL, "en")
 	assert.NoError(t, err)
 
 	assert.Len(t, mockSender.Calls, 1)
@@ -0,0 +1,234 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// subjectBlock is the name of the block every email template must define to
+// provide its subject line, e.g. {{define "subject"}}Activate Your Account{{end}}.
+const subjectBlock = "subject"
+
+type contactTemplateData struct {
+	Name      string
+	Email     string
+	Message   string
+	Timestamp string
+}
+
+type activationTemplateData struct {
+	Token         string
+	Email         string
+	ActivationURL string
+}
+
+type newUserTemplateData struct {
+	Username         string
+	Email            string
+	RegistrationDate string
+	NotificationTime string
+}
+
+type newsletterConfirmationTemplateData struct {
+	Email           string
+	ConfirmationURL string
+}
+
+type newArticleTemplateData struct {
+	Title          string
+	ArticleURL     string
+	UnsubscribeURL string
+}
+
+// sampleTemplateData holds the data used to render every known template at
+// startup. A template file whose name is not listed here is rejected.
+var sampleTemplateData = map[string]any{
+	"contact": contactTemplateData{
+		Name:      "Jane Doe",
+		Email:     "jane.doe@example.com",
+		Message:   "Hello, this is a sample message.",
+		Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).Format("2006-01-02 15:04:05 MST"),
+	},
+	"activation": activationTemplateData{
+		Token:         "SAMPLETOKEN",
+		Email:         "jane.doe@example.com",
+		ActivationURL: "https://example.com/activate?token=SAMPLETOKEN",
+	},
+	"new_user": newUserTemplateData{
+		Username:         "Jane Doe",
+		Email:            "jane.doe@example.com",
+		RegistrationDate: "2024-01-01 12:00:00 UTC",
+		NotificationTime: "2024-01-01 12:05:00 UTC",
+	},
+	"newsletter_confirmation": newsletterConfirmationTemplateData{
+		Email:           "jane.doe@example.com",
+		ConfirmationURL: "https://example.com/newsletter/confirm?token=SAMPLETOKEN",
+	},
+	"new_article": newArticleTemplateData{
+		Title:          "A sample article",
+		ArticleURL:     "https://example.com/blog/a-sample-article",
+		UnsubscribeURL: "https://example.com/newsletter/unsubscribe?token=SAMPLETOKEN",
+	},
+}
+
+type templateStore struct {
+	defaultLocale string
+	// templates maps a template name to its locale variants. The variant
+	// without locale suffix is stored under the empty key.
+	templates map[string]map[string]*template.Template
+}
+
+// loadTemplates parses the embedded templates, letting files from overrideDir
+// (when set) replace embedded files with the same name or add new locale
+// variants. Files are named <template>.html or <template>.<locale>.html.
+func loadTemplates(embedded fs.FS, overrideDir string, defaultLocale string) (*templateStore, error) {
+	sources := make(map[string]fs.FS)
+
+	if err := collectTemplateFiles(embedded, sources); err != nil {
+		return nil, fmt.Errorf("failed to read embedded email templates: %w", err)
+	}
+
+	if overrideDir != "" {
+		if err := collectTemplateFiles(os.DirFS(overrideDir), sources); err != nil {
+			return nil, fmt.Errorf("failed to read email templates from %s: %w", overrideDir, err)
+		}
+	}
+
+	store := &templateStore{
+		defaultLocale: normalizeLocale(defaultLocale),
+		templates:     make(map[string]map[string]*template.Template),
+	}
+
+	for filename, fsys := range sources {
+		name, locale := splitTemplateFilename(filename)
+
+		if _, known := sampleTemplateData[name]; !known {
+			return nil, fmt.Errorf("unknown email template %q", filename)
+		}
+
+		content, err := fs.ReadFile(fsys, filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read email template %q: %w", filename, err)
+		}
+
+		tmpl, err := template.New(filename).Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse email template %q: %w", filename, err)
+		}
+
+		if store.templates[name] == nil {
+			store.templates[name] = make(map[string]*template.Template)
+		}
+		store.templates[name][locale] = tmpl
+	}
+
+	return store, nil
+}
+
+func collectTemplateFiles(fsys fs.FS, sources map[string]fs.FS) error {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".html" {
+			continue
+		}
+		sources[entry.Name()] = fsys
+	}
+
+	return nil
+}
+
+func splitTemplateFilename(filename string) (name, locale string) {
+	base := strings.TrimSuffix(filename, ".html")
+	name, locale, _ = strings.Cut(base, ".")
+	return name, normalizeLocale(locale)
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
+
+// validate renders every template variant with sample data so that broken
+// templates are caught at startup rather than when the first email is sent.
+func (ts *templateStore) validate() error {
+	for name, variants := range ts.templates {
+		if _, ok := variants[""]; !ok {
+			return fmt.Errorf("email template %q has no default variant (%s.html)", name, name)
+		}
+
+		for _, tmpl := range variants {
+			if _, _, err := ts.execute(tmpl, sampleTemplateData[name]); err != nil {
+				return err
+			}
+		}
+	}
+
+	for name := range sampleTemplateData {
+		if _, ok := ts.templates[name]; !ok {
+			return fmt.Errorf("missing email template %q", name)
+		}
+	}
+
+	return nil
+}
+
+// render executes the best matching variant of a template: the exact locale,
+// then its base language (fr-ca -> fr), then the default locale and finally
+// the variant without locale suffix.
+func (ts *templateStore) render(name, locale string, data any) (subject string, body string, err error) {
+	variants, ok := ts.templates[name]
+	if !ok {
+		return "", "", fmt.Errorf("email template %q not found", name)
+	}
+
+	return ts.execute(ts.resolve(variants, locale), data)
+}
+
+func (ts *templateStore) resolve(variants map[string]*template.Template, locale string) *template.Template {
+	locale = normalizeLocale(locale)
+	base, _, _ := strings.Cut(locale, "-")
+
+	for _, candidate := range []string{locale, base, ts.defaultLocale} {
+		if candidate == "" {
+			continue
+		}
+		if tmpl, ok := variants[candidate]; ok {
+			return tmpl
+		}
+	}
+
+	return variants[""]
+}
+
+func (ts *templateStore) execute(tmpl *template.Template, data any) (string, string, error) {
+	if tmpl.Lookup(subjectBlock) == nil {
+		return "", "", fmt.Errorf("email template %q does not define a %q block", tmpl.Name(), subjectBlock)
+	}
+
+	var subjectBuffer bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subjectBuffer, subjectBlock, data); err != nil {
+		return "", "", fmt.Errorf("failed to render subject of email template %q: %w", tmpl.Name(), err)
+	}
+
+	subject := strings.Join(strings.Fields(html.UnescapeString(subjectBuffer.String())), " ")
+	if subject == "" {
+		return "", "", fmt.Errorf("email template %q has an empty subject", tmpl.Name())
+	}
+
+	var bodyBuffer bytes.Buffer
+	if err := tmpl.Execute(&bodyBuffer, data); err != nil {
+		return "", "", fmt.Errorf("failed to render email template %q: %w", tmpl.Name(), err)
+	}
+
+	return subject, bodyBuffer.String(), nil
+}
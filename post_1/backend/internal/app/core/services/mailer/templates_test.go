@@ -0,0 +1,109 @@
+package mailer
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func embeddedTemplates(t *testing.T) fs.FS {
+	t.Helper()
+	sub, err := fs.Sub(templateFS, "templates")
+	require.NoError(t, err)
+	return sub
+}
+
+func TestEmbeddedTemplatesAreValid(t *testing.T) {
+	store, err := loadTemplates(embeddedTemplates(t), "", "en")
+	require.NoError(t, err)
+	assert.NoError(t, store.validate())
+}
+
+func TestRenderSelectsLocaleVariant(t *testing.T) {
+	store, err := loadTemplates(embeddedTemplates(t), "", "en")
+	require.NoError(t, err)
+
+	data := sampleTemplateData["activation"]
+
+	tests := []struct {
+		locale  string
+		subject string
+	}{
+		{locale: "fr", subject: "Activez votre compte"},
+		{locale: "fr-CA", subject: "Activez votre compte"},
+		{locale: "de", subject: "Activate Your Account"},
+		{locale: "", subject: "Activate Your Account"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			subject, body, err := store.render("activation", tt.locale, data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.subject, subject)
+			assert.Contains(t, body, "https://example.com/activate?token=SAMPLETOKEN")
+		})
+	}
+}
+
+func TestRenderSubjectIsSingleLine(t *testing.T) {
+	store, err := loadTemplates(embeddedTemplates(t), "", "en")
+	require.NoError(t, err)
+
+	subject, _, err := store.render("contact", "en", contactTemplateData{Name: "Eve\r\nBcc: victim@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "New Contact Form Submission from Eve Bcc: victim@example.com", subject)
+}
+
+func TestOverrideDirReplacesEmbeddedTemplate(t *testing.T) {
+	dir := t.TempDir()
+	override := `{{define "subject"}}Custom activation{{end}}<a href="{{.ActivationURL}}">go</a>`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "activation.html"), []byte(override), 0o600))
+
+	store, err := loadTemplates(embeddedTemplates(t), dir, "en")
+	require.NoError(t, err)
+	require.NoError(t, store.validate())
+
+	subject, body, err := store.render("activation", "en", sampleTemplateData["activation"])
+	require.NoError(t, err)
+	assert.Equal(t, "Custom activation", subject)
+	assert.Contains(t, body, `<a href="https://example.com/activate?token=SAMPLETOKEN">go</a>`)
+
+	// Embedded locale variants are still available.
+	subject, _, err = store.render("activation", "fr", sampleTemplateData["activation"])
+	require.NoError(t, err)
+	assert.Equal(t, "Activez votre compte", subject)
+}
+
+func TestInvalidTemplatesAreRejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		loadErr  bool
+	}{
+		{name: "unknown template", filename: "welcome.html", content: `{{define "subject"}}Hi{{end}}`, loadErr: true},
+		{name: "syntax error", filename: "activation.html", content: `{{define "subject"}}Hi{{end}}{{.ActivationURL`, loadErr: true},
+		{name: "missing subject", filename: "activation.html", content: `<p>{{.ActivationURL}}</p>`},
+		{name: "empty subject", filename: "activation.html", content: `{{define "subject"}} {{end}}<p>hi</p>`},
+		{name: "unknown field", filename: "contact.de.html", content: `{{define "subject"}}Hallo{{end}}{{.Missing}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, tt.filename), []byte(tt.content), 0o600))
+
+			store, err := loadTemplates(embeddedTemplates(t), dir, "en")
+			if tt.loadErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Error(t, store.validate())
+		})
+	}
+}
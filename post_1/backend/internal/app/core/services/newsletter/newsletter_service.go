@@ -0,0 +1,310 @@
+package newsletter
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"personal_website/config"
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/app/core/ports"
+	"personal_website/pkg/signing"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	unsubscribePrefix = "unsubscribe."
+	cooldownPrefix    = "newsletter:confirmation:"
+	queueSize         = 100
+)
+
+type newsletterService struct {
+	config       *config.NewsletterConfig
+	emailService ports.EmailService
+	datastore    ports.Datastore
+	signer       *signing.Signer
+	logger       *slog.Logger
+	sleep        func(context.Context, time.Duration)
+
+	mu     sync.Mutex
+	closed bool
+	queue  chan int32
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewNewsletterService creates the service and starts the background worker
+// sending the new article emails. The worker first resumes the announcements
+// interrupted by the previous run, then waits for the published articles
+// until Close is called.
+func NewNewsletterService(cfg *config.NewsletterConfig, emailService ports.EmailService, datastore ports.Datastore, logger *slog.Logger) (*newsletterService, error) {
+	var secret []byte
+	if cfg.UnsubscribeSecret != nil {
+		secret = cfg.UnsubscribeSecret.Bytes()
+	}
+
+	signer, err := signing.NewSigner(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate newsletter unsubscribe secret: %w", err)
+	}
+
+	s := &newsletterService{
+		config:       cfg,
+		emailService: emailService,
+		datastore:    datastore,
+		signer:       signer,
+		logger:       logger,
+		sleep:        sleep,
+		queue:        make(chan int32, queueSize),
+		done:         make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	go s.worker(ctx)
+
+	return s, nil
+}
+
+func (s *newsletterService) Subscribe(ctx context.Context, email string, language string) error {
+	// Keeps the endpoint from flooding an address it does not own with
+	// confirmation emails. The previous token stays valid in the meantime.
+	if s.config.ResendCooldown > 0 {
+		count, err := s.datastore.QuotaRepo().IncrementQuota(ctx, cooldownPrefix+strings.ToLower(email), s.config.ResendCooldown)
+		if err != nil {
+			return err
+		}
+		if count > 1 {
+			return nil
+		}
+	}
+
+	token := domain.GenerateTokenWithTTL(0, domain.ScopeNewsletter, s.config.TokenTTL)
+
+	_, err := s.datastore.SubscriberRepo().UpsertSubscriber(ctx, domain.Subscriber{
+		Email:             email,
+		Language:          language,
+		ConfirmationToken: token,
+	})
+	if err != nil {
+		// Answer as if the subscription was new so that the endpoint does not
+		// reveal who is subscribed.
+		if errors.Is(err, domain.ErrSubscriberAlreadyConfirmed) {
+			return nil
+		}
+		return err
+	}
+
+	confirmationURL := fmt.Sprintf("%s?token=%s", s.config.ConfirmationURL, token.Plaintext)
+	return s.emailService.SendNewsletterConfirmation(ctx, email, confirmationURL, language)
+}
+
+func (s *newsletterService) Confirm(ctx context.Context, tokenPlaintext string) (domain.Subscriber, error) {
+	hash := sha256.Sum256([]byte(tokenPlaintext))
+	return s.datastore.SubscriberRepo().ConfirmSubscriber(ctx, hash[:])
+}
+
+// Unsubscribe accepts the tokens of every email sent to the subscriber, so
+// they never expire. A token names the subscriber ID, which is not reused
+// after unsubscribing, and rotating the unsubscribe secret revokes them all.
+func (s *newsletterService) Unsubscribe(ctx context.Context, token string) error {
+	payload, ok := s.signer.Verify(token)
+	if !ok || !strings.HasPrefix(payload, unsubscribePrefix) {
+		return domain.ErrInvalidNewsletterToken
+	}
+
+	id, err := strconv.ParseInt(strings.TrimPrefix(payload, unsubscribePrefix), 10, 32)
+	if err != nil {
+		return domain.ErrInvalidNewsletterToken
+	}
+
+	err = s.datastore.SubscriberRepo().DeleteSubscriber(ctx, int32(id))
+	// Unsubscribing twice is not an error
+	if err != nil && !errors.Is(err, domain.ErrSubscriberNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *newsletterService) ListSubscribers(ctx context.Context) ([]domain.Subscriber, error) {
+	return s.datastore.SubscriberRepo().ListSubscribers(ctx)
+}
+
+func (s *newsletterService) NotifyArticlePublished(ctx context.Context, articleID int32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		s.logger.WarnContext(ctx, "Newsletter is shutting down, publish the article again to announce it", "article_id", articleID)
+		return
+	}
+
+	select {
+	case s.queue <- articleID:
+	default:
+		s.logger.ErrorContext(ctx, "Newsletter queue is full, article will not be announced", "article_id", articleID)
+	}
+}
+
+// Close stops accepting articles and waits for the worker to announce the
+// queued ones. When ctx ends first the worker is interrupted: the announcement
+// in progress is resumed on the next start, the articles still queued have to
+// be published again.
+func (s *newsletterService) Close(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		return ctx.Err()
+	}
+}
+
+func (s *newsletterService) worker(ctx context.Context) {
+	defer close(s.done)
+
+	pending, err := s.datastore.SubscriberRepo().ListPendingArticleNotifications(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list the interrupted article announcements", "error", err)
+	}
+	for _, articleID := range pending {
+		s.sendArticleNotifications(ctx, articleID)
+	}
+
+	for articleID := range s.queue {
+		if ctx.Err() != nil {
+			s.logger.WarnContext(ctx, "Newsletter stopped before announcing the article, publish it again to announce it", "article_id", articleID)
+			continue
+		}
+		s.sendArticleNotifications(ctx, articleID)
+	}
+}
+
+// sendArticleNotifications emails every confirmed subscriber in batches of
+// BatchSize, pausing BatchInterval between batches to stay below the SMTP
+// provider's sending limits. Each subscriber is recorded before being emailed,
+// so an interrupted announcement resumes without emailing anyone twice. The
+// announcement is complete once no email failed.
+func (s *newsletterService) sendArticleNotifications(ctx context.Context, articleID int32) {
+	article, err := s.datastore.ArticleRepo().GetArticleByID(ctx, articleID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to load article for newsletter", "article_id", articleID, "error", err)
+		return
+	}
+
+	if !article.IsPublished {
+		return
+	}
+
+	claimed, err := s.datastore.SubscriberRepo().ClaimArticleNotification(ctx, articleID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to claim article notification", "article_id", articleID, "error", err)
+		return
+	}
+	if !claimed {
+		s.logger.InfoContext(ctx, "Article already announced to subscribers", "article_id", articleID)
+		return
+	}
+
+	batchSize := max(s.config.BatchSize, 1)
+	articleURL := strings.TrimSuffix(s.config.ArticleURL, "/") + "/" + url.PathEscape(article.Slug)
+
+	var afterID int32
+	sent, failed := 0, 0
+	complete := true
+	for {
+		subscribers, err := s.datastore.SubscriberRepo().ListConfirmedSubscribers(ctx, afterID, int32(batchSize))
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Failed to list newsletter subscribers", "article_id", articleID, "error", err)
+			complete = false
+			break
+		}
+
+		for _, subscriber := range subscribers {
+			if ctx.Err() != nil {
+				break
+			}
+
+			delivered, err := s.sendArticleNotification(ctx, article, articleURL, subscriber)
+			if err != nil {
+				failed++
+				continue
+			}
+			if delivered {
+				sent++
+			}
+		}
+
+		if ctx.Err() != nil || len(subscribers) < batchSize {
+			break
+		}
+
+		afterID = subscribers[len(subscribers)-1].ID
+		s.sleep(ctx, s.config.BatchInterval)
+	}
+
+	if !complete || failed > 0 || ctx.Err() != nil {
+		s.logger.WarnContext(ctx, "Article partially announced to newsletter subscribers, the rest is sent on the next start", "article_id", articleID, "sent", sent, "failed", failed)
+		return
+	}
+
+	if err := s.datastore.SubscriberRepo().CompleteArticleNotification(ctx, articleID); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to complete article notification", "article_id", articleID, "error", err)
+	}
+
+	s.logger.InfoContext(ctx, "Article announced to newsletter subscribers", "article_id", articleID, "sent", sent, "failed", failed)
+}
+
+// sendArticleNotification emails the article to a subscriber not emailed yet,
+// and reports whether an email was sent.
+func (s *newsletterService) sendArticleNotification(ctx context.Context, article domain.Article, articleURL string, subscriber domain.Subscriber) (bool, error) {
+	claimed, err := s.datastore.SubscriberRepo().ClaimArticleDelivery(ctx, article.ID, subscriber.ID)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	err = s.emailService.SendArticleNotification(ctx, domain.ArticleNotification{
+		Recipient:      subscriber.Email,
+		Locale:         subscriber.Language,
+		Title:          article.Title,
+		ArticleURL:     articleURL,
+		UnsubscribeURL: fmt.Sprintf("%s?token=%s", s.config.UnsubscribeURL, s.unsubscribeToken(subscriber.ID)),
+	})
+	if err != nil {
+		// Released even when shutting down, so that the next attempt retries it
+		if err := s.datastore.SubscriberRepo().ReleaseArticleDelivery(context.WithoutCancel(ctx), article.ID, subscriber.ID); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to release article delivery", "article_id", article.ID, "subscriber_id", subscriber.ID, "error", err)
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *newsletterService) unsubscribeToken(subscriberID int32) string {
+	return s.signer.Sign(unsubscribePrefix + strconv.Itoa(int(subscriberID)))
+}
+
+// sleep pauses for d, or until ctx ends.
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,320 @@
+package newsletter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"personal_website/config"
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/app/core/ports"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/awnumar/memguard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockEmailService struct {
+	ports.EmailService
+	mu            sync.Mutex
+	confirmations []string
+	notifications []domain.ArticleNotification
+	failing       map[string]bool
+}
+
+func (m *mockEmailService) SendNewsletterConfirmation(ctx context.Context, recipientEmail, confirmationURL, locale string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.confirmations = append(m.confirmations, confirmationURL)
+	return nil
+}
+
+func (m *mockEmailService) SendArticleNotification(ctx context.Context, notification domain.ArticleNotification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failing[notification.Recipient] {
+		return errors.New("mailbox unavailable")
+	}
+	m.notifications = append(m.notifications, notification)
+	return nil
+}
+
+type mockSubscriberRepo struct {
+	ports.SubscriberRepository
+	mu          sync.Mutex
+	subscribers []domain.Subscriber
+	upserted    []domain.Subscriber
+	upsertErr   error
+	deleted     []int32
+	claimed     map[int32]bool
+	completed   map[int32]bool
+	delivered   map[[2]int32]bool
+}
+
+func (m *mockSubscriberRepo) UpsertSubscriber(ctx context.Context, subscriber domain.Subscriber) (int32, error) {
+	if m.upsertErr != nil {
+		return 0, m.upsertErr
+	}
+	m.upserted = append(m.upserted, subscriber)
+	return 1, nil
+}
+
+func (m *mockSubscriberRepo) DeleteSubscriber(ctx context.Context, id int32) error {
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+
+func (m *mockSubscriberRepo) ListConfirmedSubscribers(ctx context.Context, afterID int32, limit int32) ([]domain.Subscriber, error) {
+	var page []domain.Subscriber
+	for _, subscriber := range m.subscribers {
+		if subscriber.ID > afterID && len(page) < int(limit) {
+			page = append(page, subscriber)
+		}
+	}
+	return page, nil
+}
+
+func (m *mockSubscriberRepo) ClaimArticleNotification(ctx context.Context, articleID int32) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.claimed == nil {
+		m.claimed = make(map[int32]bool)
+	}
+	m.claimed[articleID] = true
+	return !m.completed[articleID], nil
+}
+
+func (m *mockSubscriberRepo) CompleteArticleNotification(ctx context.Context, articleID int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.completed == nil {
+		m.completed = make(map[int32]bool)
+	}
+	m.completed[articleID] = true
+	return nil
+}
+
+func (m *mockSubscriberRepo) ListPendingArticleNotifications(ctx context.Context) ([]int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var pending []int32
+	for articleID := range m.claimed {
+		if !m.completed[articleID] {
+			pending = append(pending, articleID)
+		}
+	}
+	return pending, nil
+}
+
+func (m *mockSubscriberRepo) ClaimArticleDelivery(ctx context.Context, articleID int32, subscriberID int32) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.delivered == nil {
+		m.delivered = make(map[[2]int32]bool)
+	}
+	key := [2]int32{articleID, subscriberID}
+	if m.delivered[key] {
+		return false, nil
+	}
+	m.delivered[key] = true
+	return true, nil
+}
+
+func (m *mockSubscriberRepo) ReleaseArticleDelivery(ctx context.Context, articleID int32, subscriberID int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.delivered, [2]int32{articleID, subscriberID})
+	return nil
+}
+
+type mockQuotaRepo struct {
+	ports.QuotaRepository
+	counts map[string]int64
+}
+
+func (m *mockQuotaRepo) IncrementQuota(ctx context.Context, key string, window time.Duration) (int64, error) {
+	if m.counts == nil {
+		m.counts = make(map[string]int64)
+	}
+	m.counts[key]++
+	return m.counts[key], nil
+}
+
+type mockArticleRepo struct {
+	ports.ArticleRepository
+	article domain.Article
+}
+
+func (m *mockArticleRepo) GetArticleByID(ctx context.Context, id int32) (domain.Article, error) {
+	return m.article, nil
+}
+
+type mockDatastore struct {
+	ports.Datastore
+	subscriberRepo *mockSubscriberRepo
+	articleRepo    *mockArticleRepo
+	quotaRepo      mockQuotaRepo
+}
+
+func (m *mockDatastore) SubscriberRepo() ports.SubscriberRepository { return m.subscriberRepo }
+func (m *mockDatastore) ArticleRepo() ports.ArticleRepository       { return m.articleRepo }
+func (m *mockDatastore) QuotaRepo() ports.QuotaRepository           { return &m.quotaRepo }
+
+func newTestService(t *testing.T, datastore *mockDatastore) (*newsletterService, *mockEmailService, *[]time.Duration) {
+	t.Helper()
+
+	emailService := &mockEmailService{}
+	service, err := NewNewsletterService(&config.NewsletterConfig{
+		ConfirmationURL:   "https://example.com/newsletter/confirm",
+		UnsubscribeURL:    "https://example.com/newsletter/unsubscribe",
+		ArticleURL:        "https://example.com/blog/",
+		UnsubscribeSecret: memguard.NewBufferFromBytes([]byte("test-secret")),
+		TokenTTL:          time.Hour,
+		ResendCooldown:    time.Minute,
+		BatchSize:         2,
+		BatchInterval:     time.Second,
+	}, emailService, datastore, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { service.Close(context.Background()) })
+
+	var pauses []time.Duration
+	service.sleep = func(ctx context.Context, d time.Duration) { pauses = append(pauses, d) }
+
+	return service, emailService, &pauses
+}
+
+func TestSubscribe_SendsConfirmationEmail(t *testing.T) {
+	repo := &mockSubscriberRepo{}
+	service, emailService, _ := newTestService(t, &mockDatastore{subscriberRepo: repo})
+
+	err := service.Subscribe(context.Background(), "reader@example.com", "fr")
+	require.NoError(t, err)
+
+	require.Len(t, repo.upserted, 1)
+	token := repo.upserted[0].ConfirmationToken
+	require.NotNil(t, token)
+	assert.Equal(t, "fr", repo.upserted[0].Language)
+
+	require.Len(t, emailService.confirmations, 1)
+	assert.Equal(t, "https://example.com/newsletter/confirm?token="+token.Plaintext, emailService.confirmations[0])
+}
+
+func TestSubscribe_ResendCooldown(t *testing.T) {
+	repo := &mockSubscriberRepo{}
+	datastore := &mockDatastore{subscriberRepo: repo}
+	service, emailService, _ := newTestService(t, datastore)
+
+	require.NoError(t, service.Subscribe(context.Background(), "reader@example.com", "en"))
+	require.NoError(t, service.Subscribe(context.Background(), "Reader@Example.com", "en"))
+
+	assert.Len(t, emailService.confirmations, 1, "the second confirmation is within the cooldown")
+	assert.Len(t, repo.upserted, 1, "the first token stays valid")
+
+	require.NoError(t, service.Subscribe(context.Background(), "other@example.com", "en"))
+	assert.Len(t, emailService.confirmations, 2)
+}
+
+func TestSubscribe_AlreadyConfirmedIsSilent(t *testing.T) {
+	repo := &mockSubscriberRepo{upsertErr: domain.ErrSubscriberAlreadyConfirmed}
+	service, emailService, _ := newTestService(t, &mockDatastore{subscriberRepo: repo})
+
+	err := service.Subscribe(context.Background(), "reader@example.com", "en")
+	require.NoError(t, err)
+	assert.Empty(t, emailService.confirmations)
+}
+
+func TestUnsubscribe(t *testing.T) {
+	repo := &mockSubscriberRepo{}
+	service, _, _ := newTestService(t, &mockDatastore{subscriberRepo: repo})
+
+	err := service.Unsubscribe(context.Background(), service.unsubscribeToken(42))
+	require.NoError(t, err)
+	assert.Equal(t, []int32{42}, repo.deleted)
+
+	err = service.Unsubscribe(context.Background(), "unsubscribe.42.forged")
+	assert.ErrorIs(t, err, domain.ErrInvalidNewsletterToken)
+}
+
+func TestSendArticleNotifications_BatchesAndThrottles(t *testing.T) {
+	repo := &mockSubscriberRepo{subscribers: []domain.Subscriber{
+		{ID: 1, Email: "a@example.com", Language: "en"},
+		{ID: 2, Email: "b@example.com", Language: "fr"},
+		{ID: 5, Email: "c@example.com", Language: "en"},
+	}}
+	articles := &mockArticleRepo{article: domain.Article{ID: 7, Title: "Hello", Slug: "hello", IsPublished: true}}
+	service, emailService, pauses := newTestService(t, &mockDatastore{subscriberRepo: repo, articleRepo: articles})
+
+	service.sendArticleNotifications(context.Background(), 7)
+
+	require.Len(t, emailService.notifications, 3)
+	assert.Equal(t, []time.Duration{time.Second}, *pauses, "one pause between the two batches")
+
+	notification := emailService.notifications[1]
+	assert.Equal(t, "b@example.com", notification.Recipient)
+	assert.Equal(t, "fr", notification.Locale)
+	assert.Equal(t, "https://example.com/blog/hello", notification.ArticleURL)
+
+	token := strings.TrimPrefix(notification.UnsubscribeURL, "https://example.com/newsletter/unsubscribe?token=")
+	require.NoError(t, service.Unsubscribe(context.Background(), token))
+	assert.Equal(t, []int32{2}, repo.deleted)
+
+	// Publishing the same article again does not send a second email
+	service.sendArticleNotifications(context.Background(), 7)
+	assert.Len(t, emailService.notifications, 3)
+}
+
+func TestSendArticleNotifications_SkipsUnpublishedArticle(t *testing.T) {
+	repo := &mockSubscriberRepo{subscribers: []domain.Subscriber{{ID: 1, Email: "a@example.com"}}}
+	articles := &mockArticleRepo{article: domain.Article{ID: 7, Title: "Draft", Slug: "draft"}}
+	service, emailService, _ := newTestService(t, &mockDatastore{subscriberRepo: repo, articleRepo: articles})
+
+	service.sendArticleNotifications(context.Background(), 7)
+
+	assert.Empty(t, emailService.notifications)
+	assert.Empty(t, repo.claimed)
+}
+
+func TestSendArticleNotifications_RetriesFailedEmails(t *testing.T) {
+	repo := &mockSubscriberRepo{subscribers: []domain.Subscriber{
+		{ID: 1, Email: "a@example.com"},
+		{ID: 2, Email: "b@example.com"},
+	}}
+	articles := &mockArticleRepo{article: domain.Article{ID: 7, Title: "Hello", Slug: "hello", IsPublished: true}}
+	service, emailService, _ := newTestService(t, &mockDatastore{subscriberRepo: repo, articleRepo: articles})
+	emailService.failing = map[string]bool{"b@example.com": true}
+
+	service.sendArticleNotifications(context.Background(), 7)
+
+	require.Len(t, emailService.notifications, 1)
+	assert.False(t, repo.completed[7], "the announcement is incomplete while an email failed")
+
+	// The next attempt only emails the subscriber that was missed
+	emailService.failing = nil
+	service.sendArticleNotifications(context.Background(), 7)
+
+	require.Len(t, emailService.notifications, 2)
+	assert.Equal(t, "b@example.com", emailService.notifications[1].Recipient)
+	assert.True(t, repo.completed[7])
+}
+
+func TestClose_DrainsQueuedArticles(t *testing.T) {
+	repo := &mockSubscriberRepo{subscribers: []domain.Subscriber{{ID: 1, Email: "a@example.com"}}}
+	articles := &mockArticleRepo{article: domain.Article{ID: 7, Title: "Hello", Slug: "hello", IsPublished: true}}
+	service, emailService, _ := newTestService(t, &mockDatastore{subscriberRepo: repo, articleRepo: articles})
+
+	service.NotifyArticlePublished(context.Background(), 7)
+	require.NoError(t, service.Close(context.Background()))
+
+	emailService.mu.Lock()
+	defer emailService.mu.Unlock()
+	assert.Len(t, emailService.notifications, 1)
+
+	// Articles published while shutting down are not queued
+	service.NotifyArticlePublished(context.Background(), 8)
+	require.NoError(t, service.Close(context.Background()))
+}
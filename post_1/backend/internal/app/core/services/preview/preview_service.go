@@ -0,0 +1,135 @@
+package preview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"personal_website/config"
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/app/core/ports"
+	"personal_website/pkg/signing"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const previewPrefix = "preview."
+
+type previewService struct {
+	config    *config.PreviewConfig
+	datastore ports.Datastore
+	signer    *signing.Signer
+	now       func() time.Time
+}
+
+func NewPreviewService(cfg *config.PreviewConfig, datastore ports.Datastore) (*previewService, error) {
+	var secret []byte
+	if cfg.Secret != nil {
+		secret = cfg.Secret.Bytes()
+	}
+
+	signer, err := signing.NewSigner(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate preview secret: %w", err)
+	}
+
+	return &previewService{
+		config:    cfg,
+		datastore: datastore,
+		signer:    signer,
+		now:       time.Now,
+	}, nil
+}
+
+func (s *previewService) CreatePreviewLink(ctx context.Context, articleID int32, userID int) (domain.PreviewLink, error) {
+	if _, err := s.datastore.ArticleRepo().GetArticleByID(ctx, articleID); err != nil {
+		return domain.PreviewLink{}, err
+	}
+
+	// The expiry is stored to the second, like it is signed in the token
+	link, err := s.datastore.PreviewLinkRepo().CreatePreviewLink(ctx, domain.PreviewLink{
+		ArticleID: articleID,
+		CreatedBy: userID,
+		ExpiresAt: s.now().Add(s.config.TTL).Truncate(time.Second),
+	})
+	if err != nil {
+		return domain.PreviewLink{}, err
+	}
+
+	link.Token = s.token(link)
+	return link, nil
+}
+
+func (s *previewService) ListPreviewLinks(ctx context.Context, articleID int32) ([]domain.PreviewLink, error) {
+	// The links of an article in the trash are not listed, they give no access
+	if _, err := s.datastore.ArticleRepo().GetArticleByID(ctx, articleID); err != nil {
+		return nil, err
+	}
+
+	links, err := s.datastore.PreviewLinkRepo().ListPreviewLinks(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	for i := range links {
+		if links[i].Active(now) {
+			links[i].Token = s.token(links[i])
+		}
+	}
+	return links, nil
+}
+
+func (s *previewService) RevokePreviewLink(ctx context.Context, articleID int32, id int32) error {
+	return s.datastore.PreviewLinkRepo().RevokePreviewLink(ctx, articleID, id)
+}
+
+func (s *previewService) GetPreviewArticle(ctx context.Context, token string) (domain.Article, error) {
+	id, expiresAt, ok := s.parseToken(token)
+	if !ok || !s.now().Before(expiresAt) {
+		return domain.Article{}, domain.ErrInvalidPreviewToken
+	}
+
+	link, err := s.datastore.PreviewLinkRepo().GetPreviewLink(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrPreviewLinkNotFound) {
+			return domain.Article{}, domain.ErrInvalidPreviewToken
+		}
+		return domain.Article{}, err
+	}
+	if !link.Active(s.now()) {
+		return domain.Article{}, domain.ErrInvalidPreviewToken
+	}
+
+	// The articles in the trash are not found
+	return s.datastore.ArticleRepo().GetArticleByID(ctx, link.ArticleID)
+}
+
+// token signs the ID and the expiry of the link, so that the forged and
+// expired tokens are refused without a query.
+func (s *previewService) token(link domain.PreviewLink) string {
+	return s.signer.Sign(fmt.Sprintf("%s%d.%d", previewPrefix, link.ID, link.ExpiresAt.Unix()))
+}
+
+func (s *previewService) parseToken(token string) (int32, time.Time, bool) {
+	payload, ok := s.signer.Verify(token)
+	if !ok || !strings.HasPrefix(payload, previewPrefix) {
+		return 0, time.Time{}, false
+	}
+
+	idValue, expiryValue, ok := strings.Cut(strings.TrimPrefix(payload, previewPrefix), ".")
+	if !ok {
+		return 0, time.Time{}, false
+	}
+
+	id, err := strconv.ParseInt(idValue, 10, 32)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	expiry, err := strconv.ParseInt(expiryValue, 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+
+	return int32(id), time.Unix(expiry, 0), true
+}
@@ -0,0 +1,178 @@
+package preview
+
+import (
+	"context"
+	"personal_website/config"
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/app/core/ports"
+	"testing"
+	"time"
+
+	"github.com/awnumar/memguard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockArticleRepo struct {
+	ports.ArticleRepository
+	articles map[int32]domain.Article
+}
+
+func (m *mockArticleRepo) GetArticleByID(ctx context.Context, id int32) (domain.Article, error) {
+	article, ok := m.articles[id]
+	if !ok {
+		return domain.Article{}, domain.ErrArticleNotFound
+	}
+	return article, nil
+}
+
+type mockPreviewLinkRepo struct {
+	ports.PreviewLinkRepository
+	links []domain.PreviewLink
+}
+
+func (m *mockPreviewLinkRepo) CreatePreviewLink(ctx context.Context, link domain.PreviewLink) (domain.PreviewLink, error) {
+	link.ID = int32(len(m.links) + 1)
+	m.links = append(m.links, link)
+	return link, nil
+}
+
+func (m *mockPreviewLinkRepo) GetPreviewLink(ctx context.Context, id int32) (domain.PreviewLink, error) {
+	for _, link := range m.links {
+		if link.ID == id {
+			return link, nil
+		}
+	}
+	return domain.PreviewLink{}, domain.ErrPreviewLinkNotFound
+}
+
+func (m *mockPreviewLinkRepo) ListPreviewLinks(ctx context.Context, articleID int32) ([]domain.PreviewLink, error) {
+	var links []domain.PreviewLink
+	for _, link := range m.links {
+		if link.ArticleID == articleID {
+			links = append(links, link)
+		}
+	}
+	return links, nil
+}
+
+func (m *mockPreviewLinkRepo) RevokePreviewLink(ctx context.Context, articleID int32, id int32) error {
+	for i, link := range m.links {
+		if link.ID == id && link.ArticleID == articleID {
+			m.links[i].RevokedAt = time.Now()
+			return nil
+		}
+	}
+	return domain.ErrPreviewLinkNotFound
+}
+
+type mockDatastore struct {
+	ports.Datastore
+	articleRepo *mockArticleRepo
+	previewRepo *mockPreviewLinkRepo
+}
+
+func (m *mockDatastore) ArticleRepo() ports.ArticleRepository         { return m.articleRepo }
+func (m *mockDatastore) PreviewLinkRepo() ports.PreviewLinkRepository { return m.previewRepo }
+
+func newTestService(t *testing.T) (*previewService, *mockDatastore) {
+	t.Helper()
+
+	datastore := &mockDatastore{
+		articleRepo: &mockArticleRepo{articles: map[int32]domain.Article{
+			7: {ID: 7, Title: "Draft", Slug: "draft"},
+		}},
+		previewRepo: &mockPreviewLinkRepo{},
+	}
+
+	service, err := NewPreviewService(&config.PreviewConfig{
+		Secret: memguard.NewBufferFromBytes([]byte("test-preview-secret")),
+		TTL:    time.Hour,
+	}, datastore)
+	require.NoError(t, err)
+	return service, datastore
+}
+
+func TestCreatePreviewLink_GivesAccessToTheDraft(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+
+	link, err := service.CreatePreviewLink(ctx, 7, 3)
+	require.NoError(t, err)
+	assert.NotEmpty(t, link.Token)
+	assert.Equal(t, 3, link.CreatedBy)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), link.ExpiresAt, 2*time.Second)
+
+	article, err := service.GetPreviewArticle(ctx, link.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "draft", article.Slug)
+}
+
+func TestCreatePreviewLink_UnknownArticle(t *testing.T) {
+	service, datastore := newTestService(t)
+
+	_, err := service.CreatePreviewLink(context.Background(), 8, 3)
+	assert.ErrorIs(t, err, domain.ErrArticleNotFound)
+	assert.Empty(t, datastore.previewRepo.links)
+}
+
+func TestGetPreviewArticle_RefusesInvalidLinks(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+
+	link, err := service.CreatePreviewLink(ctx, 7, 3)
+	require.NoError(t, err)
+	other, err := NewPreviewService(&config.PreviewConfig{TTL: time.Hour}, nil)
+	require.NoError(t, err)
+
+	tokens := map[string]string{
+		"empty":       "",
+		"tampered":    "1" + link.Token,
+		"other key":   other.token(link),
+		"not preview": service.signer.Sign("unsubscribe.1"),
+		"unknown":     service.token(domain.PreviewLink{ID: 42, ExpiresAt: link.ExpiresAt}),
+	}
+	for name, token := range tokens {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.GetPreviewArticle(ctx, token)
+			assert.ErrorIs(t, err, domain.ErrInvalidPreviewToken)
+		})
+	}
+}
+
+func TestGetPreviewArticle_ExpiredLink(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+
+	link, err := service.CreatePreviewLink(ctx, 7, 3)
+	require.NoError(t, err)
+
+	service.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	_, err = service.GetPreviewArticle(ctx, link.Token)
+	assert.ErrorIs(t, err, domain.ErrInvalidPreviewToken)
+}
+
+func TestRevokePreviewLink(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+
+	revoked, err := service.CreatePreviewLink(ctx, 7, 3)
+	require.NoError(t, err)
+	active, err := service.CreatePreviewLink(ctx, 7, 3)
+	require.NoError(t, err)
+
+	require.NoError(t, service.RevokePreviewLink(ctx, 7, revoked.ID))
+	assert.ErrorIs(t, service.RevokePreviewLink(ctx, 8, active.ID), domain.ErrPreviewLinkNotFound)
+
+	_, err = service.GetPreviewArticle(ctx, revoked.Token)
+	assert.ErrorIs(t, err, domain.ErrInvalidPreviewToken)
+	_, err = service.GetPreviewArticle(ctx, active.Token)
+	assert.NoError(t, err)
+
+	// Only the active links are listed with their token
+	links, err := service.ListPreviewLinks(ctx, 7)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	assert.Empty(t, links[0].Token)
+	assert.Equal(t, active.Token, links[1].Token)
+}
@@ -48,7 +48,7 @@ func (u *userService) RegisterUser(ctx context.Context, user domain.User, activa
 		return domain.NewInternalError(err)
 	}
 
-	if err := u.emailService.SendActivationEmail(ctx, token.Plaintext, user.Email, activationURL); err != nil {
+	if err := u.emailService.SendActivationEmail(ctx, token.Plaintext, user.Email, activationURL, user.Language); err != nil {
 		return err
 	}
 
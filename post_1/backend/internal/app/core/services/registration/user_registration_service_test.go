@@ -26,7 +26,7 @@ type sentEmail struct {
 	user      *domain.User
 }
 
-func (m *mockEmailService) SendActivationEmail(ctx context.Context, activationToken, recipientEmail, baseURL string) error {
+func (m *mockEmailService) SendActivationEmail(ctx context.Context, activationToken, recipientEmail, baseURL, locale string) error {
 	if m.shouldFailActivation {
 		return m.activationError
 	}
@@ -50,6 +50,14 @@ func (m *mockEmailService) SendNewUserNotification(ctx context.Context, user *do
 	return nil
 }
 
+func (m *mockEmailService) SendNewsletterConfirmation(ctx context.Context, recipientEmail, confirmationURL, locale string) error {
+	return nil
+}
+
+func (m *mockEmailService) SendArticleNotification(ctx context.Context, notification domain.ArticleNotification) error {
+	return nil
+}
+
 func (m *mockEmailService) SendContactEmail(ctx context.Context, form domain.ContactMessage) error {
 	return nil
 }
@@ -198,6 +206,10 @@ func (m *mockTransaction) UserRepo() ports.UserRepository {
 	return m.userRepo
 }
 
+func (m *mockTransaction) PermissionRepo() ports.PermissionRepository {
+	return nil
+}
+
 func (m *mockTransaction) Commit() error {
 	if m.shouldFailCommit {
 		return m.commitError
@@ -229,6 +241,26 @@ func (m *mockDatabase) ArticleRepo() ports.ArticleRepository {
 	return nil
 }
 
+func (m *mockDatabase) SubscriberRepo() ports.SubscriberRepository {
+	return nil
+}
+
+func (m *mockDatabase) ResumeRepo() ports.ResumeRepository {
+	return nil
+}
+
+func (m *mockDatabase) AnalyticsRepo() ports.AnalyticsRepository {
+	return nil
+}
+
+func (m *mockDatabase) PreviewLinkRepo() ports.PreviewLinkRepository {
+	return nil
+}
+
+func (m *mockDatabase) SeriesRepo() ports.SeriesRepository {
+	return nil
+}
+
 func (m *mockDatabase) Begin(ctx context.Context) (ports.Transaction, error) {
 	if m.shouldFailBegin {
 		return nil, m.beginError
@@ -253,10 +285,46 @@ func (m *mockDatastore) ArticleRepo() ports.ArticleRepository {
 	return m.database.ArticleRepo()
 }
 
+func (m *mockDatastore) SubscriberRepo() ports.SubscriberRepository {
+	return m.database.SubscriberRepo()
+}
+
+func (m *mockDatastore) ResumeRepo() ports.ResumeRepository {
+	return m.database.ResumeRepo()
+}
+
+func (m *mockDatastore) AnalyticsRepo() ports.AnalyticsRepository {
+	return m.database.AnalyticsRepo()
+}
+
+func (m *mockDatastore) PreviewLinkRepo() ports.PreviewLinkRepository {
+	return m.database.PreviewLinkRepo()
+}
+
+func (m *mockDatastore) SeriesRepo() ports.SeriesRepository {
+	return m.database.SeriesRepo()
+}
+
 func (m *mockDatastore) SessionRepo() ports.SessionRepository {
 	return m.sessionRepo
 }
 
+func (m *mockDatastore) QuotaRepo() ports.QuotaRepository {
+	return nil
+}
+
+func (m *mockDatastore) RateLimiter() ports.RateLimiter {
+	return nil
+}
+
+func (m *mockDatastore) ViewCounterRepo() ports.ViewCounterRepository {
+	return nil
+}
+
+func (m *mockDatastore) ResponseCacheRepo() ports.ResponseCacheRepository {
+	return nil
+}
+
 func (m *mockDatastore) Begin(ctx context.Context) (ports.Transaction, error) {
 	return m.database.Begin(ctx)
 }
@@ -5,6 +5,10 @@ import (
 	"fmt"
 	"personal_website/internal/app/core/domain"
 	"personal_website/internal/app/core/ports"
+	"personal_website/pkg/validation"
+	"sort"
+	"strconv"
+	"unicode/utf8"
 )
 
 type DomainValidator struct {
@@ -19,6 +23,14 @@ func NewTokenValidator() *DomainValidator {
 	}
 }
 
+// NewArticleValidator validates the articles that do not come through the API,
+// e.g. the files of the content repository.
+func NewArticleValidator() *DomainValidator {
+	return &DomainValidator{
+		Errors: make(map[string][]string),
+	}
+}
+
 func NewUserValidator(userRepo ports.UserRepository) *DomainValidator {
 	return &DomainValidator{
 		userRepo: userRepo,
@@ -42,6 +54,16 @@ func (dv *DomainValidator) Valid() bool {
 	return len(dv.Errors) == 0
 }
 
+// Messages returns the messages of every error, sorted.
+func (dv *DomainValidator) Messages() []string {
+	var messages []string
+	for _, fieldErrors := range dv.Errors {
+		messages = append(messages, fieldErrors...)
+	}
+	sort.Strings(messages)
+	return messages
+}
+
 func (dv *DomainValidator) Error() error {
 	if len(dv.Errors) > 0 {
 		return fmt.Errorf("validation failed: %v", dv.Errors)
@@ -74,3 +96,39 @@ func (dv *DomainValidator) ValidateTokenPlaintext(tokenPlaintext string) *Domain
 
 	return dv
 }
+
+// ValidateArticle applies the rules of a POST /v1/articles request, with the
+// same messages.
+func (dv *DomainValidator) ValidateArticle(article domain.Article) *DomainValidator {
+	dv.checkLength("title", article.Title, 5, 200)
+	if dv.checkLength("slug", article.Slug, 3, 100) {
+		dv.Check(validation.IsAlphanumHyphen(article.Slug), "slug", "slug must contain only letters, numbers and hyphens")
+	}
+	dv.checkLength("content", article.Content, 50, 80000)
+
+	dv.Check(len(article.Tags) <= 20, "tags", "tags must not exceed 20 characters")
+	for i, tag := range article.Tags {
+		key := "tags[" + strconv.Itoa(i) + "]"
+		dv.Check(tag != "", key, key+" must be at least 1 characters")
+		dv.Check(utf8.RuneCountInString(tag) <= 50, key, key+" must not exceed 50 characters")
+	}
+
+	return dv
+}
+
+// checkLength checks that the value is present and its length in characters
+// is between min and max, and reports whether it is.
+func (dv *DomainValidator) checkLength(key string, value string, min int, max int) bool {
+	length := utf8.RuneCountInString(value)
+	switch {
+	case value == "":
+		dv.AddError(key, key+" is required")
+	case length < min:
+		dv.AddError(key, key+" must be at least "+strconv.Itoa(min)+" characters")
+	case length > max:
+		dv.AddError(key, key+" must not exceed "+strconv.Itoa(max)+" characters")
+	default:
+		return true
+	}
+	return false
+}
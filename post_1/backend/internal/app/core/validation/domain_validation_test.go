@@ -630,3 +630,50 @@ func TestDomainValidator_MethodChaining(t *testing.T) {
 		t.Errorf("Chained validation with valid conditions should pass, got errors: %v", validator2.Error())
 	}
 }
+
+func TestDomainValidator_ValidateArticle(t *testing.T) {
+	valid := domain.Article{
+		Title:   "Hello world",
+		Slug:    "hello-world",
+		Content: strings.Repeat("The content of the article. ", 3),
+		Tags:    []string{"go"},
+	}
+
+	tests := []struct {
+		name           string
+		edit           func(article *domain.Article)
+		expectedErrors []string
+	}{
+		{
+			name: "valid article",
+			edit: func(article *domain.Article) {},
+		},
+		{
+			name:           "missing title",
+			edit:           func(article *domain.Article) { article.Title = "" },
+			expectedErrors: []string{"title is required"},
+		},
+		{
+			name:           "invalid slug",
+			edit:           func(article *domain.Article) { article.Slug = "hello world" },
+			expectedErrors: []string{"slug must contain only letters, numbers and hyphens"},
+		},
+		{
+			name:           "short content and empty tag",
+			edit:           func(article *domain.Article) { article.Content = "Too short"; article.Tags = []string{""} },
+			expectedErrors: []string{"content must be at least 50 characters", "tags[0] must be at least 1 characters"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			article := valid
+			tt.edit(&article)
+
+			messages := NewArticleValidator().ValidateArticle(article).Messages()
+			if strings.Join(messages, "; ") != strings.Join(tt.expectedErrors, "; ") {
+				t.Errorf("ValidateArticle() errors = %v, want %v", messages, tt.expectedErrors)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package email_sender
 
-import "net/smtp"
+import (
+	"context"
+	"net"
+	"net/smtp"
+)
 
 type EmailSender struct{}
 
@@ -8,6 +12,28 @@ func (e *EmailSender) SendMail(host string, auth smtp.Auth, from string, to []st
 	return smtp.SendMail(host, auth, from, to, msg)
 }
 
+// Ping checks that the SMTP server at addr accepts connections and greets.
+func (e *EmailSender) Ping(ctx context.Context, addr string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	host, _, _ := net.SplitHostPort(addr)
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	return client.Quit()
+}
+
 func NewEmailSender() *EmailSender {
 	return &EmailSender{}
 }
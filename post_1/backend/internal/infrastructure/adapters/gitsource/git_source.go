@@ -0,0 +1,110 @@
+// Package gitsource reads the articles of a git repository of Markdown files
+// with the git command, so that working trees and bare repositories are read
+// the same way and only committed files are synced.
+package gitsource
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"personal_website/config"
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/infrastructure/markdown"
+)
+
+// maxTreeSize bounds the size of the Markdown files read from the repository.
+const maxTreeSize = 100 << 20
+
+type gitSource struct {
+	repoPath string
+	ref      string
+}
+
+func NewGitSource(cfg *config.SyncConfig) *gitSource {
+	return &gitSource{
+		repoPath: cfg.RepoPath,
+		ref:      cfg.Ref,
+	}
+}
+
+func (s *gitSource) Snapshot(ctx context.Context) (domain.ContentSnapshot, error) {
+	out, err := s.git(ctx, "rev-parse", "--verify", "--end-of-options", s.ref+"^{commit}")
+	if err != nil {
+		return domain.ContentSnapshot{}, err
+	}
+	commit := strings.TrimSpace(string(out))
+
+	files, err := s.archive(ctx, commit)
+	if err != nil {
+		return domain.ContentSnapshot{}, err
+	}
+
+	snapshot := domain.ContentSnapshot{Commit: commit, Files: make([]domain.SourceFile, 0, len(files))}
+	for _, file := range files {
+		hash := sha256.Sum256(file.Data)
+		article, err := markdown.Parse(file.Name, file.Data)
+		snapshot.Files = append(snapshot.Files, domain.SourceFile{
+			Path:    file.Name,
+			Hash:    hex.EncodeToString(hash[:]),
+			Article: article,
+			Err:     err,
+		})
+	}
+	return snapshot, nil
+}
+
+// archive reads the Markdown files of the commit from the output of git
+// archive as it is written, so that the other files of the repository, images
+// and the like, are never held in memory.
+func (s *gitSource) archive(ctx context.Context, commit string) ([]markdown.File, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", "-C", s.repoPath, "archive", "--format=tar", commit)
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("git archive: %w", err)
+	}
+
+	files, err := markdown.ReadTar(stdout, maxTreeSize)
+	if err != nil {
+		// git is killed rather than left blocked on a full pipe
+		cancel()
+		_ = cmd.Wait()
+		return nil, err
+	}
+	// The padding after the end of the archive
+	if _, err := io.Copy(io.Discard, stdout); err != nil {
+		cancel()
+		_ = cmd.Wait()
+		return nil, fmt.Errorf("git archive: %w", err)
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("git archive: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return files, nil
+}
+
+func (s *gitSource) git(ctx context.Context, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", s.repoPath}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,106 @@
+package gitsource
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"personal_website/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validContent = "This is the content of a synced article, long enough to be valid."
+
+func run(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null",
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+func newRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	run(t, dir, "init", "-q", "-b", "main")
+	return dir
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestSnapshot_ReadsCommittedArticles(t *testing.T) {
+	dir := newRepo(t)
+	writeFile(t, dir, "posts/hello.md", "---\ntitle: Hello world\n---\n"+validContent+"\n")
+	writeFile(t, dir, "posts/invalid.md", "no front matter\n")
+	writeFile(t, dir, "README.txt", "not an article\n")
+	run(t, dir, "add", ".")
+	run(t, dir, "commit", "-q", "-m", "articles")
+	commit := run(t, dir, "rev-parse", "HEAD")
+
+	// Uncommitted changes are not synced
+	writeFile(t, dir, "posts/draft.md", "---\ntitle: Work in progress\n---\n"+validContent+"\n")
+
+	snapshot, err := NewGitSource(&config.SyncConfig{RepoPath: dir, Ref: "main"}).Snapshot(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, commit, snapshot.Commit)
+	require.Len(t, snapshot.Files, 2)
+
+	byPath := make(map[string]int)
+	for i, file := range snapshot.Files {
+		byPath[file.Path] = i
+	}
+	hello := snapshot.Files[byPath["posts/hello.md"]]
+	require.NoError(t, hello.Err)
+	assert.Equal(t, "hello", hello.Article.Slug)
+	assert.Len(t, hello.Hash, 64)
+
+	invalid := snapshot.Files[byPath["posts/invalid.md"]]
+	assert.Error(t, invalid.Err)
+}
+
+func TestSnapshot_BareRepository(t *testing.T) {
+	dir := newRepo(t)
+	writeFile(t, dir, "hello.md", "---\ntitle: Hello world\n---\n"+validContent+"\n")
+	run(t, dir, "add", ".")
+	run(t, dir, "commit", "-q", "-m", "articles")
+
+	bare := filepath.Join(t.TempDir(), "content.git")
+	run(t, dir, "clone", "-q", "--bare", dir, bare)
+
+	snapshot, err := NewGitSource(&config.SyncConfig{RepoPath: bare, Ref: "HEAD"}).Snapshot(context.Background())
+	require.NoError(t, err)
+	require.Len(t, snapshot.Files, 1)
+	assert.Equal(t, "hello.md", snapshot.Files[0].Path)
+}
+
+func TestSnapshot_UnknownRef(t *testing.T) {
+	dir := newRepo(t)
+	writeFile(t, dir, "hello.md", "---\ntitle: Hello world\n---\n"+validContent+"\n")
+	run(t, dir, "add", ".")
+	run(t, dir, "commit", "-q", "-m", "articles")
+
+	_, err := NewGitSource(&config.SyncConfig{RepoPath: dir, Ref: "missing"}).Snapshot(context.Background())
+	assert.Error(t, err)
+}
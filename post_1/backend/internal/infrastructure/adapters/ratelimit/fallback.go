@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/app/core/ports"
+	"sync"
+	"time"
+)
+
+// retryPrimaryAfter is how long the fallback is used before trying the
+// primary limiter again, so that an unavailable Valkey does not add a
+// timeout to every request.
+const retryPrimaryAfter = 10 * time.Second
+
+// fallbackLimiter uses the primary limiter, usually shared through Valkey,
+// and switches to the fallback, usually in memory, while the primary fails.
+type fallbackLimiter struct {
+	primary  ports.RateLimiter
+	fallback ports.RateLimiter
+	logger   *slog.Logger
+	mu       sync.Mutex
+	downTill time.Time
+	now      func() time.Time
+}
+
+func NewFallbackLimiter(primary ports.RateLimiter, fallback ports.RateLimiter, logger *slog.Logger) *fallbackLimiter {
+	return &fallbackLimiter{
+		primary:  primary,
+		fallback: fallback,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+func (l *fallbackLimiter) Allow(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitResult, error) {
+	if l.primaryDown() {
+		return l.fallback.Allow(ctx, key, limit)
+	}
+
+	result, err := l.primary.Allow(ctx, key, limit)
+	if err != nil {
+		l.markPrimaryDown(err)
+		return l.fallback.Allow(ctx, key, limit)
+	}
+
+	l.markPrimaryUp()
+	return result, nil
+}
+
+func (l *fallbackLimiter) primaryDown() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.now().Before(l.downTill)
+}
+
+func (l *fallbackLimiter) markPrimaryDown(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.downTill.IsZero() {
+		l.logger.Warn("Rate limiter unavailable, falling back to in-memory limits", "error", err)
+	}
+	l.downTill = l.now().Add(retryPrimaryAfter)
+}
+
+func (l *fallbackLimiter) markPrimaryUp() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.downTill.IsZero() {
+		l.logger.Info("Rate limiter available again")
+		l.downTill = time.Time{}
+	}
+}
@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"personal_website/internal/app/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubLimiter struct {
+	calls  int
+	err    error
+	result domain.RateLimitResult
+}
+
+func (s *stubLimiter) Allow(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitResult, error) {
+	s.calls++
+	return s.result, s.err
+}
+
+func TestFallbackLimiter_UsesFallbackWhilePrimaryIsDown(t *testing.T) {
+	primary := &stubLimiter{err: errors.New("connection refused")}
+	fallback := &stubLimiter{result: domain.RateLimitResult{Allowed: true, Remaining: 4}}
+
+	limiter := NewFallbackLimiter(primary, fallback, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	limit := domain.RateLimit{Requests: 1, Period: time.Second, Burst: 5}
+
+	result, err := limiter.Allow(context.Background(), "ip:192.0.2.1", limit)
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.Remaining)
+
+	// The primary is not retried before the delay
+	_, err = limiter.Allow(context.Background(), "ip:192.0.2.1", limit)
+	require.NoError(t, err)
+	assert.Equal(t, 1, primary.calls)
+	assert.Equal(t, 2, fallback.calls)
+
+	// Then it is used again once it recovers
+	primary.err = nil
+	primary.result = domain.RateLimitResult{Allowed: true, Remaining: 1}
+	now = now.Add(retryPrimaryAfter)
+
+	result, err = limiter.Allow(context.Background(), "ip:192.0.2.1", limit)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Remaining)
+	assert.Equal(t, 2, primary.calls)
+	assert.Equal(t, 2, fallback.calls)
+}
@@ -0,0 +1,97 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"personal_website/internal/app/core/domain"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+type keyLimiter struct {
+	limiter  *rate.Limiter
+	limit    domain.RateLimit
+	lastSeen time.Time
+}
+
+// memoryLimiter keeps a token bucket per key in the process memory. The
+// counters are neither shared between instances nor kept across restarts.
+type memoryLimiter struct {
+	limiters map[string]*keyLimiter
+	mu       sync.Mutex
+	now      func() time.Time
+}
+
+func NewMemoryLimiter() *memoryLimiter {
+	l := &memoryLimiter{
+		limiters: make(map[string]*keyLimiter),
+		now:      time.Now,
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			l.cleanupOldEntries()
+		}
+	}()
+
+	return l
+}
+
+func (l *memoryLimiter) Allow(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitResult, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	perSecond := rate.Every(limit.Interval())
+
+	entry, exists := l.limiters[key]
+	if !exists {
+		entry = &keyLimiter{limiter: rate.NewLimiter(perSecond, limit.Burst), limit: limit}
+		l.limiters[key] = entry
+	} else if entry.limit != limit {
+		// The limit was reloaded, the bucket keeps its tokens
+		entry.limiter.SetLimitAt(now, perSecond)
+		entry.limiter.SetBurstAt(now, limit.Burst)
+		entry.limit = limit
+	}
+	entry.lastSeen = now
+
+	allowed := entry.limiter.AllowN(now, 1)
+	tokens := entry.limiter.TokensAt(now)
+
+	result := domain.RateLimitResult{
+		Allowed:    allowed,
+		Limit:      limit.Burst,
+		Remaining:  max(int(math.Floor(tokens)), 0),
+		ResetAfter: tokensDuration(float64(limit.Burst)-tokens, perSecond),
+	}
+	if !allowed {
+		result.RetryAfter = tokensDuration(1-tokens, perSecond)
+	}
+
+	return result, nil
+}
+
+func (l *memoryLimiter) cleanupOldEntries() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cutoff := l.now().Add(-time.Hour)
+	for key, entry := range l.limiters {
+		if entry.lastSeen.Before(cutoff) {
+			delete(l.limiters, key)
+		}
+	}
+}
+
+// tokensDuration is the time needed to earn the given number of tokens.
+func tokensDuration(tokens float64, perSecond rate.Limit) time.Duration {
+	if tokens <= 0 || perSecond <= 0 {
+		return 0
+	}
+	return time.Duration(tokens / float64(perSecond) * float64(time.Second))
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"personal_website/internal/app/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLimiter_Allow(t *testing.T) {
+	limiter := NewMemoryLimiter()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	limit := domain.RateLimit{Requests: 1, Period: time.Second, Burst: 3}
+
+	for i := 2; i >= 0; i-- {
+		result, err := limiter.Allow(context.Background(), "ip:192.0.2.1", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 3, result.Limit)
+		assert.Equal(t, i, result.Remaining)
+	}
+
+	result, err := limiter.Allow(context.Background(), "ip:192.0.2.1", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.Equal(t, time.Second, result.RetryAfter)
+	assert.Equal(t, 3*time.Second, result.ResetAfter)
+
+	// Other keys have their own bucket
+	result, err = limiter.Allow(context.Background(), "ip:192.0.2.2", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	// One request is earned back every second
+	now = now.Add(time.Second)
+	result, err = limiter.Allow(context.Background(), "ip:192.0.2.1", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+func TestMemoryLimiter_AppliesReloadedLimit(t *testing.T) {
+	limiter := NewMemoryLimiter()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	result, err := limiter.Allow(context.Background(), "ip:192.0.2.1", domain.RateLimit{Requests: 1, Period: time.Second, Burst: 5})
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.Remaining)
+
+	// The stricter limit applies to the client already seen
+	stricter := domain.RateLimit{Requests: 1, Period: time.Minute, Burst: 2}
+	for i := 1; i >= 0; i-- {
+		result, err = limiter.Allow(context.Background(), "ip:192.0.2.1", stricter)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 2, result.Limit)
+		assert.Equal(t, i, result.Remaining)
+	}
+
+	result, err = limiter.Allow(context.Background(), "ip:192.0.2.1", stricter)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, time.Minute, result.RetryAfter)
+}
@@ -0,0 +1,158 @@
+package datastore_adapter
+
+import (
+	"context"
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/app/core/ports"
+	"personal_website/pkg/telemetry"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// articleCacheMetrics counts the hits and misses of the cached reads.
+var articleCacheMetrics = telemetry.NewCacheMetrics("article_cache", "article cache")
+
+// cachingArticleRepo serves the published articles read by the public pages
+// from the Valkey cache. Concurrent misses of the same entry share a single
+// query, so that an invalidation does not send every visitor to Postgres.
+//
+// The cache only saves queries: when Valkey fails the articles are read from
+// Postgres, and are not stored. It does not invalidate itself, the writes go
+// through invalidatingArticleRepo.
+type cachingArticleRepo struct {
+	ports.ArticleRepository
+	cache ports.ArticleCacheRepository
+	ttl   time.Duration
+	group singleflight.Group
+}
+
+func newCachingArticleRepo(repo ports.ArticleRepository, cache ports.ArticleCacheRepository, ttl time.Duration) *cachingArticleRepo {
+	return &cachingArticleRepo{
+		ArticleRepository: repo,
+		cache:             cache,
+		ttl:               ttl,
+	}
+}
+
+func (r *cachingArticleRepo) GetArticleBySlug(ctx context.Context, slug string) (domain.Article, error) {
+	article, found, generation, err := r.cache.GetArticle(ctx, slug)
+	articleCacheMetrics.Record(ctx, "get_by_slug", found)
+	if found {
+		return article, nil
+	}
+	cacheable := err == nil
+
+	value, err, _ := r.group.Do("slug:"+slug, func() (any, error) {
+		// The query is shared, it is not cancelled with the first request
+		ctx := context.WithoutCancel(ctx)
+
+		article, err := r.ArticleRepository.GetArticleBySlug(ctx, slug)
+		if err == nil && cacheable {
+			_ = r.cache.SetArticle(ctx, article, generation, r.ttl)
+		}
+		return article, err
+	})
+	if err != nil {
+		return domain.Article{}, err
+	}
+	return value.(domain.Article), nil
+}
+
+func (r *cachingArticleRepo) ListArticles(ctx context.Context) ([]domain.Article, error) {
+	articles, found, generation, err := r.cache.GetArticleList(ctx)
+	articleCacheMetrics.Record(ctx, "list", found)
+	if found {
+		return articles, nil
+	}
+	cacheable := err == nil
+
+	value, err, _ := r.group.Do("list", func() (any, error) {
+		ctx := context.WithoutCancel(ctx)
+
+		articles, err := r.ArticleRepository.ListArticles(ctx)
+		if err == nil && cacheable {
+			_ = r.cache.SetArticleList(ctx, articles, generation, r.ttl)
+		}
+		return articles, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]domain.Article), nil
+}
+
+// invalidatingArticleRepo drops the cached articles and public responses after
+// every change of the articles, whichever instance or command made it.
+//
+// The change is kept when a cache cannot be invalidated: the failure is
+// recorded by the Valkey instrumentation and the entries expire with the TTL
+// of the cache.
+type invalidatingArticleRepo struct {
+	ports.ArticleRepository
+	invalidators []func(ctx context.Context) error
+}
+
+func newInvalidatingArticleRepo(repo ports.ArticleRepository, invalidators ...func(ctx context.Context) error) *invalidatingArticleRepo {
+	return &invalidatingArticleRepo{
+		ArticleRepository: repo,
+		invalidators:      invalidators,
+	}
+}
+
+func (r *invalidatingArticleRepo) invalidate(ctx context.Context, err error) {
+	if err != nil {
+		return
+	}
+	for _, invalidate := range r.invalidators {
+		_ = invalidate(ctx)
+	}
+}
+
+func (r *invalidatingArticleRepo) CreateArticle(ctx context.Context, article domain.Article) error {
+	err := r.ArticleRepository.CreateArticle(ctx, article)
+	r.invalidate(ctx, err)
+	return err
+}
+
+func (r *invalidatingArticleRepo) UpdateArticle(ctx context.Context, article domain.Article) (int32, error) {
+	version, err := r.ArticleRepository.UpdateArticle(ctx, article)
+	r.invalidate(ctx, err)
+	return version, err
+}
+
+func (r *invalidatingArticleRepo) PublishArticle(ctx context.Context, id int32, version int32) (int32, error) {
+	version, err := r.ArticleRepository.PublishArticle(ctx, id, version)
+	r.invalidate(ctx, err)
+	return version, err
+}
+
+func (r *invalidatingArticleRepo) UnpublishArticle(ctx context.Context, id int32, version int32) (int32, error) {
+	version, err := r.ArticleRepository.UnpublishArticle(ctx, id, version)
+	r.invalidate(ctx, err)
+	return version, err
+}
+
+func (r *invalidatingArticleRepo) SoftDeleteArticle(ctx context.Context, id int32, version int32) error {
+	err := r.ArticleRepository.SoftDeleteArticle(ctx, id, version)
+	r.invalidate(ctx, err)
+	return err
+}
+
+func (r *invalidatingArticleRepo) DeleteArticle(ctx context.Context, id int32) error {
+	err := r.ArticleRepository.DeleteArticle(ctx, id)
+	r.invalidate(ctx, err)
+	return err
+}
+
+func (r *invalidatingArticleRepo) RestoreArticle(ctx context.Context, id int32) error {
+	err := r.ArticleRepository.RestoreArticle(ctx, id)
+	r.invalidate(ctx, err)
+	return err
+}
+
+func (r *invalidatingArticleRepo) UpsertArticleBySlug(ctx context.Context, article domain.Article) (int32, bool, error) {
+	id, created, err := r.ArticleRepository.UpsertArticleBySlug(ctx, article)
+	r.invalidate(ctx, err)
+	return id, created, err
+}
@@ -0,0 +1,205 @@
+package datastore_adapter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/app/core/ports"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockArticleRepo counts the reads, which block until release is closed.
+type mockArticleRepo struct {
+	ports.ArticleRepository
+	reads   atomic.Int32
+	release chan struct{}
+	err     error
+}
+
+func newMockArticleRepo() *mockArticleRepo {
+	release := make(chan struct{})
+	close(release)
+	return &mockArticleRepo{release: release}
+}
+
+func (m *mockArticleRepo) GetArticleBySlug(ctx context.Context, slug string) (domain.Article, error) {
+	m.reads.Add(1)
+	<-m.release
+	if m.err != nil {
+		return domain.Article{}, m.err
+	}
+	return domain.Article{ID: 1, Slug: slug, IsPublished: true, Version: 2}, nil
+}
+
+func (m *mockArticleRepo) ListArticles(ctx context.Context) ([]domain.Article, error) {
+	m.reads.Add(1)
+	<-m.release
+	return []domain.Article{{ID: 1, Slug: "hello", IsPublished: true}}, m.err
+}
+
+func (m *mockArticleRepo) PublishArticle(ctx context.Context, id int32, version int32) (int32, error) {
+	return version + 1, m.err
+}
+
+// mockArticleCache is an in-memory ArticleCacheRepository.
+type mockArticleCache struct {
+	mu         sync.Mutex
+	articles   map[string]domain.Article
+	list       []domain.Article
+	generation int64
+	err        error
+}
+
+func newMockArticleCache() *mockArticleCache {
+	return &mockArticleCache{articles: make(map[string]domain.Article)}
+}
+
+func (m *mockArticleCache) GetArticle(ctx context.Context, slug string) (domain.Article, bool, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return domain.Article{}, false, 0, m.err
+	}
+	article, found := m.articles[slug]
+	return article, found, m.generation, nil
+}
+
+func (m *mockArticleCache) SetArticle(ctx context.Context, article domain.Article, generation int64, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if generation == m.generation {
+		m.articles[article.Slug] = article
+	}
+	return nil
+}
+
+func (m *mockArticleCache) GetArticleList(ctx context.Context) ([]domain.Article, bool, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return nil, false, 0, m.err
+	}
+	return m.list, m.list != nil, m.generation, nil
+}
+
+func (m *mockArticleCache) SetArticleList(ctx context.Context, articles []domain.Article, generation int64, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if generation == m.generation {
+		m.list = articles
+	}
+	return nil
+}
+
+func (m *mockArticleCache) InvalidateArticles(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.generation++
+	m.articles = make(map[string]domain.Article)
+	m.list = nil
+	return nil
+}
+
+func TestCachingArticleRepo_ReadsThrough(t *testing.T) {
+	repo := newMockArticleRepo()
+	cache := newMockArticleCache()
+	cached := newCachingArticleRepo(repo, cache, time.Minute)
+	ctx := context.Background()
+
+	for range 3 {
+		article, err := cached.GetArticleBySlug(ctx, "hello")
+		require.NoError(t, err)
+		assert.Equal(t, "hello", article.Slug)
+
+		articles, err := cached.ListArticles(ctx)
+		require.NoError(t, err)
+		assert.Len(t, articles, 1)
+	}
+	assert.Equal(t, int32(2), repo.reads.Load(), "a single query for the article and for the list")
+}
+
+func TestCachingArticleRepo_SharesConcurrentMisses(t *testing.T) {
+	repo := newMockArticleRepo()
+	repo.release = make(chan struct{})
+	cached := newCachingArticleRepo(repo, newMockArticleCache(), time.Minute)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := cached.GetArticleBySlug(context.Background(), "hello")
+			assert.NoError(t, err)
+		}()
+	}
+
+	// Let the requests join the first query before it returns
+	require.Eventually(t, func() bool { return repo.reads.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(repo.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), repo.reads.Load())
+}
+
+func TestCachingArticleRepo_DoesNotCacheErrors(t *testing.T) {
+	repo := newMockArticleRepo()
+	repo.err = domain.ErrArticleNotFound
+	cache := newMockArticleCache()
+	cached := newCachingArticleRepo(repo, cache, time.Minute)
+
+	for range 2 {
+		_, err := cached.GetArticleBySlug(context.Background(), "missing")
+		assert.ErrorIs(t, err, domain.ErrArticleNotFound)
+	}
+	assert.Equal(t, int32(2), repo.reads.Load())
+	assert.Empty(t, cache.articles)
+}
+
+func TestCachingArticleRepo_FallsBackWhenCacheFails(t *testing.T) {
+	repo := newMockArticleRepo()
+	cache := newMockArticleCache()
+	cache.err = errors.New("connection refused")
+	cached := newCachingArticleRepo(repo, cache, time.Minute)
+
+	articles, err := cached.ListArticles(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, articles, 1)
+	assert.Nil(t, cache.list, "what is read while the cache fails is not stored")
+}
+
+func TestInvalidatingArticleRepo_InvalidatesOnSuccess(t *testing.T) {
+	repo := newMockArticleRepo()
+	cache := newMockArticleCache()
+	var responses atomic.Int32
+	invalidating := newInvalidatingArticleRepo(newCachingArticleRepo(repo, cache, time.Minute),
+		func(ctx context.Context) error { responses.Add(1); return nil },
+		cache.InvalidateArticles,
+	)
+	ctx := context.Background()
+
+	_, err := invalidating.GetArticleBySlug(ctx, "hello")
+	require.NoError(t, err)
+	require.Contains(t, cache.articles, "hello")
+
+	_, err = invalidating.PublishArticle(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.Empty(t, cache.articles)
+	assert.Equal(t, int32(1), responses.Load())
+
+	// A failed change leaves the caches
+	_, err = invalidating.GetArticleBySlug(ctx, "hello")
+	require.NoError(t, err)
+	repo.err = domain.ErrArticleVersionMismatch
+	_, err = invalidating.PublishArticle(ctx, 1, 2)
+	assert.ErrorIs(t, err, domain.ErrArticleVersionMismatch)
+	assert.Contains(t, cache.articles, "hello")
+	assert.Equal(t, int32(1), responses.Load())
+}
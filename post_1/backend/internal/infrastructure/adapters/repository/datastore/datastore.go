@@ -2,18 +2,38 @@ package datastore_adapter
 
 import (
 	"context"
+	"personal_website/config"
 	"personal_website/internal/app/core/ports"
 )
 
 type Datastore struct {
-	postgresDB ports.PostgresDatabase
-	valkeyDB   ports.ValkeyDatabase
+	postgresDB  ports.PostgresDatabase
+	valkeyDB    ports.ValkeyDatabase
+	articleRepo ports.ArticleRepository
+	seriesRepo  ports.SeriesRepository
 }
 
-func NewDatastore(postgresDB ports.PostgresDatabase, valkeyDB ports.ValkeyDatabase) *Datastore {
+func NewDatastore(postgresDB ports.PostgresDatabase, valkeyDB ports.ValkeyDatabase, cfg *config.CacheConfig) *Datastore {
+	articleRepo := postgresDB.ArticleRepo()
+	if cfg.Articles {
+		articleRepo = newCachingArticleRepo(articleRepo, valkeyDB.ArticleCacheRepo(), cfg.ArticlesTTL)
+	}
+
+	// Both caches are invalidated even when this instance does not read the
+	// articles from Valkey, the other instances may. A single invalidation
+	// keeps a response from being rendered from an article dropped after it.
+	articleRepo = newInvalidatingArticleRepo(articleRepo, valkeyDB.InvalidateArticleCaches)
+
+	// The series are only rendered in the public responses
+	seriesRepo := newInvalidatingSeriesRepo(postgresDB.SeriesRepo(),
+		valkeyDB.ResponseCacheRepo().InvalidateResponses,
+	)
+
 	return &Datastore{
-		postgresDB: postgresDB,
-		valkeyDB:   valkeyDB,
+		postgresDB:  postgresDB,
+		valkeyDB:    valkeyDB,
+		articleRepo: articleRepo,
+		seriesRepo:  seriesRepo,
 	}
 }
 
@@ -22,7 +42,27 @@ func (d *Datastore) UserRepo() ports.UserRepository {
 }
 
 func (d *Datastore) ArticleRepo() ports.ArticleRepository {
-	return d.postgresDB.ArticleRepo()
+	return d.articleRepo
+}
+
+func (d *Datastore) SubscriberRepo() ports.SubscriberRepository {
+	return d.postgresDB.SubscriberRepo()
+}
+
+func (d *Datastore) ResumeRepo() ports.ResumeRepository {
+	return d.postgresDB.ResumeRepo()
+}
+
+func (d *Datastore) AnalyticsRepo() ports.AnalyticsRepository {
+	return d.postgresDB.AnalyticsRepo()
+}
+
+func (d *Datastore) PreviewLinkRepo() ports.PreviewLinkRepository {
+	return d.postgresDB.PreviewLinkRepo()
+}
+
+func (d *Datastore) SeriesRepo() ports.SeriesRepository {
+	return d.seriesRepo
 }
 
 func (d *Datastore) PermissionRepo() ports.PermissionRepository {
@@ -33,6 +73,22 @@ func (d *Datastore) SessionRepo() ports.SessionRepository {
 	return d.valkeyDB.SessionRepo()
 }
 
+func (d *Datastore) QuotaRepo() ports.QuotaRepository {
+	return d.valkeyDB.QuotaRepo()
+}
+
+func (d *Datastore) RateLimiter() ports.RateLimiter {
+	return d.valkeyDB.RateLimiter()
+}
+
+func (d *Datastore) ViewCounterRepo() ports.ViewCounterRepository {
+	return d.valkeyDB.ViewCounterRepo()
+}
+
+func (d *Datastore) ResponseCacheRepo() ports.ResponseCacheRepository {
+	return d.valkeyDB.ResponseCacheRepo()
+}
+
 func (d *Datastore) Begin(ctx context.Context) (ports.Transaction, error) {
 	return d.postgresDB.Begin(ctx)
 }
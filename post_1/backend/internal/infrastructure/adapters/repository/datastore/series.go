@@ -0,0 +1,49 @@
+package datastore_adapter
+
+import (
+	"context"
+
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/app/core/ports"
+)
+
+// invalidatingSeriesRepo drops the public responses after every change of the
+// series, the articles render their place in their series.
+type invalidatingSeriesRepo struct {
+	ports.SeriesRepository
+	invalidators []func(ctx context.Context) error
+}
+
+func newInvalidatingSeriesRepo(repo ports.SeriesRepository, invalidators ...func(ctx context.Context) error) *invalidatingSeriesRepo {
+	return &invalidatingSeriesRepo{
+		SeriesRepository: repo,
+		invalidators:     invalidators,
+	}
+}
+
+func (r *invalidatingSeriesRepo) invalidate(ctx context.Context, err error) {
+	if err != nil {
+		return
+	}
+	for _, invalidate := range r.invalidators {
+		_ = invalidate(ctx)
+	}
+}
+
+func (r *invalidatingSeriesRepo) CreateSeries(ctx context.Context, series domain.Series, articleIDs []int32) (domain.Series, error) {
+	series, err := r.SeriesRepository.CreateSeries(ctx, series, articleIDs)
+	r.invalidate(ctx, err)
+	return series, err
+}
+
+func (r *invalidatingSeriesRepo) SetSeriesArticles(ctx context.Context, id int32, articleIDs []int32) error {
+	err := r.SeriesRepository.SetSeriesArticles(ctx, id, articleIDs)
+	r.invalidate(ctx, err)
+	return err
+}
+
+func (r *invalidatingSeriesRepo) DeleteSeries(ctx context.Context, id int32) error {
+	err := r.SeriesRepository.DeleteSeries(ctx, id)
+	r.invalidate(ctx, err)
+	return err
+}
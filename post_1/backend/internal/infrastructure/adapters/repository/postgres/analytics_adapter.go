@@ -0,0 +1,82 @@
+package postgres_adapter
+
+import (
+	"context"
+	"time"
+
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/infrastructure/adapters/repository/postgres/sqlc"
+)
+
+type analyticsAdapter struct {
+	queries *sqlc.Queries
+}
+
+func NewAnalyticsAdapter(queries *sqlc.Queries) *analyticsAdapter {
+	return &analyticsAdapter{
+		queries: queries,
+	}
+}
+
+func (a *analyticsAdapter) AddArticleViews(ctx context.Context, views []domain.ArticleViews) error {
+	params := sqlc.AddArticleViewsParams{
+		ArticleIds: make([]int32, len(views)),
+		Days:       make([]time.Time, len(views)),
+		Views:      make([]int64, len(views)),
+	}
+	for i, view := range views {
+		params.ArticleIds[i] = view.ArticleID
+		params.Days[i] = view.Day
+		params.Views[i] = view.Views
+	}
+
+	// A single statement, so that a failed flush can be retried as a whole
+	err := a.queries.AddArticleViews(ctx, params)
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+	return nil
+}
+
+func (a *analyticsAdapter) ListArticleViews(ctx context.Context, articleID int32, dateRange domain.DateRange) ([]domain.ArticleViews, error) {
+	rows, err := a.queries.ListArticleViews(ctx, sqlc.ListArticleViewsParams{
+		ArticleID: articleID,
+		FromDay:   dateRange.From,
+		ToDay:     dateRange.To,
+	})
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	views := make([]domain.ArticleViews, 0, len(rows))
+	for _, row := range rows {
+		views = append(views, domain.ArticleViews{
+			ArticleID: articleID,
+			Day:       domain.ViewDay(row.Day),
+			Views:     row.Views,
+		})
+	}
+	return views, nil
+}
+
+func (a *analyticsAdapter) ListTopArticles(ctx context.Context, dateRange domain.DateRange, limit int32) ([]domain.TopArticle, error) {
+	rows, err := a.queries.ListTopArticles(ctx, sqlc.ListTopArticlesParams{
+		FromDay:  dateRange.From,
+		ToDay:    dateRange.To,
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	articles := make([]domain.TopArticle, 0, len(rows))
+	for _, row := range rows {
+		articles = append(articles, domain.TopArticle{
+			ArticleID: row.ID,
+			Title:     row.Title,
+			Slug:      row.Slug,
+			Views:     row.Views,
+		})
+	}
+	return articles, nil
+}
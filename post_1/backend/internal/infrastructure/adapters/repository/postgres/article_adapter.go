@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"personal_website/internal/app/core/domain"
 	"personal_website/internal/infrastructure/adapters/repository/postgres/sqlc"
@@ -22,10 +23,15 @@ func NewArticleAdapter(queries *sqlc.Queries) *articleAdapter {
 }
 
 func (a *articleAdapter) CreateArticle(ctx context.Context, article domain.Article) error {
+	if err := a.checkSlugNotRetired(ctx, article.Slug, 0); err != nil {
+		return err
+	}
+
 	err := a.queries.CreateArticle(ctx, sqlc.CreateArticleParams{
 		Title:   article.Title,
 		Slug:    article.Slug,
 		Content: article.Content,
+		Tags:    article.Tags,
 	})
 	if err != nil {
 		var pgErr *pq.Error
@@ -48,63 +54,117 @@ func (a *articleAdapter) GetArticleByID(ctx context.Context, id int32) (domain.A
 		}
 		return domain.Article{}, domain.NewInternalError(err)
 	}
-	return a.sqlcRowToArticle(row.ID, row.Title, row.Slug, row.Content, row.CreatedAt, row.PublishedAt, row.IsPublished, sql.NullTime{}, sql.NullBool{}), nil
+	article := a.sqlcRowToArticle(row.ID, row.Title, row.Slug, row.Content, row.CreatedAt, row.PublishedAt, row.IsPublished, sql.NullTime{}, sql.NullBool{})
+	article.Tags = row.Tags
+	article.Version = row.Version
+	return article, nil
 }
 
 func (a *articleAdapter) GetArticleBySlug(ctx context.Context, slug string) (domain.Article, error) {
 	row, err := a.queries.GetArticleBySlug(ctx, slug)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return domain.Article{}, domain.ErrArticleNotFound
+			return domain.Article{}, a.slugRedirect(ctx, slug)
 		}
 		return domain.Article{}, domain.NewInternalError(err)
 	}
-	return a.sqlcRowToArticle(row.ID, row.Title, row.Slug, row.Content, row.CreatedAt, row.PublishedAt, row.IsPublished, sql.NullTime{}, sql.NullBool{}), nil
+	article := a.sqlcRowToArticle(row.ID, row.Title, row.Slug, row.Content, row.CreatedAt, row.PublishedAt, row.IsPublished, row.UpdatedAt, sql.NullBool{})
+	article.Tags = row.Tags
+	article.Version = row.Version
+	return article, nil
 }
 
-func (a *articleAdapter) UpdateArticle(ctx context.Context, article domain.Article) error {
-	err := a.queries.UpdateArticle(ctx, sqlc.UpdateArticleParams{
+func (a *articleAdapter) UpdateArticle(ctx context.Context, article domain.Article) (int32, error) {
+	if err := a.checkSlugNotRetired(ctx, article.Slug, article.ID); err != nil {
+		return 0, err
+	}
+
+	version, err := a.queries.UpdateArticle(ctx, sqlc.UpdateArticleParams{
 		ID:      article.ID,
 		Title:   article.Title,
 		Slug:    article.Slug,
 		Content: article.Content,
+		Tags:    article.Tags,
+		Version: article.Version,
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return domain.ErrArticleNotFound
+			return 0, a.versionError(ctx, article.ID)
 		}
 		var pgErr *pq.Error
 		if errors.As(err, &pgErr) {
 			switch pgErr.Code {
 			case "23505": // unique_violation
-				return domain.ErrArticleAlreadyExists
+				return 0, domain.ErrArticleAlreadyExists
 			}
 		}
-		return domain.NewInternalError(err)
+		return 0, domain.NewInternalError(err)
 	}
-	return nil
+	return version, nil
+}
+
+func (a *articleAdapter) PublishArticle(ctx context.Context, id int32, version int32) (int32, error) {
+	version, err := a.queries.PublishArticle(ctx, sqlc.PublishArticleParams{ID: id, Version: version})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, a.versionError(ctx, id)
+		}
+		return 0, domain.NewInternalError(err)
+	}
+	return version, nil
 }
 
-func (a *articleAdapter) PublishArticle(ctx context.Context, id int32) error {
-	err := a.queries.PublishArticle(ctx, id)
+func (a *articleAdapter) UnpublishArticle(ctx context.Context, id int32, version int32) (int32, error) {
+	version, err := a.queries.UnpublishArticle(ctx, sqlc.UnpublishArticleParams{ID: id, Version: version})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, a.versionError(ctx, id)
+		}
+		return 0, domain.NewInternalError(err)
+	}
+	return version, nil
+}
+
+// slugRedirect tells why no published article has the slug: either it is a
+// retired slug of one, or the article does not exist.
+func (a *articleAdapter) slugRedirect(ctx context.Context, slug string) error {
+	current, err := a.queries.GetArticleSlugRedirect(ctx, slug)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.ErrArticleNotFound
 		}
 		return domain.NewInternalError(err)
 	}
+	return domain.NewArticleMovedError(current)
+}
+
+// checkSlugNotRetired refuses to give the article, zero for a new one, a slug
+// retired by another article: its links redirect to the other article.
+func (a *articleAdapter) checkSlugNotRetired(ctx context.Context, slug string, id int32) error {
+	articleID, err := a.queries.GetRetiredSlugArticleID(ctx, slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return domain.NewInternalError(err)
+	}
+	if articleID != id {
+		return domain.ErrArticleSlugRetired
+	}
 	return nil
 }
 
-func (a *articleAdapter) UnpublishArticle(ctx context.Context, id int32) error {
-	err := a.queries.UnpublishArticle(ctx, id)
+// versionError tells why a change of the article was not applied: either it
+// does not exist, or it has another version.
+func (a *articleAdapter) versionError(ctx context.Context, id int32) error {
+	_, err := a.queries.GetAllArticlesByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.ErrArticleNotFound
 		}
 		return domain.NewInternalError(err)
 	}
-	return nil
+	return domain.ErrArticleVersionMismatch
 }
 
 func (a *articleAdapter) ListArticles(ctx context.Context) ([]domain.Article, error) {
@@ -115,7 +175,9 @@ func (a *articleAdapter) ListArticles(ctx context.Context) ([]domain.Article, er
 
 	articles := make([]domain.Article, 0, len(rows))
 	for _, row := range rows {
-		article := a.sqlcRowToArticle(row.ID, row.Title, row.Slug, "", sql.NullTime{}, row.PublishedAt, sql.NullBool{Valid: true, Bool: true}, sql.NullTime{}, sql.NullBool{})
+		article := a.sqlcRowToArticle(row.ID, row.Title, row.Slug, "", sql.NullTime{}, row.PublishedAt, sql.NullBool{Valid: true, Bool: true}, row.UpdatedAt, sql.NullBool{})
+		article.Tags = row.Tags
+		article.Version = row.Version
 		articles = append(articles, article)
 	}
 	return articles, nil
@@ -130,6 +192,8 @@ func (a *articleAdapter) ListAllArticles(ctx context.Context) ([]domain.Article,
 	articles := make([]domain.Article, 0, len(rows))
 	for _, row := range rows {
 		article := a.sqlcRowToArticle(row.ID, row.Title, row.Slug, "", row.CreatedAt, row.PublishedAt, row.IsPublished, row.UpdatedAt, sql.NullBool{})
+		article.Tags = row.Tags
+		article.Version = row.Version
 		articles = append(articles, article)
 	}
 	return articles, nil
@@ -152,14 +216,14 @@ func (a *articleAdapter) ListDeletedArticles(ctx context.Context) ([]domain.Arti
 	return articles, nil
 }
 
-func (a *articleAdapter) SoftDeleteArticle(ctx context.Context, id int32) error {
-	rowsAffected, err := a.queries.SoftDeleteArticle(ctx, id)
+func (a *articleAdapter) SoftDeleteArticle(ctx context.Context, id int32, version int32) error {
+	_, err := a.queries.SoftDeleteArticle(ctx, sqlc.SoftDeleteArticleParams{ID: id, Version: version})
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return a.versionError(ctx, id)
+		}
 		return domain.NewInternalError(err)
 	}
-	if rowsAffected == 0 {
-		return domain.ErrArticleNotFound
-	}
 	return nil
 }
 
@@ -202,6 +266,92 @@ func (a *articleAdapter) RestoreArticle(ctx context.Context, id int32) error {
 	return nil
 }
 
+func (a *articleAdapter) UpsertArticleBySlug(ctx context.Context, article domain.Article) (int32, bool, error) {
+	// A retired slug is never the slug of an article, the upsert would create
+	// one
+	if err := a.checkSlugNotRetired(ctx, article.Slug, 0); err != nil {
+		return 0, false, err
+	}
+
+	tags := article.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	row, err := a.queries.UpsertArticleBySlug(ctx, sqlc.UpsertArticleBySlugParams{
+		Title:       article.Title,
+		Slug:        article.Slug,
+		Content:     article.Content,
+		Tags:        tags,
+		IsPublished: article.IsPublished,
+		PublishedAt: sql.NullTime{Time: article.PublishedAt, Valid: !article.PublishedAt.IsZero()},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, domain.ErrArticleInTrash
+		}
+		return 0, false, domain.NewInternalError(err)
+	}
+	return row.ID, row.Created, nil
+}
+
+func (a *articleAdapter) ListAllArticlesWithContent(ctx context.Context) ([]domain.Article, error) {
+	rows, err := a.queries.ListAllArticlesWithContent(ctx)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	articles := make([]domain.Article, 0, len(rows))
+	for _, row := range rows {
+		article := a.sqlcRowToArticle(row.ID, row.Title, row.Slug, row.Content, row.CreatedAt, row.PublishedAt, row.IsPublished, sql.NullTime{}, sql.NullBool{})
+		article.Tags = row.Tags
+		articles = append(articles, article)
+	}
+	return articles, nil
+}
+
+func (a *articleAdapter) ListArticleSources(ctx context.Context) ([]domain.ArticleSource, error) {
+	rows, err := a.queries.ListArticleSources(ctx)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	sources := make([]domain.ArticleSource, 0, len(rows))
+	for _, row := range rows {
+		sources = append(sources, domain.ArticleSource{
+			ArticleID:   row.ArticleID,
+			Slug:        row.Slug,
+			Path:        row.Path,
+			ContentHash: row.ContentHash,
+			CommitSHA:   row.CommitSha,
+			IsDeleted:   row.IsDeleted.Valid && row.IsDeleted.Bool,
+			SyncedAt:    row.SyncedAt,
+		})
+	}
+	return sources, nil
+}
+
+func (a *articleAdapter) SaveArticleSource(ctx context.Context, source domain.ArticleSource) error {
+	err := a.queries.SaveArticleSource(ctx, sqlc.SaveArticleSourceParams{
+		ArticleID:   source.ArticleID,
+		Path:        source.Path,
+		ContentHash: source.ContentHash,
+		CommitSha:   source.CommitSHA,
+	})
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+	return nil
+}
+
+func (a *articleAdapter) GetArticlesLastModified(ctx context.Context) (time.Time, error) {
+	lastModified, err := a.queries.GetArticlesLastModified(ctx)
+	if err != nil {
+		return time.Time{}, domain.NewInternalError(err)
+	}
+	return lastModified, nil
+}
+
 func (a *articleAdapter) sqlcRowToArticle(id int32, title, slug, content string, createdAt, publishedAt sql.NullTime, isPublished sql.NullBool, updatedAt sql.NullTime, isDeleted sql.NullBool) domain.Article {
 	article := domain.Article{
 		ID:      id,
@@ -218,6 +368,10 @@ func (a *articleAdapter) sqlcRowToArticle(id int32, title, slug, content string,
 		article.PublishedAt = publishedAt.Time
 	}
 
+	if updatedAt.Valid {
+		article.UpdatedAt = updatedAt.Time
+	}
+
 	if isPublished.Valid {
 		article.IsPublished = isPublished.Bool
 	}
@@ -6,6 +6,7 @@ import (
 	"personal_website/config"
 	"personal_website/internal/app/core/ports"
 	"personal_website/internal/infrastructure/adapters/repository/postgres/sqlc"
+	"personal_website/pkg/telemetry"
 )
 
 type database struct {
@@ -14,6 +15,11 @@ type database struct {
 	articleRepo    ports.ArticleRepository
 	userRepo       ports.UserRepository
 	permissionRepo ports.PermissionRepository
+	subscriberRepo ports.SubscriberRepository
+	resumeRepo     ports.ResumeRepository
+	analyticsRepo  ports.AnalyticsRepository
+	previewRepo    ports.PreviewLinkRepository
+	seriesRepo     ports.SeriesRepository
 }
 
 func NewDatabase(cfg *config.PostgresConfig) (*database, error) {
@@ -21,7 +27,13 @@ func NewDatabase(cfg *config.PostgresConfig) (*database, error) {
 	if err != nil {
 		return &database{}, err
 	}
-	queries := sqlc.New(db)
+
+	if err := telemetry.InstrumentDBStats(db, cfg.Database.String()); err != nil {
+		db.Close()
+		return &database{}, err
+	}
+
+	queries := sqlc.New(newInstrumentedDBTX(db))
 
 	return &database{
 		db:             db,
@@ -29,12 +41,22 @@ func NewDatabase(cfg *config.PostgresConfig) (*database, error) {
 		articleRepo:    NewArticleAdapter(queries),
 		userRepo:       NewUserAdapter(queries),
 		permissionRepo: NewPermissionAdapter(queries),
+		subscriberRepo: NewSubscriberAdapter(queries),
+		resumeRepo:     NewResumeAdapter(queries),
+		analyticsRepo:  NewAnalyticsAdapter(queries),
+		previewRepo:    NewPreviewLinkAdapter(queries),
+		seriesRepo:     NewSeriesAdapter(queries),
 	}, nil
 }
 
-func (d *database) UserRepo() ports.UserRepository             { return d.userRepo }
-func (d *database) ArticleRepo() ports.ArticleRepository       { return d.articleRepo }
-func (d *database) PermissionRepo() ports.PermissionRepository { return d.permissionRepo }
+func (d *database) UserRepo() ports.UserRepository               { return d.userRepo }
+func (d *database) ArticleRepo() ports.ArticleRepository         { return d.articleRepo }
+func (d *database) PermissionRepo() ports.PermissionRepository   { return d.permissionRepo }
+func (d *database) SubscriberRepo() ports.SubscriberRepository   { return d.subscriberRepo }
+func (d *database) ResumeRepo() ports.ResumeRepository           { return d.resumeRepo }
+func (d *database) AnalyticsRepo() ports.AnalyticsRepository     { return d.analyticsRepo }
+func (d *database) PreviewLinkRepo() ports.PreviewLinkRepository { return d.previewRepo }
+func (d *database) SeriesRepo() ports.SeriesRepository           { return d.seriesRepo }
 
 func (d *database) Begin(ctx context.Context) (ports.Transaction, error) {
 	tx, err := d.db.BeginTx(ctx, nil)
@@ -42,14 +64,19 @@ func (d *database) Begin(ctx context.Context) (ports.Transaction, error) {
 		return nil, err
 	}
 
-	qtx := sqlc.New(tx)
+	qtx := sqlc.New(newInstrumentedDBTX(tx))
 	return &transaction{
-		tx:       tx,
-		queries:  qtx,
-		userRepo: NewUserAdapter(qtx),
+		tx:             tx,
+		queries:        qtx,
+		userRepo:       NewUserAdapter(qtx),
+		permissionRepo: NewPermissionAdapter(qtx),
 	}, nil
 }
 
+func (d *database) Ping(ctx context.Context) error {
+	return d.db.PingContext(ctx)
+}
+
 func (d *database) Close() {
 	d.db.Close()
 }
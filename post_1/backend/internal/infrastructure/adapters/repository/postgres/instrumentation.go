@@ -0,0 +1,109 @@
+package postgres_adapter
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"personal_website/internal/infrastructure/adapters/repository/postgres/sqlc"
+	"personal_website/pkg/telemetry"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "personal_website/internal/infrastructure/adapters/repository/postgres"
+
+// queryMetrics is shared by every instrumentedDBTX, transactions included.
+var queryMetrics = telemetry.NewClientMetrics("db_query", "database query")
+
+// instrumentedDBTX creates a client span and records the latency of every
+// query run by sqlc, named after the "-- name:" annotation that sqlc keeps at
+// the top of the query.
+type instrumentedDBTX struct {
+	db     sqlc.DBTX
+	tracer trace.Tracer
+}
+
+func newInstrumentedDBTX(db sqlc.DBTX) *instrumentedDBTX {
+	return &instrumentedDBTX{
+		db:     db,
+		tracer: otel.Tracer(tracerName),
+	}
+}
+
+func (t *instrumentedDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := t.start(ctx, query)
+
+	result, err := t.db.ExecContext(ctx, query, args...)
+	t.end(ctx, span, err)
+	return result, err
+}
+
+func (t *instrumentedDBTX) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	ctx, span := t.start(ctx, query)
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	t.end(ctx, span, err)
+	return stmt, err
+}
+
+func (t *instrumentedDBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := t.start(ctx, query)
+
+	rows, err := t.db.QueryContext(ctx, query, args...)
+	t.end(ctx, span, err)
+	return rows, err
+}
+
+func (t *instrumentedDBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span := t.start(ctx, query)
+
+	row := t.db.QueryRowContext(ctx, query, args...)
+	err := row.Err()
+	if err == sql.ErrNoRows {
+		err = nil
+	}
+	t.end(ctx, span, err)
+	return row
+}
+
+type querySpan struct {
+	trace.Span
+	name  string
+	start time.Time
+}
+
+func (t *instrumentedDBTX) start(ctx context.Context, query string) (context.Context, querySpan) {
+	name := queryName(query)
+	ctx, span := t.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", "postgresql"),
+			attribute.String("db.operation.name", name),
+		),
+	)
+	return ctx, querySpan{Span: span, name: name, start: time.Now()}
+}
+
+func (t *instrumentedDBTX) end(ctx context.Context, span querySpan, err error) {
+	queryMetrics.Record(ctx, span.name, span.start, err)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// queryName extracts ActivateUser from "-- name: ActivateUser :exec".
+func queryName(query string) string {
+	line, _, _ := strings.Cut(query, "\n")
+	fields := strings.Fields(strings.TrimPrefix(line, "-- name:"))
+	if !strings.HasPrefix(line, "-- name:") || len(fields) == 0 {
+		return "query"
+	}
+	return fields[0]
+}
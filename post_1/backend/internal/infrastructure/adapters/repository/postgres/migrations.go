@@ -0,0 +1,181 @@
+package postgres_adapter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+
+	"personal_website/sql/schemas"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/lib/pq"
+)
+
+// migrationsLockID is the key of the advisory lock taken while migrating, so
+// that replicas starting together check and migrate the schema one at a time.
+const migrationsLockID = 7_345_112_001
+
+var (
+	ErrSchemaAhead  = errors.New("the database schema is newer than this binary")
+	ErrSchemaBehind = errors.New("the database schema has pending migrations")
+	ErrSchemaDirty  = errors.New("the last database migration failed, fix the database and force its version")
+)
+
+// LatestSchemaVersion returns the version of the last migration embedded in
+// the binary.
+var LatestSchemaVersion = sync.OnceValue(func() uint {
+	source, err := iofs.New(schemas.FS, ".")
+	if err != nil {
+		panic(fmt.Sprintf("reading the embedded migrations: %v", err))
+	}
+	defer source.Close()
+
+	version, err := source.First()
+	if err != nil {
+		panic(fmt.Sprintf("reading the embedded migrations: %v", err))
+	}
+	for {
+		next, err := source.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version
+		}
+		if err != nil {
+			panic(fmt.Sprintf("reading the embedded migrations: %v", err))
+		}
+		version = next
+	}
+})
+
+// Migrate applies the pending migrations and returns the schema version. It
+// refuses to touch a schema newer than the binary.
+func (d *database) Migrate(ctx context.Context) (uint, error) {
+	var version uint
+	err := d.withMigrate(ctx, func(m *migrate.Migrate) error {
+		if _, err := schemaVersion(m); err != nil && !errors.Is(err, ErrSchemaBehind) {
+			return err
+		}
+
+		if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return err
+		}
+		var err error
+		version, err = schemaVersion(m)
+		return err
+	})
+	return version, err
+}
+
+// Rollback rolls back the given number of migrations, or all of them when
+// steps is zero, and returns the schema version.
+func (d *database) Rollback(ctx context.Context, steps int) (uint, error) {
+	var version uint
+	err := d.withMigrate(ctx, func(m *migrate.Migrate) error {
+		var err error
+		if steps == 0 {
+			err = m.Down()
+		} else {
+			err = m.Steps(-steps)
+		}
+		if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return err
+		}
+
+		version, err = schemaVersion(m)
+		if errors.Is(err, ErrSchemaBehind) {
+			return nil
+		}
+		return err
+	})
+	return version, err
+}
+
+// SchemaVersion returns the schema version of the database. The error tells
+// whether it differs from LatestSchemaVersion, or is dirty. It reads the
+// version table directly, without the lock, for the health checks.
+func (d *database) SchemaVersion(ctx context.Context) (uint, error) {
+	var version int64
+	var dirty bool
+	err := d.db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	var pgErr *pq.Error
+	if errors.Is(err, sql.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == "42P01") { // undefined_table
+		return 0, compareSchemaVersion(0, false)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return uint(version), compareSchemaVersion(uint(version), dirty)
+}
+
+func schemaVersion(m *migrate.Migrate) (uint, error) {
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, compareSchemaVersion(0, false)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return version, compareSchemaVersion(version, dirty)
+}
+
+// compareSchemaVersion compares the version of the database, 0 when no
+// migration was applied, to the embedded migrations.
+func compareSchemaVersion(version uint, dirty bool) error {
+	latest := LatestSchemaVersion()
+	switch {
+	case dirty:
+		return fmt.Errorf("%w: version %d", ErrSchemaDirty, version)
+	case version > latest:
+		return fmt.Errorf("%w: version %d, expected %d", ErrSchemaAhead, version, latest)
+	case version < latest:
+		return fmt.Errorf("%w: version %d, expected %d", ErrSchemaBehind, version, latest)
+	}
+	return nil
+}
+
+// withMigrate runs fn with the embedded migrations while holding the
+// migrations advisory lock. The lock is taken on its own connection: a
+// connection returned to the pool would otherwise keep holding it. The driver
+// gets its own connection too, closing a driver made with WithInstance would
+// close the pool of the application.
+func (d *database) withMigrate(ctx context.Context, fn func(m *migrate.Migrate) error) error {
+	lockConn, err := d.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer lockConn.Close()
+
+	if _, err := lockConn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationsLockID); err != nil {
+		return fmt.Errorf("waiting for the migrations lock: %w", err)
+	}
+	defer lockConn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationsLockID)
+
+	source, err := iofs.New(schemas.FS, ".")
+	if err != nil {
+		return err
+	}
+	conn, err := d.db.Conn(ctx)
+	if err != nil {
+		source.Close()
+		return err
+	}
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
+	if err != nil {
+		source.Close()
+		conn.Close()
+		return err
+	}
+	m, err := migrate.NewWithInstance("iofs", source, "postgres", driver)
+	if err != nil {
+		source.Close()
+		driver.Close()
+		return err
+	}
+	defer m.Close()
+
+	return fn(m)
+}
@@ -19,3 +19,48 @@ func NewPermissionAdapter(queries *sqlc.Queries) *permissionAdapter {
 func (p *permissionAdapter) GetPermissions(ctx context.Context, user *domain.User) (domain.Permissions, error) {
 	return p.queries.GetPermissions(ctx, int32(user.ID))
 }
+
+func (p *permissionAdapter) GrantPermission(ctx context.Context, userID int, code string) (bool, error) {
+	granted, err := p.queries.GrantPermission(ctx, sqlc.GrantPermissionParams{
+		UserID: int64(userID),
+		Code:   code,
+	})
+	if err != nil {
+		return false, domain.NewInternalError(err)
+	}
+	if granted > 0 {
+		return true, nil
+	}
+
+	// Nothing inserted: either already granted or an unknown permission
+	exists, err := p.queries.PermissionExists(ctx, code)
+	if err != nil {
+		return false, domain.NewInternalError(err)
+	}
+	if !exists {
+		return false, domain.ErrPermissionNotFound
+	}
+	return false, nil
+}
+
+func (p *permissionAdapter) RevokePermission(ctx context.Context, userID int, code string) (bool, error) {
+	revoked, err := p.queries.RevokePermission(ctx, sqlc.RevokePermissionParams{
+		UserID: int64(userID),
+		Code:   code,
+	})
+	if err != nil {
+		return false, domain.NewInternalError(err)
+	}
+	if revoked > 0 {
+		return true, nil
+	}
+
+	exists, err := p.queries.PermissionExists(ctx, code)
+	if err != nil {
+		return false, domain.NewInternalError(err)
+	}
+	if !exists {
+		return false, domain.ErrPermissionNotFound
+	}
+	return false, nil
+}
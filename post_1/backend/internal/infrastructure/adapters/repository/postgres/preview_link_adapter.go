@@ -0,0 +1,92 @@
+package postgres_adapter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/infrastructure/adapters/repository/postgres/sqlc"
+
+	"github.com/lib/pq"
+)
+
+type previewLinkAdapter struct {
+	queries *sqlc.Queries
+}
+
+func NewPreviewLinkAdapter(queries *sqlc.Queries) *previewLinkAdapter {
+	return &previewLinkAdapter{
+		queries: queries,
+	}
+}
+
+func (p *previewLinkAdapter) CreatePreviewLink(ctx context.Context, link domain.PreviewLink) (domain.PreviewLink, error) {
+	row, err := p.queries.CreatePreviewLink(ctx, sqlc.CreatePreviewLinkParams{
+		ArticleID: link.ArticleID,
+		CreatedBy: sql.NullInt32{Int32: int32(link.CreatedBy), Valid: link.CreatedBy != 0},
+		ExpiresAt: link.ExpiresAt,
+	})
+	if err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23503": // foreign_key_violation
+				return domain.PreviewLink{}, domain.ErrArticleNotFound
+			}
+		}
+		return domain.PreviewLink{}, domain.NewInternalError(err)
+	}
+	return previewLinkFromRow(row), nil
+}
+
+func (p *previewLinkAdapter) GetPreviewLink(ctx context.Context, id int32) (domain.PreviewLink, error) {
+	row, err := p.queries.GetPreviewLink(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.PreviewLink{}, domain.ErrPreviewLinkNotFound
+		}
+		return domain.PreviewLink{}, domain.NewInternalError(err)
+	}
+	return previewLinkFromRow(row), nil
+}
+
+func (p *previewLinkAdapter) ListPreviewLinks(ctx context.Context, articleID int32) ([]domain.PreviewLink, error) {
+	rows, err := p.queries.ListPreviewLinks(ctx, articleID)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	links := make([]domain.PreviewLink, 0, len(rows))
+	for _, row := range rows {
+		links = append(links, previewLinkFromRow(row))
+	}
+	return links, nil
+}
+
+func (p *previewLinkAdapter) RevokePreviewLink(ctx context.Context, articleID int32, id int32) error {
+	rowsAffected, err := p.queries.RevokePreviewLink(ctx, sqlc.RevokePreviewLinkParams{ID: id, ArticleID: articleID})
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrPreviewLinkNotFound
+	}
+	return nil
+}
+
+func previewLinkFromRow(row sqlc.ContentPreviewLink) domain.PreviewLink {
+	link := domain.PreviewLink{
+		ID:        row.ID,
+		ArticleID: row.ArticleID,
+		CreatedAt: row.CreatedAt,
+		ExpiresAt: row.ExpiresAt,
+	}
+	if row.CreatedBy.Valid {
+		link.CreatedBy = int(row.CreatedBy.Int32)
+	}
+	if row.RevokedAt.Valid {
+		link.RevokedAt = row.RevokedAt.Time
+	}
+	return link
+}
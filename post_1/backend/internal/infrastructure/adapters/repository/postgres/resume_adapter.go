@@ -0,0 +1,100 @@
+package postgres_adapter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/infrastructure/adapters/repository/postgres/sqlc"
+)
+
+type resumeAdapter struct {
+	queries *sqlc.Queries
+}
+
+func NewResumeAdapter(queries *sqlc.Queries) *resumeAdapter {
+	return &resumeAdapter{
+		queries: queries,
+	}
+}
+
+func (r *resumeAdapter) CreateResumeVersion(ctx context.Context, version domain.ResumeVersion) (domain.ResumeVersion, error) {
+	row, err := r.queries.CreateResumeVersion(ctx, sqlc.CreateResumeVersionParams{
+		ObjectName:  version.ObjectName,
+		FileName:    version.FileName,
+		ContentType: version.ContentType,
+		Size:        version.Size,
+		Checksum:    version.Checksum,
+		UploadedBy:  sql.NullInt32{Int32: int32(version.UploadedBy), Valid: version.UploadedBy != 0},
+	})
+	if err != nil {
+		return domain.ResumeVersion{}, domain.NewInternalError(err)
+	}
+	return resumeVersionFromRow(row), nil
+}
+
+func (r *resumeAdapter) GetResumeVersionByID(ctx context.Context, id int32) (domain.ResumeVersion, error) {
+	row, err := r.queries.GetResumeVersionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ResumeVersion{}, domain.ErrResumeVersionNotFound
+		}
+		return domain.ResumeVersion{}, domain.NewInternalError(err)
+	}
+	return resumeVersionFromRow(row), nil
+}
+
+func (r *resumeAdapter) GetCurrentResumeVersion(ctx context.Context) (domain.ResumeVersion, error) {
+	row, err := r.queries.GetCurrentResumeVersion(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ResumeVersion{}, domain.ErrResumeNotFound
+		}
+		return domain.ResumeVersion{}, domain.NewInternalError(err)
+	}
+	return resumeVersionFromRow(row), nil
+}
+
+func (r *resumeAdapter) ListResumeVersions(ctx context.Context) ([]domain.ResumeVersion, error) {
+	rows, err := r.queries.ListResumeVersions(ctx)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	versions := make([]domain.ResumeVersion, 0, len(rows))
+	for _, row := range rows {
+		versions = append(versions, resumeVersionFromRow(row))
+	}
+	return versions, nil
+}
+
+func (r *resumeAdapter) SetCurrentResumeVersion(ctx context.Context, id int32) error {
+	rowsAffected, err := r.queries.SetCurrentResumeVersion(ctx, id)
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrResumeVersionNotFound
+	}
+	return nil
+}
+
+func resumeVersionFromRow(row sqlc.ResumeVersion) domain.ResumeVersion {
+	version := domain.ResumeVersion{
+		ID:          row.ID,
+		ObjectName:  row.ObjectName,
+		FileName:    row.FileName,
+		ContentType: row.ContentType,
+		Size:        row.Size,
+		Checksum:    row.Checksum,
+		IsCurrent:   row.IsCurrent,
+	}
+	if row.UploadedBy.Valid {
+		version.UploadedBy = int(row.UploadedBy.Int32)
+	}
+	if row.CreatedAt.Valid {
+		version.CreatedAt = row.CreatedAt.Time
+	}
+	return version
+}
@@ -0,0 +1,229 @@
+package postgres_adapter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/infrastructure/adapters/repository/postgres/sqlc"
+
+	"github.com/lib/pq"
+)
+
+type seriesAdapter struct {
+	queries *sqlc.Queries
+}
+
+func NewSeriesAdapter(queries *sqlc.Queries) *seriesAdapter {
+	return &seriesAdapter{
+		queries: queries,
+	}
+}
+
+func (s *seriesAdapter) CreateSeries(ctx context.Context, series domain.Series, articleIDs []int32) (domain.Series, error) {
+	row, err := s.queries.CreateSeries(ctx, sqlc.CreateSeriesParams{
+		Title:       series.Title,
+		Slug:        series.Slug,
+		Description: series.Description,
+		ArticleIds:  articleIDs,
+	})
+	if err != nil {
+		return domain.Series{}, seriesWriteError(err)
+	}
+
+	created := seriesFromRow(row)
+	created.Articles, err = s.listArticles(ctx, created.ID, true)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	return created, nil
+}
+
+func (s *seriesAdapter) ListSeries(ctx context.Context) ([]domain.Series, error) {
+	return s.list(ctx, false)
+}
+
+func (s *seriesAdapter) ListAllSeries(ctx context.Context) ([]domain.Series, error) {
+	return s.list(ctx, true)
+}
+
+func (s *seriesAdapter) GetSeriesBySlug(ctx context.Context, slug string) (domain.Series, error) {
+	row, err := s.queries.GetSeriesBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Series{}, domain.ErrSeriesNotFound
+		}
+		return domain.Series{}, domain.NewInternalError(err)
+	}
+
+	series := seriesFromRow(row)
+	series.Articles, err = s.listArticles(ctx, series.ID, false)
+	if err != nil {
+		return domain.Series{}, err
+	}
+
+	// A series is public once one of its articles is
+	if len(series.Articles) == 0 {
+		return domain.Series{}, domain.ErrSeriesNotFound
+	}
+	return series, nil
+}
+
+func (s *seriesAdapter) GetArticleSeries(ctx context.Context, articleID int32) (domain.Series, error) {
+	row, err := s.queries.GetArticleSeries(ctx, articleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Series{}, domain.ErrSeriesNotFound
+		}
+		return domain.Series{}, domain.NewInternalError(err)
+	}
+
+	series := seriesFromRow(row)
+	series.Articles, err = s.listArticles(ctx, series.ID, false)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	return series, nil
+}
+
+func (s *seriesAdapter) GetSeriesByID(ctx context.Context, id int32) (domain.Series, error) {
+	row, err := s.queries.GetSeriesByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Series{}, domain.ErrSeriesNotFound
+		}
+		return domain.Series{}, domain.NewInternalError(err)
+	}
+
+	series := seriesFromRow(row)
+	series.Articles, err = s.listArticles(ctx, series.ID, true)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	return series, nil
+}
+
+func (s *seriesAdapter) SetSeriesArticles(ctx context.Context, id int32, articleIDs []int32) error {
+	// A NULL array would keep every article
+	if articleIDs == nil {
+		articleIDs = []int32{}
+	}
+
+	rowsAffected, err := s.queries.SetSeriesArticles(ctx, sqlc.SetSeriesArticlesParams{
+		ArticleIds: articleIDs,
+		SeriesID:   id,
+	})
+	if err != nil {
+		return seriesWriteError(err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrSeriesNotFound
+	}
+	return nil
+}
+
+func (s *seriesAdapter) DeleteSeries(ctx context.Context, id int32) error {
+	rowsAffected, err := s.queries.DeleteSeries(ctx, id)
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrSeriesNotFound
+	}
+	return nil
+}
+
+// list reads the series and their articles with two queries.
+func (s *seriesAdapter) list(ctx context.Context, all bool) ([]domain.Series, error) {
+	rows, err := s.queries.ListSeries(ctx, all)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	series := make([]domain.Series, 0, len(rows))
+	ids := make([]int32, 0, len(rows))
+	for _, row := range rows {
+		series = append(series, seriesFromRow(row))
+		ids = append(ids, row.ID)
+	}
+
+	articles, err := s.queries.ListSeriesArticles(ctx, sqlc.ListSeriesArticlesParams{SeriesIds: ids, All: all})
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	bySeries := make(map[int32][]domain.Article, len(series))
+	for _, row := range articles {
+		bySeries[row.SeriesID] = append(bySeries[row.SeriesID], seriesArticleFromRow(row))
+	}
+	for i := range series {
+		series[i].Articles = bySeries[series[i].ID]
+	}
+	return series, nil
+}
+
+func (s *seriesAdapter) listArticles(ctx context.Context, id int32, all bool) ([]domain.Article, error) {
+	rows, err := s.queries.ListSeriesArticles(ctx, sqlc.ListSeriesArticlesParams{SeriesIds: []int32{id}, All: all})
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	articles := make([]domain.Article, 0, len(rows))
+	for _, row := range rows {
+		articles = append(articles, seriesArticleFromRow(row))
+	}
+	return articles, nil
+}
+
+// seriesWriteError maps the constraints of the series to their domain errors.
+func seriesWriteError(err error) error {
+	var pgErr *pq.Error
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23503": // foreign_key_violation
+			return domain.ErrArticleNotFound
+		case "23505": // unique_violation
+			if pgErr.Constraint == "series_articles_article_id_key" {
+				return domain.ErrArticleInAnotherSeries
+			}
+			return domain.ErrSeriesAlreadyExists
+		}
+	}
+	return domain.NewInternalError(err)
+}
+
+func seriesFromRow(row sqlc.ContentSeries) domain.Series {
+	return domain.Series{
+		ID:          row.ID,
+		Title:       row.Title,
+		Slug:        row.Slug,
+		Description: row.Description,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+}
+
+func seriesArticleFromRow(row sqlc.ListSeriesArticlesRow) domain.Article {
+	article := domain.Article{
+		ID:          row.ID,
+		Title:       row.Title,
+		Slug:        row.Slug,
+		Tags:        row.Tags,
+		IsPublished: row.IsPublished.Bool,
+		Version:     row.Version,
+	}
+	if row.CreatedAt.Valid {
+		article.CreatedAt = row.CreatedAt.Time
+	}
+	if row.PublishedAt.Valid {
+		article.PublishedAt = row.PublishedAt.Time
+	}
+	if row.UpdatedAt.Valid {
+		article.UpdatedAt = row.UpdatedAt.Time
+	}
+	if row.DeletedAt.Valid {
+		article.DeletedAt = row.DeletedAt.Time
+	}
+	return article
+}
@@ -8,24 +8,33 @@ package sqlc
 import (
 	"context"
 	"database/sql"
+
+	"github.com/lib/pq"
 )
 
 const createArticle = `-- name: CreateArticle :exec
 INSERT INTO content.articles (
     title,
     slug,
-    content
-) VALUES ($1, $2, $3)
+    content,
+    tags
+) VALUES ($1, $2, $3, COALESCE($4::text[], '{}'))
 `
 
 type CreateArticleParams struct {
 	Title   string
 	Slug    string
 	Content string
+	Tags    []string
 }
 
 func (q *Queries) CreateArticle(ctx context.Context, arg CreateArticleParams) error {
-	_, err := q.db.ExecContext(ctx, createArticle, arg.Title, arg.Slug, arg.Content)
+	_, err := q.db.ExecContext(ctx, createArticle,
+		arg.Title,
+		arg.Slug,
+		arg.Content,
+		pq.Array(arg.Tags),
+	)
 	return err
 }
 
@@ -94,9 +103,11 @@ SELECT
   title,
   slug,
   content,
+  tags,
   created_at,
   published_at,
-  is_published
+  is_published,
+  version
 FROM content.articles
 WHERE id = $1
     AND (is_deleted = false OR is_deleted IS NULL)
@@ -107,9 +118,11 @@ type GetArticleByIdRow struct {
 	Title       string
 	Slug        string
 	Content     string
+	Tags        []string
 	CreatedAt   sql.NullTime
 	PublishedAt sql.NullTime
 	IsPublished sql.NullBool
+	Version     int32
 }
 
 func (q *Queries) GetArticleById(ctx context.Context, id int32) (GetArticleByIdRow, error) {
@@ -120,9 +133,11 @@ func (q *Queries) GetArticleById(ctx context.Context, id int32) (GetArticleByIdR
 		&i.Title,
 		&i.Slug,
 		&i.Content,
+		pq.Array(&i.Tags),
 		&i.CreatedAt,
 		&i.PublishedAt,
 		&i.IsPublished,
+		&i.Version,
 	)
 	return i, err
 }
@@ -132,10 +147,12 @@ SELECT
   id,
   title,
   slug,
+  tags,
   published_at,
   is_published,
   created_at,
-  updated_at
+  updated_at,
+  version
 FROM content.articles
 WHERE is_deleted = false OR is_deleted IS NULL
 ORDER BY created_at DESC
@@ -145,10 +162,12 @@ type ListAllArticlesRow struct {
 	ID          int32
 	Title       string
 	Slug        string
+	Tags        []string
 	PublishedAt sql.NullTime
 	IsPublished sql.NullBool
 	CreatedAt   sql.NullTime
 	UpdatedAt   sql.NullTime
+	Version     int32
 }
 
 func (q *Queries) ListAllArticles(ctx context.Context) ([]ListAllArticlesRow, error) {
@@ -164,10 +183,70 @@ func (q *Queries) ListAllArticles(ctx context.Context) ([]ListAllArticlesRow, er
 			&i.ID,
 			&i.Title,
 			&i.Slug,
+			pq.Array(&i.Tags),
 			&i.PublishedAt,
 			&i.IsPublished,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllArticlesWithContent = `-- name: ListAllArticlesWithContent :many
+SELECT
+  id,
+  title,
+  slug,
+  content,
+  tags,
+  created_at,
+  published_at,
+  is_published
+FROM content.articles
+WHERE is_deleted = false OR is_deleted IS NULL
+ORDER BY slug
+`
+
+type ListAllArticlesWithContentRow struct {
+	ID          int32
+	Title       string
+	Slug        string
+	Content     string
+	Tags        []string
+	CreatedAt   sql.NullTime
+	PublishedAt sql.NullTime
+	IsPublished sql.NullBool
+}
+
+func (q *Queries) ListAllArticlesWithContent(ctx context.Context) ([]ListAllArticlesWithContentRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAllArticlesWithContent)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAllArticlesWithContentRow
+	for rows.Next() {
+		var i ListAllArticlesWithContentRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Slug,
+			&i.Content,
+			pq.Array(&i.Tags),
+			&i.CreatedAt,
+			&i.PublishedAt,
+			&i.IsPublished,
 		); err != nil {
 			return nil, err
 		}
@@ -240,18 +319,28 @@ func (q *Queries) ListDeletedArticles(ctx context.Context) ([]ListDeletedArticle
 	return items, nil
 }
 
-const publishArticle = `-- name: PublishArticle :exec
+const publishArticle = `-- name: PublishArticle :one
 UPDATE content.articles
 SET
     is_published = true,
     published_at = now(),
+    version = version + 1,
     updated_at = now()
 WHERE id = $1
+    AND ($2::integer = 0 OR version = $2)
+RETURNING version
 `
 
-func (q *Queries) PublishArticle(ctx context.Context, id int32) error {
-	_, err := q.db.ExecContext(ctx, publishArticle, id)
-	return err
+type PublishArticleParams struct {
+	ID      int32
+	Version int32
+}
+
+func (q *Queries) PublishArticle(ctx context.Context, arg PublishArticleParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, publishArticle, arg.ID, arg.Version)
+	var version int32
+	err := row.Scan(&version)
+	return version, err
 }
 
 const restoreArticle = `-- name: RestoreArticle :exec
@@ -259,6 +348,7 @@ UPDATE content.articles
 SET
     is_deleted = false,
     deleted_at = NULL,
+    version = version + 1,
     updated_at = now()
 WHERE id = $1
 `
@@ -268,61 +358,166 @@ func (q *Queries) RestoreArticle(ctx context.Context, id int32) error {
 	return err
 }
 
-const softDeleteArticle = `-- name: SoftDeleteArticle :execrows
+const softDeleteArticle = `-- name: SoftDeleteArticle :one
 UPDATE content.articles
 SET
     is_deleted = true,
     is_published = false,
     deleted_at = now(),
+    version = version + 1,
     updated_at = now()
 WHERE id = $1
+    AND ($2::integer = 0 OR version = $2)
+RETURNING version
 `
 
-func (q *Queries) SoftDeleteArticle(ctx context.Context, id int32) (int64, error) {
-	result, err := q.db.ExecContext(ctx, softDeleteArticle, id)
-	if err != nil {
-		return 0, err
-	}
-	return result.RowsAffected()
+type SoftDeleteArticleParams struct {
+	ID      int32
+	Version int32
 }
 
-const unpublishArticle = `-- name: UnpublishArticle :exec
+func (q *Queries) SoftDeleteArticle(ctx context.Context, arg SoftDeleteArticleParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, softDeleteArticle, arg.ID, arg.Version)
+	var version int32
+	err := row.Scan(&version)
+	return version, err
+}
+
+const unpublishArticle = `-- name: UnpublishArticle :one
 UPDATE content.articles
 SET
     is_published = false,
     published_at = NULL,
+    version = version + 1,
     updated_at = now()
 WHERE id = $1
+    AND ($2::integer = 0 OR version = $2)
+RETURNING version
 `
 
-func (q *Queries) UnpublishArticle(ctx context.Context, id int32) error {
-	_, err := q.db.ExecContext(ctx, unpublishArticle, id)
-	return err
+type UnpublishArticleParams struct {
+	ID      int32
+	Version int32
 }
 
-const updateArticle = `-- name: UpdateArticle :exec
-UPDATE content.articles
-SET
-    title = $2,
-    slug = $3,
-    content = $4,
-    updated_at = now()
-WHERE id = $1
+func (q *Queries) UnpublishArticle(ctx context.Context, arg UnpublishArticleParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, unpublishArticle, arg.ID, arg.Version)
+	var version int32
+	err := row.Scan(&version)
+	return version, err
+}
+
+const updateArticle = `-- name: UpdateArticle :one
+WITH updated AS (
+    UPDATE content.articles
+    SET
+        title = $1,
+        slug = $2,
+        content = $3,
+        tags = COALESCE($4::text[], tags),
+        version = version + 1,
+        updated_at = now()
+    WHERE id = $5
+        AND ($6::integer = 0 OR version = $6)
+    RETURNING id, version
+), previous AS (
+    SELECT id, slug FROM content.articles WHERE id = $5
+), retired AS (
+    INSERT INTO content.article_slugs (slug, article_id)
+    SELECT previous.slug, updated.id
+    FROM previous
+    JOIN updated ON updated.id = previous.id
+    WHERE previous.slug <> $2
+    ON CONFLICT (slug) DO NOTHING
+), reclaimed AS (
+    DELETE FROM content.article_slugs s
+    USING updated
+    WHERE s.slug = $2 AND s.article_id = updated.id
+)
+SELECT version FROM updated
 `
 
 type UpdateArticleParams struct {
-	ID      int32
 	Title   string
 	Slug    string
 	Content string
+	Tags    []string
+	ID      int32
+	Version int32
 }
 
-func (q *Queries) UpdateArticle(ctx context.Context, arg UpdateArticleParams) error {
-	_, err := q.db.ExecContext(ctx, updateArticle,
+// The change is only applied to the given version, a zero version applies it
+// to any version. No row is returned when the article does not exist or has
+// another version, like for the publication and the deletion.
+// A replaced slug is retired to redirect to the article, which can take it
+// back. previous reads the article as it was before the update.
+func (q *Queries) UpdateArticle(ctx context.Context, arg UpdateArticleParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, updateArticle,
+		arg.Title,
+		arg.Slug,
+		arg.Content,
+		pq.Array(arg.Tags),
 		arg.ID,
+		arg.Version,
+	)
+	var version int32
+	err := row.Scan(&version)
+	return version, err
+}
+
+const upsertArticleBySlug = `-- name: UpsertArticleBySlug :one
+INSERT INTO content.articles (
+    title,
+    slug,
+    content,
+    tags,
+    is_published,
+    published_at
+) VALUES (
+    $1, $2, $3, $4::text[], $5::boolean,
+    CASE WHEN $5::boolean THEN COALESCE($6::timestamptz, now()) END
+)
+ON CONFLICT (slug) DO UPDATE
+SET
+    title = EXCLUDED.title,
+    content = EXCLUDED.content,
+    tags = EXCLUDED.tags,
+    is_published = EXCLUDED.is_published,
+    published_at = CASE
+        WHEN EXCLUDED.is_published THEN COALESCE($6::timestamptz, content.articles.published_at, now())
+    END,
+    version = content.articles.version + 1,
+    updated_at = now()
+WHERE content.articles.is_deleted = false OR content.articles.is_deleted IS NULL
+RETURNING id, (xmax = 0)::boolean AS created
+`
+
+type UpsertArticleBySlugParams struct {
+	Title       string
+	Slug        string
+	Content     string
+	Tags        []string
+	IsPublished bool
+	PublishedAt sql.NullTime
+}
+
+type UpsertArticleBySlugRow struct {
+	ID      int32
+	Created bool
+}
+
+// A published article keeps its publication date unless one is given. The
+// articles in the trash are not updated, no row is returned for them.
+func (q *Queries) UpsertArticleBySlug(ctx context.Context, arg UpsertArticleBySlugParams) (UpsertArticleBySlugRow, error) {
+	row := q.db.QueryRowContext(ctx, upsertArticleBySlug,
 		arg.Title,
 		arg.Slug,
 		arg.Content,
+		pq.Array(arg.Tags),
+		arg.IsPublished,
+		arg.PublishedAt,
 	)
-	return err
+	var i UpsertArticleBySlugRow
+	err := row.Scan(&i.ID, &i.Created)
+	return i, err
 }
@@ -8,6 +8,9 @@ package sqlc
 import (
 	"context"
 	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 const getArticleBySlug = `-- name: GetArticleBySlug :one
@@ -16,9 +19,12 @@ SELECT
   title,
   slug,
   content,
+  tags,
   created_at,
   published_at,
-  is_published
+  is_published,
+  updated_at,
+  version
 FROM content.articles
 WHERE slug = $1
     AND is_published = true
@@ -30,9 +36,12 @@ type GetArticleBySlugRow struct {
 	Title       string
 	Slug        string
 	Content     string
+	Tags        []string
 	CreatedAt   sql.NullTime
 	PublishedAt sql.NullTime
 	IsPublished sql.NullBool
+	UpdatedAt   sql.NullTime
+	Version     int32
 }
 
 func (q *Queries) GetArticleBySlug(ctx context.Context, slug string) (GetArticleBySlugRow, error) {
@@ -43,19 +52,39 @@ func (q *Queries) GetArticleBySlug(ctx context.Context, slug string) (GetArticle
 		&i.Title,
 		&i.Slug,
 		&i.Content,
+		pq.Array(&i.Tags),
 		&i.CreatedAt,
 		&i.PublishedAt,
 		&i.IsPublished,
+		&i.UpdatedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
+const getArticlesLastModified = `-- name: GetArticlesLastModified :one
+SELECT COALESCE(max(updated_at), 'epoch')::timestamptz AS last_modified
+FROM content.articles
+`
+
+// The unpublished and deleted articles are included, their last change may
+// have removed them from the published ones.
+func (q *Queries) GetArticlesLastModified(ctx context.Context) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, getArticlesLastModified)
+	var last_modified time.Time
+	err := row.Scan(&last_modified)
+	return last_modified, err
+}
+
 const listArticles = `-- name: ListArticles :many
 SELECT
   id,
   title,
   slug,
-  published_at
+  tags,
+  published_at,
+  updated_at,
+  version
 FROM content.articles
 WHERE is_published = true
     AND is_deleted = false
@@ -66,7 +95,10 @@ type ListArticlesRow struct {
 	ID          int32
 	Title       string
 	Slug        string
+	Tags        []string
 	PublishedAt sql.NullTime
+	UpdatedAt   sql.NullTime
+	Version     int32
 }
 
 func (q *Queries) ListArticles(ctx context.Context) ([]ListArticlesRow, error) {
@@ -82,7 +114,10 @@ func (q *Queries) ListArticles(ctx context.Context) ([]ListArticlesRow, error) {
 			&i.ID,
 			&i.Title,
 			&i.Slug,
+			pq.Array(&i.Tags),
 			&i.PublishedAt,
+			&i.UpdatedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
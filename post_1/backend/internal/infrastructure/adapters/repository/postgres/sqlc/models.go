@@ -6,8 +6,15 @@ package sqlc
 
 import (
 	"database/sql"
+	"time"
 )
 
+type AnalyticsArticleView struct {
+	ArticleID int32
+	Day       time.Time
+	Views     int64
+}
+
 type AppUser struct {
 	ID           int32
 	CreatedAt    sql.NullTime
@@ -15,6 +22,7 @@ type AppUser struct {
 	Email        string
 	PasswordHash []byte
 	Activated    bool
+	Language     string
 }
 
 type AuthPermission struct {
@@ -38,4 +46,79 @@ type ContentArticle struct {
 	DeletedAt   sql.NullTime
 	IsPublished sql.NullBool
 	IsDeleted   sql.NullBool
+	Tags        []string
+	Version     int32
+}
+
+type ContentArticleSlug struct {
+	Slug      string
+	ArticleID int32
+	RetiredAt time.Time
+}
+
+type ContentArticleSource struct {
+	ArticleID   int32
+	Path        string
+	ContentHash string
+	CommitSha   string
+	SyncedAt    time.Time
+}
+
+type ContentPreviewLink struct {
+	ID        int32
+	ArticleID int32
+	CreatedBy sql.NullInt32
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	RevokedAt sql.NullTime
+}
+
+type ContentSeries struct {
+	ID          int32
+	Title       string
+	Slug        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type ContentSeriesArticle struct {
+	SeriesID  int32
+	ArticleID int32
+	Position  int32
+}
+
+type NewsletterArticleDelivery struct {
+	ArticleID    int32
+	SubscriberID int32
+	SentAt       time.Time
+}
+
+type NewsletterArticleNotification struct {
+	ArticleID   int32
+	NotifiedAt  sql.NullTime
+	CompletedAt sql.NullTime
+}
+
+type NewsletterSubscriber struct {
+	ID                    int32
+	Email                 string
+	Language              string
+	Confirmed             bool
+	ConfirmationTokenHash []byte
+	ConfirmationExpiresAt sql.NullTime
+	CreatedAt             sql.NullTime
+	ConfirmedAt           sql.NullTime
+}
+
+type ResumeVersion struct {
+	ID          int32
+	ObjectName  string
+	FileName    string
+	ContentType string
+	Size        int64
+	Checksum    string
+	IsCurrent   bool
+	UploadedBy  sql.NullInt32
+	CreatedAt   sql.NullTime
 }
@@ -56,3 +56,60 @@ func (q *Queries) GetPermissions(ctx context.Context, id int32) ([]string, error
 	}
 	return items, nil
 }
+
+const grantPermission = `-- name: GrantPermission :execrows
+INSERT INTO auth.users_permissions (user_id, permission_id)
+SELECT $1, p.id
+FROM auth.permissions AS p
+WHERE p.code = $2
+ON CONFLICT DO NOTHING
+`
+
+type GrantPermissionParams struct {
+	UserID int64
+	Code   string
+}
+
+func (q *Queries) GrantPermission(ctx context.Context, arg GrantPermissionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, grantPermission, arg.UserID, arg.Code)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const permissionExists = `-- name: PermissionExists :one
+SELECT EXISTS(
+    SELECT 1
+    FROM auth.permissions
+    WHERE code = $1
+)
+`
+
+func (q *Queries) PermissionExists(ctx context.Context, code string) (bool, error) {
+	row := q.db.QueryRowContext(ctx, permissionExists, code)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const revokePermission = `-- name: RevokePermission :execrows
+DELETE FROM auth.users_permissions AS up
+USING auth.permissions AS p
+WHERE up.permission_id = p.id
+    AND up.user_id = $1
+    AND p.code = $2
+`
+
+type RevokePermissionParams struct {
+	UserID int64
+	Code   string
+}
+
+func (q *Queries) RevokePermission(ctx context.Context, arg RevokePermissionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokePermission, arg.UserID, arg.Code)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
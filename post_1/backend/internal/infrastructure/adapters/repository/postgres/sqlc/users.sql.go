@@ -39,8 +39,9 @@ const createUser = `-- name: CreateUser :one
 INSERT INTO app.users (
     name,
     email,
-    password_hash
-) VALUES ($1, $2, $3)
+    password_hash,
+    language
+) VALUES ($1, $2, $3, $4)
 RETURNING id
 `
 
@@ -48,10 +49,16 @@ type CreateUserParams struct {
 	Name         string
 	Email        string
 	PasswordHash []byte
+	Language     string
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (int32, error) {
-	row := q.db.QueryRowContext(ctx, createUser, arg.Name, arg.Email, arg.PasswordHash)
+	row := q.db.QueryRowContext(ctx, createUser,
+		arg.Name,
+		arg.Email,
+		arg.PasswordHash,
+		arg.Language,
+	)
 	var id int32
 	err := row.Scan(&id)
 	return id, err
@@ -79,7 +86,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id int32) error {
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, created_at, name, email, password_hash, activated
+SELECT id, created_at, name, email, password_hash, activated, language
 FROM app.users
 WHERE email = $1
 `
@@ -94,6 +101,7 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (AppUser, er
 		&i.Email,
 		&i.PasswordHash,
 		&i.Activated,
+		&i.Language,
 	)
 	return i, err
 }
@@ -0,0 +1,159 @@
+package postgres_adapter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/infrastructure/adapters/repository/postgres/sqlc"
+)
+
+type subscriberAdapter struct {
+	queries *sqlc.Queries
+}
+
+func NewSubscriberAdapter(queries *sqlc.Queries) *subscriberAdapter {
+	return &subscriberAdapter{
+		queries: queries,
+	}
+}
+
+func (s *subscriberAdapter) UpsertSubscriber(ctx context.Context, subscriber domain.Subscriber) (int32, error) {
+	params := sqlc.UpsertSubscriberParams{
+		Email:    subscriber.Email,
+		Language: subscriber.Language,
+	}
+	if token := subscriber.ConfirmationToken; token != nil {
+		params.ConfirmationTokenHash = token.Hash
+		params.ConfirmationExpiresAt = sql.NullTime{Time: token.Expiry, Valid: true}
+	}
+
+	id, err := s.queries.UpsertSubscriber(ctx, params)
+	if err != nil {
+		// The upsert does not touch confirmed subscribers, so no row is returned
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrSubscriberAlreadyConfirmed
+		}
+		return 0, domain.NewInternalError(err)
+	}
+	return id, nil
+}
+
+func (s *subscriberAdapter) ConfirmSubscriber(ctx context.Context, tokenHash []byte) (domain.Subscriber, error) {
+	row, err := s.queries.ConfirmSubscriber(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Subscriber{}, domain.ErrInvalidNewsletterToken
+		}
+		return domain.Subscriber{}, domain.NewInternalError(err)
+	}
+
+	return domain.Subscriber{
+		ID:        row.ID,
+		Email:     row.Email,
+		Confirmed: true,
+	}, nil
+}
+
+func (s *subscriberAdapter) DeleteSubscriber(ctx context.Context, id int32) error {
+	rowsAffected, err := s.queries.DeleteSubscriber(ctx, id)
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrSubscriberNotFound
+	}
+	return nil
+}
+
+func (s *subscriberAdapter) ListSubscribers(ctx context.Context) ([]domain.Subscriber, error) {
+	rows, err := s.queries.ListSubscribers(ctx)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	subscribers := make([]domain.Subscriber, 0, len(rows))
+	for _, row := range rows {
+		subscriber := domain.Subscriber{
+			ID:        row.ID,
+			Email:     row.Email,
+			Language:  row.Language,
+			Confirmed: row.Confirmed,
+		}
+		if row.CreatedAt.Valid {
+			subscriber.CreatedAt = row.CreatedAt.Time
+		}
+		if row.ConfirmedAt.Valid {
+			subscriber.ConfirmedAt = row.ConfirmedAt.Time
+		}
+		subscribers = append(subscribers, subscriber)
+	}
+	return subscribers, nil
+}
+
+func (s *subscriberAdapter) ListConfirmedSubscribers(ctx context.Context, afterID int32, limit int32) ([]domain.Subscriber, error) {
+	rows, err := s.queries.ListConfirmedSubscribers(ctx, sqlc.ListConfirmedSubscribersParams{
+		ID:    afterID,
+		Limit: limit,
+	})
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	subscribers := make([]domain.Subscriber, 0, len(rows))
+	for _, row := range rows {
+		subscribers = append(subscribers, domain.Subscriber{
+			ID:        row.ID,
+			Email:     row.Email,
+			Language:  row.Language,
+			Confirmed: true,
+		})
+	}
+	return subscribers, nil
+}
+
+func (s *subscriberAdapter) ClaimArticleNotification(ctx context.Context, articleID int32) (bool, error) {
+	rowsAffected, err := s.queries.ClaimArticleNotification(ctx, articleID)
+	if err != nil {
+		return false, domain.NewInternalError(err)
+	}
+	return rowsAffected > 0, nil
+}
+
+func (s *subscriberAdapter) CompleteArticleNotification(ctx context.Context, articleID int32) error {
+	if err := s.queries.CompleteArticleNotification(ctx, articleID); err != nil {
+		return domain.NewInternalError(err)
+	}
+	return nil
+}
+
+func (s *subscriberAdapter) ListPendingArticleNotifications(ctx context.Context) ([]int32, error) {
+	articleIDs, err := s.queries.ListPendingArticleNotifications(ctx)
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	return articleIDs, nil
+}
+
+func (s *subscriberAdapter) ClaimArticleDelivery(ctx context.Context, articleID int32, subscriberID int32) (bool, error) {
+	rowsAffected, err := s.queries.ClaimArticleDelivery(ctx, sqlc.ClaimArticleDeliveryParams{
+		ArticleID:    articleID,
+		SubscriberID: subscriberID,
+	})
+	if err != nil {
+		return false, domain.NewInternalError(err)
+	}
+	return rowsAffected > 0, nil
+}
+
+func (s *subscriberAdapter) ReleaseArticleDelivery(ctx context.Context, articleID int32, subscriberID int32) error {
+	err := s.queries.ReleaseArticleDelivery(ctx, sqlc.ReleaseArticleDeliveryParams{
+		ArticleID:    articleID,
+		SubscriberID: subscriberID,
+	})
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+	return nil
+}
@@ -7,13 +7,15 @@ import (
 )
 
 type transaction struct {
-	tx        *sql.Tx
-	queries   *sqlc.Queries
-	userRepo  ports.UserRepository
-	tokenRepo ports.TokenRepository
+	tx             *sql.Tx
+	queries        *sqlc.Queries
+	userRepo       ports.UserRepository
+	tokenRepo      ports.TokenRepository
+	permissionRepo ports.PermissionRepository
 }
 
-func (t *transaction) UserRepo() ports.UserRepository   { return t.userRepo }
-func (t *transaction) TokenRepo() ports.TokenRepository { return t.tokenRepo }
-func (t *transaction) Commit() error                    { return t.tx.Commit() }
-func (t *transaction) Rollback() error                  { return t.tx.Rollback() }
+func (t *transaction) UserRepo() ports.UserRepository             { return t.userRepo }
+func (t *transaction) TokenRepo() ports.TokenRepository           { return t.tokenRepo }
+func (t *transaction) PermissionRepo() ports.PermissionRepository { return t.permissionRepo }
+func (t *transaction) Commit() error                              { return t.tx.Commit() }
+func (t *transaction) Rollback() error                            { return t.tx.Rollback() }
@@ -26,6 +26,7 @@ func (u *userAdapter) CreateUser(ctx context.Context, user domain.User) (int, er
 		Name:         user.Name,
 		Email:        user.Email,
 		PasswordHash: user.Password.Hash(),
+		Language:     user.Language,
 	})
 	if err != nil {
 		var pgErr *pq.Error
@@ -73,6 +74,7 @@ func (u *userAdapter) GetUserByEmail(ctx context.Context, email string) (domain.
 		Name:      row.Name,
 		Email:     row.Email,
 		Activated: row.Activated,
+		Language:  row.Language,
 	}
 
 	user.Password.SetHash(row.PasswordHash)
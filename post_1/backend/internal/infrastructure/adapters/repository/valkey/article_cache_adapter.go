@@ -0,0 +1,128 @@
+package valkey_adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"personal_website/internal/app/core/domain"
+
+	valkey "github.com/valkey-io/valkey-go"
+)
+
+const (
+	articlesKey      = "{articles}:records"
+	articleListField = "list"
+	articleField     = "slug:"
+)
+
+// storedArticle is the JSON encoding of a cached article.
+type storedArticle struct {
+	ID          int32     `json:"id"`
+	Title       string    `json:"title"`
+	Slug        string    `json:"slug"`
+	Content     string    `json:"content,omitempty"`
+	Tags        []string  `json:"tags"`
+	CreatedAt   time.Time `json:"created_at"`
+	PublishedAt time.Time `json:"published_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	IsPublished bool      `json:"is_published"`
+	Version     int32     `json:"version"`
+}
+
+func toStoredArticle(article domain.Article) storedArticle {
+	return storedArticle{
+		ID:          article.ID,
+		Title:       article.Title,
+		Slug:        article.Slug,
+		Content:     article.Content,
+		Tags:        article.Tags,
+		CreatedAt:   article.CreatedAt,
+		PublishedAt: article.PublishedAt,
+		UpdatedAt:   article.UpdatedAt,
+		IsPublished: article.IsPublished,
+		Version:     article.Version,
+	}
+}
+
+func (s storedArticle) toDomain() domain.Article {
+	return domain.Article{
+		ID:          s.ID,
+		Title:       s.Title,
+		Slug:        s.Slug,
+		Content:     s.Content,
+		Tags:        s.Tags,
+		CreatedAt:   s.CreatedAt,
+		PublishedAt: s.PublishedAt,
+		UpdatedAt:   s.UpdatedAt,
+		IsPublished: s.IsPublished,
+		Version:     s.Version,
+	}
+}
+
+type articleCacheAdapter struct {
+	client valkey.Client
+}
+
+func NewArticleCacheAdapter(client valkey.Client) *articleCacheAdapter {
+	return &articleCacheAdapter{
+		client: client,
+	}
+}
+
+func (c *articleCacheAdapter) GetArticle(ctx context.Context, slug string) (domain.Article, bool, int64, error) {
+	data, found, generation, err := getCached(ctx, c.client, articlesKey, articleField+slug)
+	if err != nil || !found {
+		return domain.Article{}, false, generation, err
+	}
+
+	var stored storedArticle
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return domain.Article{}, false, 0, domain.NewInternalError(fmt.Errorf("invalid cached article %q: %w", slug, err))
+	}
+	return stored.toDomain(), true, generation, nil
+}
+
+func (c *articleCacheAdapter) SetArticle(ctx context.Context, article domain.Article, generation int64, ttl time.Duration) error {
+	data, err := json.Marshal(toStoredArticle(article))
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+	return setCached(ctx, c.client, articlesKey, articleField+article.Slug, data, generation, ttl)
+}
+
+func (c *articleCacheAdapter) GetArticleList(ctx context.Context) ([]domain.Article, bool, int64, error) {
+	data, found, generation, err := getCached(ctx, c.client, articlesKey, articleListField)
+	if err != nil || !found {
+		return nil, false, generation, err
+	}
+
+	var stored []storedArticle
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, false, 0, domain.NewInternalError(fmt.Errorf("invalid cached article list: %w", err))
+	}
+
+	articles := make([]domain.Article, 0, len(stored))
+	for _, article := range stored {
+		articles = append(articles, article.toDomain())
+	}
+	return articles, true, generation, nil
+}
+
+func (c *articleCacheAdapter) SetArticleList(ctx context.Context, articles []domain.Article, generation int64, ttl time.Duration) error {
+	stored := make([]storedArticle, 0, len(articles))
+	for _, article := range articles {
+		stored = append(stored, toStoredArticle(article))
+	}
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+	return setCached(ctx, c.client, articlesKey, articleListField, data, generation, ttl)
+}
+
+func (c *articleCacheAdapter) InvalidateArticles(ctx context.Context) error {
+	return invalidateCached(ctx, c.client, articlesKey)
+}
@@ -0,0 +1,89 @@
+package valkey_adapter
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"personal_website/internal/app/core/domain"
+
+	valkey "github.com/valkey-io/valkey-go"
+)
+
+// The caches of the articles are hashes sharing the {articles} hash tag, so
+// that the script storing an entry reads the generation in the same slot. The
+// generation is incremented by every invalidation of any of them.
+const articlesGenerationKey = "{articles}:generation"
+
+// setCachedScript stores an entry unless the generation was incremented by an
+// invalidation since the entry was read from its source. The entries of a
+// hash expire together, ttl after the first one was stored.
+var setCachedScript = valkey.NewLuaScript(`
+if tonumber(redis.call('GET', KEYS[2]) or '0') ~= tonumber(ARGV[1]) then
+    return 0
+end
+redis.call('HSET', KEYS[1], ARGV[2], ARGV[3])
+redis.call('EXPIRE', KEYS[1], ARGV[4], 'NX')
+return 1
+`)
+
+// getCached returns the field of the hash, whether it was found, and the
+// current generation of the caches.
+func getCached(ctx context.Context, client valkey.Client, key string, field string) ([]byte, bool, int64, error) {
+	cmds := valkey.Commands{
+		client.B().Hget().Key(key).Field(field).Build(),
+		client.B().Get().Key(articlesGenerationKey).Build(),
+	}
+	results := client.DoMulti(ctx, cmds...)
+
+	var generation int64
+	if value, err := results[1].ToString(); err == nil {
+		generation, err = strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, false, 0, domain.NewInternalError(fmt.Errorf("invalid cache generation %q: %w", value, err))
+		}
+	} else if !valkey.IsValkeyNil(err) {
+		return nil, false, 0, domain.NewInternalError(err)
+	}
+
+	data, err := results[0].AsBytes()
+	if valkey.IsValkeyNil(err) {
+		return nil, false, generation, nil
+	}
+	if err != nil {
+		return nil, false, 0, domain.NewInternalError(err)
+	}
+	return data, true, generation, nil
+}
+
+func setCached(ctx context.Context, client valkey.Client, key string, field string, data []byte, generation int64, ttl time.Duration) error {
+	err := setCachedScript.Exec(ctx, client, []string{key, articlesGenerationKey}, []string{
+		strconv.FormatInt(generation, 10),
+		field,
+		string(data),
+		strconv.FormatInt(max(int64(ttl.Seconds()), 1), 10),
+	}).Error()
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+	return nil
+}
+
+// invalidateCached drops the hashes with a single increment of the
+// generation, the keys must share the {articles} hash tag.
+func invalidateCached(ctx context.Context, client valkey.Client, keys ...string) error {
+	// The generation is incremented first, so that an entry read before the
+	// change cannot be stored after the hashes are dropped
+	cmds := valkey.Commands{
+		client.B().Incr().Key(articlesGenerationKey).Build(),
+		client.B().Del().Key(keys...).Build(),
+	}
+
+	for _, result := range client.DoMulti(ctx, cmds...) {
+		if err := result.Error(); err != nil {
+			return domain.NewInternalError(err)
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package valkey_adapter
 
 import (
+	"context"
 	"personal_website/config"
 	"personal_website/internal/app/core/ports"
 
@@ -8,8 +9,13 @@ import (
 )
 
 type valkeyDatabase struct {
-	client      valkey.Client
+	client      *instrumentedClient
 	sessionRepo ports.SessionRepository
+	quotaRepo   ports.QuotaRepository
+	rateLimiter ports.RateLimiter
+	viewCounter ports.ViewCounterRepository
+	respCache   ports.ResponseCacheRepository
+	artCache    ports.ArticleCacheRepository
 }
 
 func NewDatabase(cfg *config.ValkeyConfig) (*valkeyDatabase, error) {
@@ -23,21 +29,50 @@ func NewDatabase(cfg *config.ValkeyConfig) (*valkeyDatabase, error) {
 		option.Password = cfg.Password.String()
 	}
 
-	client, err := valkey.NewClient(option)
+	rawClient, err := valkey.NewClient(option)
 	if err != nil {
 		return nil, err
 	}
+	client := newInstrumentedClient(rawClient)
 
 	sessionRepo := NewSessionAdapter(client)
+	quotaRepo := NewQuotaAdapter(client)
+	rateLimiter := NewRateLimiterAdapter(client)
+	viewCounter := NewViewCounterAdapter(client)
+	respCache := NewResponseCacheAdapter(client)
+	artCache := NewArticleCacheAdapter(client)
 
 	return &valkeyDatabase{
 		client:      client,
 		sessionRepo: sessionRepo,
+		quotaRepo:   quotaRepo,
+		rateLimiter: rateLimiter,
+		viewCounter: viewCounter,
+		respCache:   respCache,
+		artCache:    artCache,
 	}, nil
 }
 
 func (d *valkeyDatabase) SessionRepo() ports.SessionRepository { return d.sessionRepo }
 
+func (d *valkeyDatabase) QuotaRepo() ports.QuotaRepository { return d.quotaRepo }
+
+func (d *valkeyDatabase) RateLimiter() ports.RateLimiter { return d.rateLimiter }
+
+func (d *valkeyDatabase) ViewCounterRepo() ports.ViewCounterRepository { return d.viewCounter }
+
+func (d *valkeyDatabase) ResponseCacheRepo() ports.ResponseCacheRepository { return d.respCache }
+
+func (d *valkeyDatabase) ArticleCacheRepo() ports.ArticleCacheRepository { return d.artCache }
+
+func (d *valkeyDatabase) InvalidateArticleCaches(ctx context.Context) error {
+	return invalidateCached(ctx, d.client, articlesKey, responsesKey)
+}
+
+func (d *valkeyDatabase) Ping(ctx context.Context) error {
+	return d.client.Do(ctx, d.client.B().Ping().Build()).Error()
+}
+
 func (d *valkeyDatabase) Close() {
 	d.client.Close()
 }
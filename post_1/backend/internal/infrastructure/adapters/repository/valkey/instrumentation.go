@@ -0,0 +1,96 @@
+package valkey_adapter
+
+import (
+	"context"
+	"time"
+
+	"personal_website/pkg/telemetry"
+
+	valkey "github.com/valkey-io/valkey-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "personal_website/internal/infrastructure/adapters/repository/valkey"
+
+// commandMetrics measures the commands of the session, quota, rate limiter,
+// view counter, response cache and article cache adapters.
+var commandMetrics = telemetry.NewClientMetrics("valkey_command", "Valkey command")
+
+// instrumentedClient creates a client span and records the latency of the
+// commands sent with Do and DoMulti, which are the only ones used by the
+// adapters.
+type instrumentedClient struct {
+	valkey.Client
+	tracer trace.Tracer
+}
+
+func newInstrumentedClient(client valkey.Client) *instrumentedClient {
+	return &instrumentedClient{
+		Client: client,
+		tracer: otel.Tracer(tracerName),
+	}
+}
+
+func (c *instrumentedClient) Do(ctx context.Context, cmd valkey.Completed) valkey.ValkeyResult {
+	name := commandName(cmd)
+	ctx, span := c.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", "valkey"),
+			attribute.String("db.operation.name", name),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := c.Client.Do(ctx, cmd)
+	c.record(ctx, span, name, start, result.Error())
+	return result
+}
+
+func (c *instrumentedClient) DoMulti(ctx context.Context, multi ...valkey.Completed) []valkey.ValkeyResult {
+	ctx, span := c.tracer.Start(ctx, "PIPELINE",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", "valkey"),
+			attribute.String("db.operation.name", "PIPELINE"),
+			attribute.Int("db.operation.batch.size", len(multi)),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+	results := c.Client.DoMulti(ctx, multi...)
+
+	var err error
+	for _, result := range results {
+		if resultErr := result.Error(); resultErr != nil && !valkey.IsValkeyNil(resultErr) {
+			err = resultErr
+		}
+	}
+	c.record(ctx, span, "PIPELINE", start, err)
+	return results
+}
+
+func commandName(cmd valkey.Completed) string {
+	if commands := cmd.Commands(); len(commands) > 0 {
+		return commands[0]
+	}
+	return "COMMAND"
+}
+
+func (c *instrumentedClient) record(ctx context.Context, span trace.Span, command string, start time.Time, err error) {
+	// A missing key is an expected answer, not a failure
+	if valkey.IsValkeyNil(err) {
+		err = nil
+	}
+
+	commandMetrics.Record(ctx, command, start, err)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,50 @@
+package valkey_adapter
+
+import (
+	"context"
+	"time"
+
+	"personal_website/internal/app/core/domain"
+
+	valkey "github.com/valkey-io/valkey-go"
+)
+
+type quotaAdapter struct {
+	client valkey.Client
+}
+
+func NewQuotaAdapter(client valkey.Client) *quotaAdapter {
+	return &quotaAdapter{
+		client: client,
+	}
+}
+
+func (q *quotaAdapter) buildKey(key string) string {
+	return "quota:" + key
+}
+
+func (q *quotaAdapter) IncrementQuota(ctx context.Context, key string, window time.Duration) (int64, error) {
+	key = q.buildKey(key)
+	seconds := max(int64(window.Seconds()), 1)
+
+	// INCR and EXPIRE NX are pipelined so the window starts with the first
+	// increment and is not extended by the following ones.
+	cmds := valkey.Commands{
+		q.client.B().Incr().Key(key).Build(),
+		q.client.B().Expire().Key(key).Seconds(seconds).Nx().Build(),
+	}
+
+	results := q.client.DoMulti(ctx, cmds...)
+	for _, result := range results {
+		if err := result.Error(); err != nil {
+			return 0, domain.NewInternalError(err)
+		}
+	}
+
+	count, err := results[0].AsInt64()
+	if err != nil {
+		return 0, domain.NewInternalError(err)
+	}
+
+	return count, nil
+}
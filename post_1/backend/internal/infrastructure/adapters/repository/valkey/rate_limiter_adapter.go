@@ -0,0 +1,75 @@
+package valkey_adapter
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"personal_website/internal/app/core/domain"
+
+	valkey "github.com/valkey-io/valkey-go"
+)
+
+// gcraScript implements the generic cell rate algorithm: the key stores the
+// theoretical arrival time (TAT) of the next request in microseconds. A
+// request is allowed while the TAT stays within burst * interval of now.
+// Running as a script makes the read and the update atomic across instances,
+// and the Valkey clock is shared by all of them.
+var gcraScript = valkey.NewLuaScript(`
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+local interval = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+
+local tat = tonumber(redis.call('GET', KEYS[1]) or now)
+if tat < now then
+    tat = now
+end
+
+local new_tat = tat + interval
+local allow_at = new_tat - burst * interval
+if allow_at > now then
+    return {0, 0, tat - now, allow_at - now}
+end
+
+redis.call('SET', KEYS[1], new_tat, 'PX', math.max(math.ceil((new_tat - now) / 1000), 1))
+return {1, math.floor((now - allow_at) / interval), new_tat - now, 0}
+`)
+
+type rateLimiterAdapter struct {
+	client valkey.Client
+}
+
+func NewRateLimiterAdapter(client valkey.Client) *rateLimiterAdapter {
+	return &rateLimiterAdapter{
+		client: client,
+	}
+}
+
+func (r *rateLimiterAdapter) buildKey(key string) string {
+	return "ratelimit:" + key
+}
+
+func (r *rateLimiterAdapter) Allow(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitResult, error) {
+	interval := max(limit.Interval().Microseconds(), 1)
+
+	values, err := gcraScript.Exec(ctx, r.client, []string{r.buildKey(key)}, []string{
+		strconv.FormatInt(interval, 10),
+		strconv.Itoa(limit.Burst),
+	}).AsIntSlice()
+	if err != nil {
+		return domain.RateLimitResult{}, domain.NewInternalError(err)
+	}
+	if len(values) != 4 {
+		return domain.RateLimitResult{}, domain.NewInternalError(fmt.Errorf("unexpected rate limiter reply: %v", values))
+	}
+
+	return domain.RateLimitResult{
+		Allowed:    values[0] == 1,
+		Limit:      limit.Burst,
+		Remaining:  int(values[1]),
+		ResetAfter: time.Duration(values[2]) * time.Microsecond,
+		RetryAfter: time.Duration(values[3]) * time.Microsecond,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package valkey_adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"personal_website/internal/app/core/domain"
+
+	valkey "github.com/valkey-io/valkey-go"
+)
+
+const responsesKey = "{articles}:responses"
+
+// storedResponse is the JSON encoding of a cached response.
+type storedResponse struct {
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"last_modified"`
+	ArticleID    int32     `json:"article_id,omitempty"`
+	Location     string    `json:"location,omitempty"`
+}
+
+type responseCacheAdapter struct {
+	client valkey.Client
+}
+
+func NewResponseCacheAdapter(client valkey.Client) *responseCacheAdapter {
+	return &responseCacheAdapter{
+		client: client,
+	}
+}
+
+func (c *responseCacheAdapter) GetResponse(ctx context.Context, key string) (domain.CachedResponse, bool, error) {
+	data, found, generation, err := getCached(ctx, c.client, responsesKey, key)
+	if err != nil || !found {
+		return domain.CachedResponse{Generation: generation}, false, err
+	}
+
+	var stored storedResponse
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return domain.CachedResponse{}, false, domain.NewInternalError(fmt.Errorf("invalid cached response %q: %w", key, err))
+	}
+
+	return domain.CachedResponse{
+		Body:         stored.Body,
+		ETag:         stored.ETag,
+		LastModified: stored.LastModified,
+		ArticleID:    stored.ArticleID,
+		Location:     stored.Location,
+		Generation:   generation,
+	}, true, nil
+}
+
+func (c *responseCacheAdapter) SetResponse(ctx context.Context, key string, response domain.CachedResponse, ttl time.Duration) error {
+	data, err := json.Marshal(storedResponse{
+		Body:         response.Body,
+		ETag:         response.ETag,
+		LastModified: response.LastModified,
+		ArticleID:    response.ArticleID,
+		Location:     response.Location,
+	})
+	if err != nil {
+		return domain.NewInternalError(err)
+	}
+
+	return setCached(ctx, c.client, responsesKey, key, data, response.Generation, ttl)
+}
+
+func (c *responseCacheAdapter) InvalidateResponses(ctx context.Context) error {
+	return invalidateCached(ctx, c.client, responsesKey)
+}
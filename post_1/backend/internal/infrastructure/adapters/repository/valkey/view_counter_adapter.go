@@ -0,0 +1,160 @@
+package valkey_adapter
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"personal_website/internal/app/core/domain"
+
+	valkey "github.com/valkey-io/valkey-go"
+)
+
+const (
+	// The keys share the {analytics} hash tag, so that the scripts touching
+	// several of them stay in a single slot
+	pendingViewsKey = "{analytics}:pending"
+	// dayRetention keeps the salt and the visitors of a day until the day is
+	// over in every time zone, after which they cannot be linked to anyone.
+	dayRetention = 48 * time.Hour
+)
+
+// recordViewScript adds the visitor hash to the visitors of the article and
+// day, and increments the pending counter only when it was not there yet.
+var recordViewScript = valkey.NewLuaScript(`
+if redis.call('SADD', KEYS[1], ARGV[1]) == 0 then
+    return 0
+end
+redis.call('EXPIRE', KEYS[1], ARGV[2])
+redis.call('HINCRBY', KEYS[2], ARGV[3], 1)
+return 1
+`)
+
+// drainViewsScript reads and deletes the pending counters atomically, so that
+// instances flushing concurrently never persist the same views twice.
+var drainViewsScript = valkey.NewLuaScript(`
+local views = redis.call('HGETALL', KEYS[1])
+redis.call('DEL', KEYS[1])
+return views
+`)
+
+type viewCounterAdapter struct {
+	client valkey.Client
+}
+
+func NewViewCounterAdapter(client valkey.Client) *viewCounterAdapter {
+	return &viewCounterAdapter{
+		client: client,
+	}
+}
+
+func (v *viewCounterAdapter) VisitorSalt(ctx context.Context, day time.Time) ([]byte, error) {
+	key := "{analytics}:salt:" + day.Format(domain.DayLayout)
+
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	// The first instance to ask sets the salt of the day, the others get it
+	cmds := valkey.Commands{
+		v.client.B().Set().Key(key).Value(valkey.BinaryString(salt)).Nx().Ex(dayRetention).Build(),
+		v.client.B().Get().Key(key).Build(),
+	}
+
+	results := v.client.DoMulti(ctx, cmds...)
+	if err := results[0].Error(); err != nil && !valkey.IsValkeyNil(err) {
+		return nil, domain.NewInternalError(err)
+	}
+
+	stored, err := results[1].AsBytes()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+	return stored, nil
+}
+
+func (v *viewCounterAdapter) RecordView(ctx context.Context, view domain.ArticleView) (bool, error) {
+	day := view.Day.Format(domain.DayLayout)
+	visitorsKey := fmt.Sprintf("{analytics}:visitors:%s:%d", day, view.ArticleID)
+
+	counted, err := recordViewScript.Exec(ctx, v.client, []string{visitorsKey, pendingViewsKey}, []string{
+		view.VisitorHash,
+		strconv.FormatInt(int64(dayRetention.Seconds()), 10),
+		pendingField(view.Day, view.ArticleID),
+	}).AsInt64()
+	if err != nil {
+		return false, domain.NewInternalError(err)
+	}
+
+	return counted == 1, nil
+}
+
+func (v *viewCounterAdapter) DrainViews(ctx context.Context) ([]domain.ArticleViews, error) {
+	values, err := drainViewsScript.Exec(ctx, v.client, []string{pendingViewsKey}, nil).AsStrSlice()
+	if err != nil {
+		return nil, domain.NewInternalError(err)
+	}
+
+	views := make([]domain.ArticleViews, 0, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		view, err := parsePendingField(values[i])
+		if err != nil {
+			return nil, domain.NewInternalError(err)
+		}
+
+		view.Views, err = strconv.ParseInt(values[i+1], 10, 64)
+		if err != nil {
+			return nil, domain.NewInternalError(fmt.Errorf("invalid pending views %q: %w", values[i+1], err))
+		}
+		views = append(views, view)
+	}
+
+	return views, nil
+}
+
+func (v *viewCounterAdapter) RestoreViews(ctx context.Context, views []domain.ArticleViews) error {
+	if len(views) == 0 {
+		return nil
+	}
+
+	cmds := make(valkey.Commands, 0, len(views))
+	for _, view := range views {
+		cmds = append(cmds, v.client.B().Hincrby().Key(pendingViewsKey).Field(pendingField(view.Day, view.ArticleID)).Increment(view.Views).Build())
+	}
+
+	for _, result := range v.client.DoMulti(ctx, cmds...) {
+		if err := result.Error(); err != nil {
+			return domain.NewInternalError(err)
+		}
+	}
+	return nil
+}
+
+// pendingField identifies the counter of an article and day in the pending
+// hash, e.g. "2024-05-01:42".
+func pendingField(day time.Time, articleID int32) string {
+	return day.Format(domain.DayLayout) + ":" + strconv.Itoa(int(articleID))
+}
+
+func parsePendingField(field string) (domain.ArticleViews, error) {
+	dayValue, idValue, ok := strings.Cut(field, ":")
+	if !ok {
+		return domain.ArticleViews{}, fmt.Errorf("invalid pending views field %q", field)
+	}
+
+	day, err := time.Parse(domain.DayLayout, dayValue)
+	if err != nil {
+		return domain.ArticleViews{}, fmt.Errorf("invalid pending views field %q: %w", field, err)
+	}
+
+	articleID, err := strconv.ParseInt(idValue, 10, 32)
+	if err != nil {
+		return domain.ArticleViews{}, fmt.Errorf("invalid pending views field %q: %w", field, err)
+	}
+
+	return domain.ArticleViews{ArticleID: int32(articleID), Day: day}, nil
+}
@@ -0,0 +1,66 @@
+package resume
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"personal_website/pkg/telemetry"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "personal_website/internal/infrastructure/adapters/resume"
+
+// requestMetrics measures the requests sent to MinIO by HTTP method.
+var requestMetrics = telemetry.NewClientMetrics("minio_request", "MinIO request")
+
+// instrumentedTransport creates a client span and records the latency of
+// every request sent to MinIO, including the ranged reads made when a
+// download seeks in an object.
+type instrumentedTransport struct {
+	base   http.RoundTripper
+	tracer trace.Tracer
+}
+
+func newInstrumentedTransport(base http.RoundTripper) *instrumentedTransport {
+	return &instrumentedTransport{
+		base:   base,
+		tracer: otel.Tracer(tracerName),
+	}
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(req.Context(), "minio "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Host),
+			attribute.String("url.path", req.URL.Path),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		requestMetrics.Record(ctx, req.Method, start, err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// Client errors such as a missing object are answers, not failures
+	var statusErr error
+	if resp.StatusCode >= http.StatusInternalServerError {
+		statusErr = errors.New(resp.Status)
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	requestMetrics.Record(ctx, req.Method, start, statusErr)
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	return resp, nil
+}
@@ -2,30 +2,44 @@ package resume
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"io"
 	"personal_website/config"
 	"personal_website/internal/app/core/domain"
+	"personal_website/internal/app/core/ports"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 )
 
 type resumeService struct {
-	client *minio.Client
-	config *config.MinioConfig
+	client   *minio.Client
+	config   *config.MinioConfig
+	versions ports.ResumeRepository
 }
 
-func NewService(cfg *config.MinioConfig) (*resumeService, error) {
+func NewService(cfg *config.MinioConfig, versions ports.ResumeRepository) (*resumeService, error) {
 	endpoint := cfg.Endpoint.String()
 	accessKey := cfg.AccessKey.String()
 	secretKey := cfg.SecretKey.String()
 	useSSL := cfg.UseSSL
 
-	client, err := minio.New(endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
-		Secure: useSSL,
-	})
+	var client *minio.Client
+
+	transport, err := minio.DefaultTransport(useSSL)
+	if err == nil {
+		client, err = minio.New(endpoint, &minio.Options{
+			Creds:     credentials.NewStaticV4(accessKey, secretKey, ""),
+			Secure:    useSSL,
+			Transport: newInstrumentedTransport(transport),
+		})
+	}
 	if err != nil {
 		return nil, domain.DomainError{
 			Code:       "resume_storage_unavailable",
@@ -36,31 +50,95 @@ func NewService(cfg *config.MinioConfig) (*resumeService, error) {
 	}
 
 	return &resumeService{
-		client: client,
-		config: cfg,
+		client:   client,
+		config:   cfg,
+		versions: versions,
 	}, nil
 }
 
-func (s *resumeService) GetResume(ctx context.Context, objectName string) ([]byte, error) {
+// OpenCurrentResume returns the current version as a seekable MinIO object:
+// seeking makes the following reads ranged requests, so only the requested
+// bytes are fetched and nothing is buffered in memory.
+func (s *resumeService) OpenCurrentResume(ctx context.Context) (domain.ResumeFile, error) {
+	version, err := s.versions.GetCurrentResumeVersion(ctx)
+	if err != nil {
+		return domain.ResumeFile{}, err
+	}
+
 	bucket := s.config.Bucket.String()
 
-	object, err := s.client.GetObject(ctx, bucket, objectName, minio.GetObjectOptions{})
+	object, err := s.client.GetObject(ctx, bucket, version.ObjectName, minio.GetObjectOptions{})
 	if err != nil {
-		return nil, s.mapMinioError(err, "get object")
+		return domain.ResumeFile{}, s.mapMinioError(err, "get object")
 	}
-	defer object.Close()
 
-	data, err := io.ReadAll(object)
+	// GetObject is lazy, the stat is the first request reaching MinIO
+	info, err := object.Stat()
 	if err != nil {
-		return nil, domain.DomainError{
-			Code:       "resume_read_failed",
-			Message:    "failed to read resume file",
+		object.Close()
+		return domain.ResumeFile{}, s.mapMinioError(err, "stat object")
+	}
+
+	return domain.ResumeFile{
+		Version:      version,
+		Content:      object,
+		Size:         info.Size,
+		ETag:         strconv.Quote(info.ETag),
+		LastModified: info.LastModified,
+	}, nil
+}
+
+// UploadResume stores the file under a new object name, so that previous
+// versions stay downloadable, then records its metadata.
+func (s *resumeService) UploadResume(ctx context.Context, upload domain.ResumeUpload) (domain.ResumeVersion, error) {
+	bucket := s.config.Bucket.String()
+	objectName := fmt.Sprintf("resumes/%s-%s.pdf", time.Now().UTC().Format("20060102T150405Z"), strings.ToLower(rand.Text()[:8]))
+
+	hash := sha256.New()
+	info, err := s.client.PutObject(ctx, bucket, objectName, io.TeeReader(upload.Content, hash), upload.Size, minio.PutObjectOptions{
+		ContentType: upload.ContentType,
+	})
+	if err != nil {
+		return domain.ResumeVersion{}, domain.DomainError{
+			Code:       "resume_upload_failed",
+			Message:    "failed to store resume file",
 			Type:       domain.ErrorTypeInternal,
 			Underlying: err,
 		}
 	}
 
-	return data, nil
+	version, err := s.versions.CreateResumeVersion(ctx, domain.ResumeVersion{
+		ObjectName:  objectName,
+		FileName:    upload.FileName,
+		ContentType: upload.ContentType,
+		Size:        info.Size,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		UploadedBy:  upload.UploadedBy,
+	})
+	if err != nil {
+		// Do not leave an object without metadata behind
+		_ = s.client.RemoveObject(context.WithoutCancel(ctx), bucket, objectName, minio.RemoveObjectOptions{})
+		return domain.ResumeVersion{}, err
+	}
+
+	if upload.MakeCurrent {
+		return s.SetCurrentVersion(ctx, version.ID)
+	}
+
+	return version, nil
+}
+
+func (s *resumeService) ListVersions(ctx context.Context) ([]domain.ResumeVersion, error) {
+	return s.versions.ListResumeVersions(ctx)
+}
+
+func (s *resumeService) SetCurrentVersion(ctx context.Context, id int32) (domain.ResumeVersion, error) {
+	err := s.versions.SetCurrentResumeVersion(ctx, id)
+	if err != nil {
+		return domain.ResumeVersion{}, err
+	}
+
+	return s.versions.GetResumeVersionByID(ctx, id)
 }
 
 func (s *resumeService) CheckConnection(ctx context.Context) error {
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"personal_website/internal/infrastructure/dto_validation"
+	"personal_website/internal/infrastructure/http/dto"
+	"personal_website/internal/infrastructure/http/mappers"
+)
+
+// articlesImport upserts by slug the articles of a JSON array in the format of
+// POST /v1/articles, so that an import can be run again after fixing the
+// failed articles.
+func (c *cli) articlesImport(ctx context.Context, args []string) error {
+	fs := c.flags("articles import", "<file.json>")
+	publish := fs.Bool("publish", false, "Publish the imported articles, they are drafts otherwise")
+	if err := c.parse(fs, args, 1); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var requests []dto.ArticleRequest
+	if err := json.Unmarshal(data, &requests); err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+
+	// Validate everything first, so that an invalid file imports nothing
+	var invalid []error
+	for i, request := range requests {
+		validator := dto_validation.NewDtoValidator()
+		if !validator.ValidateStruct(request).Valid() {
+			invalid = append(invalid, fmt.Errorf("article %d (%s): %v", i+1, request.Slug, validator.Errors))
+		}
+	}
+	if len(invalid) > 0 {
+		return errors.Join(invalid...)
+	}
+
+	adminService, err := c.openAdminService()
+	if err != nil {
+		return err
+	}
+
+	var created, updated int
+	var failed []error
+	for _, request := range requests {
+		isNew, err := adminService.ImportArticle(ctx, mappers.ArticleRequestToDomain(request), *publish)
+		switch {
+		case err != nil:
+			failed = append(failed, fmt.Errorf("%s: %w", request.Slug, err))
+		case isNew:
+			created++
+		default:
+			updated++
+		}
+	}
+
+	fmt.Fprintf(c.stdout, "Created %d articles, updated %d, failed %d\n", created, updated, len(failed))
+	return errors.Join(failed...)
+}
@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"personal_website/config"
+	"personal_website/internal/app/core/ports"
+	"personal_website/internal/app/core/services/admin"
+	datastore_adapter "personal_website/internal/infrastructure/adapters/repository/datastore"
+	postgres_adapter "personal_website/internal/infrastructure/adapters/repository/postgres"
+	valkey_adapter "personal_website/internal/infrastructure/adapters/repository/valkey"
+)
+
+// errUsage is returned when the command line is invalid, the usage has then
+// already been printed.
+var errUsage = errors.New("invalid usage")
+
+const usage = `Usage: api <command> [flags] [arguments]
+
+Commands:
+  serve                               Start the API server (default)
+  migrate up|down|status              Apply, roll back or show the database migrations
+  user create -email -name            Create an activated user, the password is read from stdin
+  user grant <email> <permission>     Grant a permission to a user
+  user revoke <email> <permission>    Revoke a permission from a user
+  user deactivate <email>             Deactivate a user and revoke its sessions
+  sessions revoke <email>             Revoke the sessions of a user
+  articles import <file.json>         Import articles from a JSON array
+  sync [-dry-run]                     Sync the articles with the content git repository
+
+Every command reads the configuration like the server, see -config.
+Run "api <command> -h" for the flags of a command.
+`
+
+// subcommand runs with the flags after its name.
+type subcommand func(ctx context.Context, args []string) error
+
+type cli struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	configFile string
+	cfg        *config.Config
+	datastore  ports.Datastore
+	closers    []func()
+}
+
+// Run executes the administration command in args and returns the exit code
+// of the process. The server itself is started by the serve command, in main.
+func Run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	c := &cli{stdin: stdin, stdout: stdout, stderr: stderr}
+	defer c.close()
+
+	err := c.run(ctx, args)
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	default:
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+}
+
+func (c *cli) run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(c.stderr, usage)
+		return errUsage
+	}
+
+	switch args[0] {
+	case "migrate":
+		return c.dispatch(ctx, args, map[string]subcommand{
+			"up":     c.migrateUp,
+			"down":   c.migrateDown,
+			"status": c.migrateStatus,
+		})
+	case "user":
+		return c.dispatch(ctx, args, map[string]subcommand{
+			"create":     c.userCreate,
+			"grant":      c.userGrant,
+			"revoke":     c.userRevoke,
+			"deactivate": c.userDeactivate,
+		})
+	case "sessions":
+		return c.dispatch(ctx, args, map[string]subcommand{
+			"revoke": c.sessionsRevoke,
+		})
+	case "articles":
+		return c.dispatch(ctx, args, map[string]subcommand{
+			"import": c.articlesImport,
+		})
+	case "sync":
+		return c.sync(ctx, args[1:])
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(c.stdout, usage)
+		return nil
+	default:
+		fmt.Fprintf(c.stderr, "Unknown command %q\n\n%s", args[0], usage)
+		return errUsage
+	}
+}
+
+func (c *cli) dispatch(ctx context.Context, args []string, subcommands map[string]subcommand) error {
+	if len(args) < 2 {
+		fmt.Fprintf(c.stderr, "Missing %s command, expected one of %s\n", args[0], strings.Join(slices.Sorted(maps.Keys(subcommands)), ", "))
+		return errUsage
+	}
+
+	run, ok := subcommands[args[1]]
+	if !ok {
+		fmt.Fprintf(c.stderr, "Unknown %s command %q, expected one of %s\n", args[0], args[1], strings.Join(slices.Sorted(maps.Keys(subcommands)), ", "))
+		return errUsage
+	}
+	return run(ctx, args[2:])
+}
+
+// flags creates the flag set of a command, with the -config flag shared by
+// every command.
+func (c *cli) flags(name string, arguments string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.StringVar(&c.configFile, "config", "", "YAML or TOML configuration file (default $CONFIG_FILE)")
+	fs.Usage = func() {
+		fmt.Fprintf(c.stderr, "Usage: api %s [flags] %s\n", name, arguments)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parse parses the flags and checks the number of positional arguments.
+func (c *cli) parse(fs *flag.FlagSet, args []string, positional int) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if fs.NArg() != positional {
+		fmt.Fprintf(c.stderr, "Expected %d arguments, got %d\n", positional, fs.NArg())
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+// config loads the configuration from the sources of the server, without its
+// flags.
+func (c *cli) config() (*config.Config, error) {
+	if c.cfg != nil {
+		return c.cfg, nil
+	}
+
+	var args []string
+	if c.configFile != "" {
+		args = []string{"-config", c.configFile}
+	}
+	cfg, _, err := config.Load(args)
+	if err != nil {
+		return nil, err
+	}
+
+	c.cfg = &cfg
+	return c.cfg, nil
+}
+
+// openDatastore connects to Postgres and Valkey like the server.
+func (c *cli) openDatastore() (ports.Datastore, error) {
+	if c.datastore != nil {
+		return c.datastore, nil
+	}
+
+	cfg, err := c.config()
+	if err != nil {
+		return nil, err
+	}
+
+	pgDatabase, err := postgres_adapter.NewDatabase(&cfg.Postgres)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to postgres: %w", err)
+	}
+	c.closers = append(c.closers, pgDatabase.Close)
+
+	vkDatabase, err := valkey_adapter.NewDatabase(&cfg.Valkey)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to valkey: %w", err)
+	}
+	c.closers = append(c.closers, vkDatabase.Close)
+
+	c.datastore = datastore_adapter.NewDatastore(pgDatabase, vkDatabase, &cfg.App.Cache)
+	return c.datastore, nil
+}
+
+func (c *cli) openAdminService() (ports.AdminService, error) {
+	datastore, err := c.openDatastore()
+	if err != nil {
+		return nil, err
+	}
+	return admin.NewAdminService(datastore), nil
+}
+
+func (c *cli) close() {
+	for _, closer := range slices.Backward(c.closers) {
+		closer()
+	}
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	postgres_adapter "personal_website/internal/infrastructure/adapters/repository/postgres"
+)
+
+// migrator applies the migrations embedded in the binary, it is implemented
+// by the Postgres database.
+type migrator interface {
+	Migrate(ctx context.Context) (uint, error)
+	Rollback(ctx context.Context, steps int) (uint, error)
+	SchemaVersion(ctx context.Context) (uint, error)
+}
+
+func (c *cli) openMigrator() (migrator, error) {
+	cfg, err := c.config()
+	if err != nil {
+		return nil, err
+	}
+
+	pgDatabase, err := postgres_adapter.NewDatabase(&cfg.Postgres)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to postgres: %w", err)
+	}
+	c.closers = append(c.closers, pgDatabase.Close)
+	return pgDatabase, nil
+}
+
+func (c *cli) migrateUp(ctx context.Context, args []string) error {
+	fs := c.flags("migrate up", "")
+	if err := c.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	m, err := c.openMigrator()
+	if err != nil {
+		return err
+	}
+	version, err := m.Migrate(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stdout, "Version %d, up to date\n", version)
+	return nil
+}
+
+func (c *cli) migrateDown(ctx context.Context, args []string) error {
+	fs := c.flags("migrate down", "")
+	steps := fs.Int("steps", 1, "Number of migrations to roll back")
+	all := fs.Bool("all", false, "Roll back every migration, dropping all the data")
+	if err := c.parse(fs, args, 0); err != nil {
+		return err
+	}
+	if *steps < 1 {
+		fmt.Fprintln(c.stderr, "-steps must be positive")
+		return errUsage
+	}
+	if *all {
+		*steps = 0
+	}
+
+	m, err := c.openMigrator()
+	if err != nil {
+		return err
+	}
+	version, err := m.Rollback(ctx, *steps)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stdout, "Version %d, latest %d\n", version, postgres_adapter.LatestSchemaVersion())
+	return nil
+}
+
+func (c *cli) migrateStatus(ctx context.Context, args []string) error {
+	fs := c.flags("migrate status", "")
+	if err := c.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	m, err := c.openMigrator()
+	if err != nil {
+		return err
+	}
+	version, err := m.SchemaVersion(ctx)
+	// Pending migrations are a normal status, the other errors hold the
+	// version when they have one
+	behind := errors.Is(err, postgres_adapter.ErrSchemaBehind)
+	if err != nil && !behind {
+		return err
+	}
+
+	fmt.Fprintf(c.stdout, "Version %d, latest %d\n", version, postgres_adapter.LatestSchemaVersion())
+	if behind {
+		fmt.Fprintln(c.stdout, "Migrations are pending, run migrate up")
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/app/core/services/contentsync"
+	"personal_website/internal/infrastructure/adapters/gitsource"
+)
+
+// sync runs the content sync like POST /v1/sync, the repository and the
+// revision default to the sync settings of the configuration.
+func (c *cli) sync(ctx context.Context, args []string) error {
+	fs := c.flags("sync", "")
+	dryRun := fs.Bool("dry-run", false, "Only print the changes")
+	repoPath := fs.String("repo", "", "Git repository to sync (default sync.repo_path)")
+	ref := fs.String("ref", "", "Revision to sync (default sync.ref)")
+	if err := c.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	cfg, err := c.config()
+	if err != nil {
+		return err
+	}
+	syncConfig := cfg.Sync
+	if *repoPath != "" {
+		syncConfig.RepoPath = *repoPath
+	}
+	if *ref != "" {
+		syncConfig.Ref = *ref
+	}
+	if syncConfig.RepoPath == "" {
+		return errors.New("no repository to sync, set sync.repo_path or -repo")
+	}
+
+	datastore, err := c.openDatastore()
+	if err != nil {
+		return err
+	}
+
+	logger := slog.New(slog.NewTextHandler(c.stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	service := contentsync.NewSyncService(gitsource.NewGitSource(&syncConfig), datastore, logger)
+	report, err := service.Sync(ctx, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range report.Changes {
+		switch change.Action {
+		case domain.SyncUnchanged:
+		case domain.SyncFailed:
+			fmt.Fprintf(c.stdout, "%-9s %s: %s\n", change.Action, change.Path, change.Error)
+		default:
+			fmt.Fprintf(c.stdout, "%-9s %s (%s)\n", change.Action, change.Path, change.Slug)
+		}
+	}
+
+	verb := "Synced"
+	if report.DryRun {
+		verb = "Dry run of"
+	}
+	fmt.Fprintf(c.stdout, "%s commit %s: created %d, updated %d, deleted %d, unchanged %d, failed %d\n",
+		verb,
+		report.Commit,
+		report.Count(domain.SyncCreate),
+		report.Count(domain.SyncUpdate),
+		report.Count(domain.SyncDelete),
+		report.Count(domain.SyncUnchanged),
+		report.Count(domain.SyncFailed),
+	)
+	if failed := report.Count(domain.SyncFailed); failed > 0 {
+		return fmt.Errorf("%d files failed to sync", failed)
+	}
+	return nil
+}
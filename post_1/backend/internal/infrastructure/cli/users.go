@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/infrastructure/dto_validation"
+	"personal_website/internal/infrastructure/http/dto"
+	"personal_website/internal/infrastructure/http/mappers"
+)
+
+func (c *cli) userCreate(ctx context.Context, args []string) error {
+	fs := c.flags("user create", "< password")
+	email := fs.String("email", "", "Email of the user (required)")
+	name := fs.String("name", "", "Name of the user (required)")
+	language := fs.String("language", "", "Language of the emails sent to the user (default smtp.default_locale)")
+	permissions := fs.String("permissions", "", "Comma separated permissions to grant, e.g. articles:read,articles:write")
+	if err := c.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	// The password is never a flag, it would end up in the shell history
+	password, err := bufio.NewReader(c.stdin).ReadString('\n')
+	if err != nil && password == "" {
+		return errors.New("the password must be written to stdin")
+	}
+
+	request := dto.UserRequest{
+		Name:     strings.TrimSpace(*name),
+		Email:    strings.TrimSpace(*email),
+		Password: strings.TrimRight(password, "\r\n"),
+		Language: *language,
+	}
+	validator := dto_validation.NewDtoValidator()
+	if !validator.ValidateStruct(request).Valid() {
+		return fmt.Errorf("invalid user: %v", validator.Errors)
+	}
+
+	cfg, err := c.config()
+	if err != nil {
+		return err
+	}
+	user, err := mappers.UserRequestToDomain(request, "", cfg.SMTP.DefaultLocale)
+	if err != nil {
+		return err
+	}
+
+	var codes domain.Permissions
+	for _, code := range strings.Split(*permissions, ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			codes = append(codes, code)
+		}
+	}
+
+	adminService, err := c.openAdminService()
+	if err != nil {
+		return err
+	}
+	id, err := adminService.CreateUser(ctx, user, codes)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stdout, "Created user %d <%s>\n", id, user.Email)
+	return nil
+}
+
+func (c *cli) userGrant(ctx context.Context, args []string) error {
+	fs := c.flags("user grant", "<email> <permission>")
+	if err := c.parse(fs, args, 2); err != nil {
+		return err
+	}
+	email, code := fs.Arg(0), fs.Arg(1)
+
+	adminService, err := c.openAdminService()
+	if err != nil {
+		return err
+	}
+	granted, err := adminService.GrantPermission(ctx, email, code)
+	if err != nil {
+		return err
+	}
+
+	if granted {
+		fmt.Fprintf(c.stdout, "Granted %s to %s, effective on the next login\n", code, email)
+	} else {
+		fmt.Fprintf(c.stdout, "%s already has %s\n", email, code)
+	}
+	return nil
+}
+
+func (c *cli) userRevoke(ctx context.Context, args []string) error {
+	fs := c.flags("user revoke", "<email> <permission>")
+	if err := c.parse(fs, args, 2); err != nil {
+		return err
+	}
+	email, code := fs.Arg(0), fs.Arg(1)
+
+	adminService, err := c.openAdminService()
+	if err != nil {
+		return err
+	}
+	revoked, err := adminService.RevokePermission(ctx, email, code)
+	if err != nil {
+		return err
+	}
+
+	if revoked {
+		fmt.Fprintf(c.stdout, "Revoked %s from %s and its sessions\n", code, email)
+	} else {
+		fmt.Fprintf(c.stdout, "%s does not have %s\n", email, code)
+	}
+	return nil
+}
+
+func (c *cli) userDeactivate(ctx context.Context, args []string) error {
+	fs := c.flags("user deactivate", "<email>")
+	if err := c.parse(fs, args, 1); err != nil {
+		return err
+	}
+
+	adminService, err := c.openAdminService()
+	if err != nil {
+		return err
+	}
+	if err := adminService.DeactivateUser(ctx, fs.Arg(0)); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stdout, "Deactivated %s and revoked its sessions\n", fs.Arg(0))
+	return nil
+}
+
+func (c *cli) sessionsRevoke(ctx context.Context, args []string) error {
+	fs := c.flags("sessions revoke", "<email>")
+	if err := c.parse(fs, args, 1); err != nil {
+		return err
+	}
+
+	adminService, err := c.openAdminService()
+	if err != nil {
+		return err
+	}
+	if err := adminService.RevokeSessions(ctx, fs.Arg(0)); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stdout, "Revoked the sessions of %s\n", fs.Arg(0))
+	return nil
+}
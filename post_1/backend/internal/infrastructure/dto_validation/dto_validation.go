@@ -61,6 +61,12 @@ func (v *DtoValidator) getErrorMessage(fieldErr validator.FieldError) string {
 		return field + " cannot contain script tags"
 	case "alphanum_hyphen":
 		return field + " must contain only letters, numbers and hyphens"
+	case "unique":
+		return field + " must not contain duplicates"
+	case "ne":
+		return field + " cannot be " + fieldErr.Param()
+	case "bcp47_language_tag":
+		return field + " must be a valid language tag (e.g. en, fr-FR)"
 	default:
 		return "validation failed for " + field + " (" + tag + ")"
 	}
@@ -0,0 +1,21 @@
+package dto
+
+type DailyViewsResponse struct {
+	Day   string `json:"day"`
+	Views int64  `json:"views"`
+}
+
+type ArticleViewsResponse struct {
+	ArticleID int32                `json:"article_id"`
+	From      string               `json:"from"`
+	To        string               `json:"to"`
+	Total     int64                `json:"total"`
+	Days      []DailyViewsResponse `json:"days"`
+}
+
+type TopArticleResponse struct {
+	ArticleID int32  `json:"article_id"`
+	Title     string `json:"title"`
+	Slug      string `json:"slug"`
+	Views     int64  `json:"views"`
+}
@@ -4,25 +4,55 @@ type ArticleRequest struct {
 	Title   string `json:"title" validate:"required,min=5,max=200"`
 	Slug    string `json:"slug" validate:"required,min=3,max=100,alphanum_hyphen"`
 	Content string `json:"content" validate:"required,min=50,max=80000"`
+	// Tags are kept unchanged by an update when omitted
+	Tags []string `json:"tags,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
 }
 
 type ArticlePreview struct {
-	ID           int32   `json:"id"`
-	Title        string  `json:"title"`
-	Slug         string  `json:"slug"`
-	Created_at   *string `json:"created_at"`
-	Published_at *string `json:"published_at"`
-	Deleted_at   *string `json:"deleted_at"`
-	IsPublished  bool    `json:"is_published"`
+	ID           int32    `json:"id"`
+	Title        string   `json:"title"`
+	Slug         string   `json:"slug"`
+	Tags         []string `json:"tags"`
+	Created_at   *string  `json:"created_at"`
+	Published_at *string  `json:"published_at"`
+	Deleted_at   *string  `json:"deleted_at"`
+	IsPublished  bool     `json:"is_published"`
+	// Version is sent back in If-Match by the CMS to change the article
+	Version int32 `json:"version,omitempty"`
 }
 
 type ArticleResponse struct {
-	ID           int32   `json:"id"`
-	Title        string  `json:"title"`
-	Slug         string  `json:"slug"`
-	Content      string  `json:"content"`
-	Created_at   *string `json:"created_at"`
-	Published_at *string `json:"published_at"`
-	Deleted_at   *string `json:"deleted_at"`
-	IsPublished  bool    `json:"is_published"`
+	ID           int32    `json:"id"`
+	Title        string   `json:"title"`
+	Slug         string   `json:"slug"`
+	Content      string   `json:"content"`
+	Tags         []string `json:"tags"`
+	Created_at   *string  `json:"created_at"`
+	Published_at *string  `json:"published_at"`
+	Deleted_at   *string  `json:"deleted_at"`
+	IsPublished  bool     `json:"is_published"`
+	// Version is sent back in If-Match by the CMS to change the article
+	Version int32 `json:"version,omitempty"`
+	// Series is set for the published articles of a series
+	Series *ArticleSeriesResponse `json:"series,omitempty"`
+}
+
+// ArticleMovedResponse points a retired slug to the current slug of the
+// article, also sent in the Location header
+type ArticleMovedResponse struct {
+	Slug string `json:"slug"`
+}
+
+type ArticleImportResult struct {
+	File   string `json:"file"`
+	Slug   string `json:"slug,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type ArticleImportResponse struct {
+	Created int                   `json:"created"`
+	Updated int                   `json:"updated"`
+	Failed  int                   `json:"failed"`
+	Files   []ArticleImportResult `json:"files"`
 }
@@ -1,7 +1,19 @@
 package dto
 
+import "time"
+
 type ContactForm struct {
 	Name    string `json:"name" validate:"required,min=2,max=100,no_html"`
 	Email   string `json:"email" validate:"required,email,max=254"`
 	Message string `json:"message" validate:"required,min=10,max=5000,no_script_tags"`
+	// Website is a honeypot: the field is hidden from humans and must stay empty.
+	Website   string `json:"website"`
+	Challenge string `json:"challenge" validate:"required,max=256"`
+	Nonce     string `json:"nonce" validate:"required,max=64"`
+}
+
+type ContactChallengeResponse struct {
+	Challenge  string    `json:"challenge"`
+	Difficulty int       `json:"difficulty"`
+	ExpiresAt  time.Time `json:"expires_at"`
 }
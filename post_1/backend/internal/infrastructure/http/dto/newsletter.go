@@ -0,0 +1,19 @@
+package dto
+
+type NewsletterSubscribeRequest struct {
+	Email    string `json:"email" validate:"required,email,max=254"`
+	Language string `json:"language,omitempty" validate:"omitempty,max=35,bcp47_language_tag"`
+}
+
+type NewsletterTokenRequest struct {
+	Token string `json:"token" validate:"required,max=256"`
+}
+
+type SubscriberResponse struct {
+	ID          int32   `json:"id"`
+	Email       string  `json:"email"`
+	Language    string  `json:"language"`
+	Confirmed   bool    `json:"confirmed"`
+	CreatedAt   string  `json:"created_at"`
+	ConfirmedAt *string `json:"confirmed_at"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+type PreviewLinkResponse struct {
+	ID        int32   `json:"id"`
+	ArticleID int32   `json:"article_id"`
+	Token     string  `json:"token,omitempty"`
+	CreatedAt string  `json:"created_at"`
+	ExpiresAt string  `json:"expires_at"`
+	RevokedAt *string `json:"revoked_at,omitempty"`
+	Active    bool    `json:"active"`
+}
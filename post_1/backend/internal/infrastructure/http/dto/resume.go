@@ -0,0 +1,11 @@
+package dto
+
+type ResumeVersionResponse struct {
+	ID          int32  `json:"id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Checksum    string `json:"checksum"`
+	IsCurrent   bool   `json:"is_current"`
+	CreatedAt   string `json:"created_at"`
+}
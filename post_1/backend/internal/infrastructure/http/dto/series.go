@@ -0,0 +1,39 @@
+package dto
+
+type SeriesRequest struct {
+	Title string `json:"title" validate:"required,min=3,max=200"`
+	// The slug "all" is the path of the list of the CMS
+	Slug        string `json:"slug" validate:"required,min=3,max=100,alphanum_hyphen,ne=all"`
+	Description string `json:"description" validate:"max=2000"`
+	// ArticleIDs are the articles of the series in their order
+	ArticleIDs []int32 `json:"article_ids" validate:"max=100,unique"`
+}
+
+type SeriesArticlesRequest struct {
+	ArticleIDs []int32 `json:"article_ids" validate:"max=100,unique"`
+}
+
+type SeriesResponse struct {
+	ID          int32            `json:"id"`
+	Title       string           `json:"title"`
+	Slug        string           `json:"slug"`
+	Description string           `json:"description"`
+	CreatedAt   string           `json:"created_at"`
+	Articles    []ArticlePreview `json:"articles"`
+}
+
+// ArticleSeriesResponse places an article in its series, among the published
+// articles of the series only
+type ArticleSeriesResponse struct {
+	Title    string             `json:"title"`
+	Slug     string             `json:"slug"`
+	Position int                `json:"position"`
+	Total    int                `json:"total"`
+	Previous *SeriesArticleLink `json:"previous"`
+	Next     *SeriesArticleLink `json:"next"`
+}
+
+type SeriesArticleLink struct {
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
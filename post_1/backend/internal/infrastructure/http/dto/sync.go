@@ -0,0 +1,20 @@
+package dto
+
+type SyncChangeResponse struct {
+	Action    string `json:"action"`
+	Path      string `json:"path"`
+	Slug      string `json:"slug,omitempty"`
+	ArticleID int32  `json:"article_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type SyncResponse struct {
+	Commit    string               `json:"commit"`
+	DryRun    bool                 `json:"dry_run"`
+	Created   int                  `json:"created"`
+	Updated   int                  `json:"updated"`
+	Deleted   int                  `json:"deleted"`
+	Unchanged int                  `json:"unchanged"`
+	Failed    int                  `json:"failed"`
+	Changes   []SyncChangeResponse `json:"changes"`
+}
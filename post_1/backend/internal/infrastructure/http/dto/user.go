@@ -4,6 +4,7 @@ type UserRequest struct {
 	Name     string `json:"name" validate:"required,min=2,max=100"`
 	Email    string `json:"email" validate:"required,email,max=254"`
 	Password string `json:"password" validate:"required,min=8,max=72,strong_password"`
+	Language string `json:"language,omitempty" validate:"omitempty,max=35,bcp47_language_tag"`
 }
 
 type UserResponse struct {
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/infrastructure/http/mappers"
+	"personal_website/pkg/utils"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	defaultAnalyticsDays = 30
+	defaultTopArticles   = 10
+	maxTopArticles       = 100
+)
+
+// ArticleViews godoc
+// @Summary Get the views of an article
+// @Description Get the unique visitors of an article for every day of a date range. Visitors are
+// @Description counted once per day. The views of the last minutes may not be included yet.
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Article ID"
+// @Param from query string false "First day, YYYY-MM-DD (default: 29 days before to)"
+// @Param to query string false "Last day, YYYY-MM-DD (default: today)"
+// @Success 200 {object} utils.Envelope{data=dto.ArticleViewsResponse} "Daily views"
+// @Failure 400 {object} string "Invalid ID or date"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 404 {object} string "Article not found"
+// @Failure 422 {object} string "Date range reversed or longer than 366 days"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/analytics/articles/id/{id}/views [get]
+func (h *Handler) ArticleViews(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.extractIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	dateRange, ok := h.readDateRange(w, r)
+	if !ok {
+		return
+	}
+
+	views, err := h.analyticsService.ArticleViews(r.Context(), id, dateRange)
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	data := mappers.ArticleViewsToResponse(id, dateRange, views)
+
+	err = utils.WriteJSON(w, http.StatusOK, utils.Envelope{"data": data})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
+
+// TopArticles godoc
+// @Summary Get the most viewed articles
+// @Description Get the articles with the most unique visitors over a date range
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param from query string false "First day, YYYY-MM-DD (default: 29 days before to)"
+// @Param to query string false "Last day, YYYY-MM-DD (default: today)"
+// @Param limit query int false "Number of articles, 1 to 100 (default: 10)"
+// @Success 200 {object} utils.Envelope{data=[]dto.TopArticleResponse} "Most viewed articles"
+// @Failure 400 {object} string "Invalid date or limit"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 422 {object} string "Date range reversed or longer than 366 days"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/analytics/articles/top [get]
+func (h *Handler) TopArticles(w http.ResponseWriter, r *http.Request) {
+	dateRange, ok := h.readDateRange(w, r)
+	if !ok {
+		return
+	}
+
+	limit := defaultTopArticles
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxTopArticles {
+			h.errorResponder.BadRequestResponse(w, r, fmt.Errorf("limit must be an integer between 1 and %d", maxTopArticles))
+			return
+		}
+	}
+
+	articles, err := h.analyticsService.TopArticles(r.Context(), dateRange, int32(limit))
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	err = utils.WriteJSON(w, http.StatusOK, utils.Envelope{"data": mappers.TopArticlesToResponses(articles)})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
+
+// recordArticleView counts the view of a published article. Failures are
+// only logged: the reader must get the article anyway.
+func (h *Handler) recordArticleView(r *http.Request, articleID int32) {
+	ctx := r.Context()
+
+	counted, err := h.analyticsService.RecordArticleView(ctx, articleID, h.contextGetClientIP(r), r.UserAgent())
+	if err != nil {
+		h.logger.WarnContext(ctx, "Failed to record article view", "article_id", articleID, "error", err)
+		return
+	}
+
+	if h.telemetry != nil {
+		h.telemetry.ArticleViews.Add(ctx, 1, metric.WithAttributes(attribute.Bool("unique", counted)))
+	}
+}
+
+// readDateRange reads the from and to query parameters, which default to the
+// last 30 days.
+func (h *Handler) readDateRange(w http.ResponseWriter, r *http.Request) (domain.DateRange, bool) {
+	query := r.URL.Query()
+
+	to := domain.ViewDay(time.Now())
+	if value := query.Get("to"); value != "" {
+		day, err := time.Parse(domain.DayLayout, value)
+		if err != nil {
+			h.errorResponder.BadRequestResponse(w, r, fmt.Errorf("to must be a date formatted as YYYY-MM-DD"))
+			return domain.DateRange{}, false
+		}
+		to = day
+	}
+
+	from := to.AddDate(0, 0, 1-defaultAnalyticsDays)
+	if value := query.Get("from"); value != "" {
+		day, err := time.Parse(domain.DayLayout, value)
+		if err != nil {
+			h.errorResponder.BadRequestResponse(w, r, fmt.Errorf("from must be a date formatted as YYYY-MM-DD"))
+			return domain.DateRange{}, false
+		}
+		from = day
+	}
+
+	return domain.DateRange{From: from, To: to}, true
+}
@@ -1,8 +1,13 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"personal_website/internal/app/core/domain"
 	_ "personal_website/internal/infrastructure/http/docs"
 	"personal_website/internal/infrastructure/http/dto"
 	"personal_website/internal/infrastructure/http/mappers"
@@ -19,7 +24,7 @@ import (
 // @Param article body dto.ArticleRequest true "Article data"
 // @Success 201 "Article created successfully"
 // @Failure 400 {object} string "Invalid JSON body or validation error"
-// @Failure 409 {object} string "article already exists"
+// @Failure 409 {object} string "article already exists, or its slug was retired by another article"
 // @Failure 500 {object} string "Internal server error"
 // @Router /v1/articles [post]
 func (h *Handler) CreateArticle(w http.ResponseWriter, r *http.Request) {
@@ -49,17 +54,21 @@ func (h *Handler) CreateArticle(w http.ResponseWriter, r *http.Request) {
 
 // UpdateArticle godoc
 // @Summary Update an existing article
-// @Description Update an existing article by ID
+// @Description Update an existing article by ID. The If-Match header must hold the ETag of the version
+// @Description being edited, the new version is returned in the ETag header.
 // @Tags articles
 // @Accept json
 // @Produce json
 // @Security Bearer
 // @Param id path int true "Article ID"
+// @Param If-Match header string true "ETag of the edited version, or * for any version"
 // @Param article body dto.ArticleRequest true "Updated article data"
 // @Success 200 "Article updated successfully"
 // @Failure 400 {object} string "Invalid JSON body or validation error"
 // @Failure 404 {object} string "Article not found"
-// @Failure 409 {object} string "Article with slug already exists"
+// @Failure 409 {object} string "Article with slug already exists, or the slug was retired by another article"
+// @Failure 412 {object} string "Article modified since it was loaded"
+// @Failure 428 {object} string "If-Match header missing"
 // @Failure 500 {object} string "Internal server error"
 // @Router /v1/articles/id/{id} [put]
 func (h *Handler) UpdateArticle(w http.ResponseWriter, r *http.Request) {
@@ -68,6 +77,11 @@ func (h *Handler) UpdateArticle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	version, ok := h.extractIfMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
 	var dtoArticle dto.ArticleRequest
 
 	err := utils.ReadJSON(w, r, &dtoArticle)
@@ -82,24 +96,33 @@ func (h *Handler) UpdateArticle(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 	domainArticle := mappers.ArticleRequestToDomainWithID(dtoArticle, id)
+	domainArticle.Version = version
 
-	err = h.datastore.ArticleRepo().UpdateArticle(ctx, domainArticle)
+	version, err = h.datastore.ArticleRepo().UpdateArticle(ctx, domainArticle)
 	if err != nil {
 		h.HandleDomainError(w, r, err)
 		return
 	}
 
+	setArticleETag(w, version)
 	w.WriteHeader(http.StatusOK)
 }
 
 // GetArticleBySlug godoc
 // @Summary Get article by slug
-// @Description Retrieve a published article by its URL slug
+// @Description Retrieve a published article by its URL slug. The view is counted once per visitor and day. The
+// @Description response is cached, and revalidated with its ETag or Last-Modified. A slug the article was
+// @Description renamed from redirects to its current slug. The article of a series holds its place among the
+// @Description published articles of the series.
 // @Tags articles
 // @Accept json
 // @Produce json
 // @Param slug path string true "Article slug"
+// @Param If-None-Match header string false "ETag of the cached article"
+// @Param If-Modified-Since header string false "Last-Modified of the cached article"
 // @Success 200 {object} utils.Envelope{data=dto.ArticleResponse} "Article details"
+// @Success 301 {object} utils.Envelope{data=dto.ArticleMovedResponse} "The article moved to another slug"
+// @Success 304 "The cached article is current"
 // @Failure 404 {object} string "Article not found"
 // @Failure 500 {object} string "Internal server error"
 // @Router /v1/articles/slug/{slug} [get]
@@ -110,19 +133,61 @@ func (h *Handler) GetArticleBySlug(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := r.Context()
-	article, err := h.datastore.ArticleRepo().GetArticleBySlug(ctx, slug)
-	if err != nil {
-		h.HandleDomainError(w, r, err)
-		return
+	response, ok := h.serveCachedResponse(w, r, articleSlugCachePrefix+slug, func(ctx context.Context) (domain.CachedResponse, error) {
+		article, err := h.datastore.ArticleRepo().GetArticleBySlug(ctx, slug)
+		var moved domain.ArticleMovedError
+		if errors.As(err, &moved) {
+			return renderArticleMoved(moved.Slug)
+		}
+		if err != nil {
+			return domain.CachedResponse{}, err
+		}
+
+		data := mappers.ArticleToResponse(article)
+		etag := fmt.Sprintf(`"%d-%d"`, article.ID, article.Version)
+		lastModified := article.UpdatedAt
+
+		part, inSeries, err := h.articleSeriesPart(ctx, article)
+		if err != nil {
+			return domain.CachedResponse{}, err
+		}
+		if inSeries {
+			data.Series = mappers.SeriesPartToResponse(part)
+			etag, lastModified, err = h.seriesArticleValidators(ctx, article, part.Series)
+			if err != nil {
+				return domain.CachedResponse{}, err
+			}
+		}
+
+		body, err := json.Marshal(utils.Envelope{"data": data})
+		if err != nil {
+			return domain.CachedResponse{}, domain.NewInternalError(err)
+		}
+
+		return domain.CachedResponse{
+			Body:         body,
+			ETag:         etag,
+			LastModified: lastModified,
+			ArticleID:    article.ID,
+		}, nil
+	})
+	if ok && response.ArticleID != 0 {
+		h.recordArticleView(r, response.ArticleID)
 	}
+}
 
-	data := mappers.ArticleToResponse(article)
-
-	err = utils.WriteJSON(w, http.StatusOK, utils.Envelope{"data": data})
+// renderArticleMoved redirects a retired slug to the current one. Its views
+// are counted once the client follows the redirect.
+func renderArticleMoved(slug string) (domain.CachedResponse, error) {
+	body, err := json.Marshal(utils.Envelope{"data": dto.ArticleMovedResponse{Slug: slug}})
 	if err != nil {
-		h.errorResponder.ServerErrorResponse(w, r, err)
+		return domain.CachedResponse{}, domain.NewInternalError(err)
 	}
+
+	return domain.CachedResponse{
+		Body:     body,
+		Location: "/v1/articles/slug/" + url.PathEscape(slug),
+	}, nil
 }
 
 // GetArticleById godoc
@@ -153,6 +218,7 @@ func (h *Handler) GetArticleById(w http.ResponseWriter, r *http.Request) {
 
 	data := mappers.ArticleToPreview(article)
 
+	setArticleETag(w, article.Version)
 	err = utils.WriteJSON(w, http.StatusOK, utils.Envelope{"data": data})
 	if err != nil {
 		h.errorResponder.ServerErrorResponse(w, r, err)
@@ -161,7 +227,8 @@ func (h *Handler) GetArticleById(w http.ResponseWriter, r *http.Request) {
 
 // GetArticleForEdit godoc
 // @Summary Get article for editing
-// @Description Retrieve full article content by ID for editing purposes
+// @Description Retrieve full article content by ID for editing purposes. The ETag header holds the version
+// @Description of the article, to send in If-Match with the changes.
 // @Tags articles
 // @Accept json
 // @Produce json
@@ -187,6 +254,7 @@ func (h *Handler) GetArticleForEdit(w http.ResponseWriter, r *http.Request) {
 
 	data := mappers.ArticleToResponse(article)
 
+	setArticleETag(w, article.Version)
 	err = utils.WriteJSON(w, http.StatusOK, utils.Envelope{"data": data})
 	if err != nil {
 		h.errorResponder.ServerErrorResponse(w, r, err)
@@ -195,15 +263,19 @@ func (h *Handler) GetArticleForEdit(w http.ResponseWriter, r *http.Request) {
 
 // PublishArticle godoc
 // @Summary Publish an article
-// @Description Mark an article as published
+// @Description Mark an article as published. The first publication of an article is announced to the
+// @Description newsletter subscribers. The If-Match header must hold the ETag of the article.
 // @Tags articles
 // @Accept json
 // @Produce json
 // @Security Bearer
 // @Param id path int true "Article ID"
+// @Param If-Match header string true "ETag of the article, or * for any version"
 // @Success 200 "Article published successfully"
 // @Failure 400 {object} string "Invalid ID parameter"
 // @Failure 404 {object} string "Article not found"
+// @Failure 412 {object} string "Article modified since it was loaded"
+// @Failure 428 {object} string "If-Match header missing"
 // @Failure 500 {object} string "Internal server error"
 // @Router /v1/articles/id/{id}/publish [patch]
 func (h *Handler) PublishArticle(w http.ResponseWriter, r *http.Request) {
@@ -212,27 +284,38 @@ func (h *Handler) PublishArticle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	version, ok := h.extractIfMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
 	ctx := r.Context()
-	err := h.datastore.ArticleRepo().PublishArticle(ctx, id)
+	version, err := h.datastore.ArticleRepo().PublishArticle(ctx, id, version)
 	if err != nil {
 		h.HandleDomainError(w, r, err)
 		return
 	}
 
+	h.newsletterService.NotifyArticlePublished(ctx, id)
+
+	setArticleETag(w, version)
 	w.WriteHeader(http.StatusOK)
 }
 
 // UnpublishArticle godoc
 // @Summary Unpublish an article
-// @Description Mark an article as unpublished
+// @Description Mark an article as unpublished. The If-Match header must hold the ETag of the article.
 // @Tags articles
 // @Accept json
 // @Produce json
 // @Security Bearer
 // @Param id path int true "Article ID"
+// @Param If-Match header string true "ETag of the article, or * for any version"
 // @Success 200 "Article unpublished successfully"
 // @Failure 400 {object} string "Invalid ID parameter"
 // @Failure 404 {object} string "Article not found"
+// @Failure 412 {object} string "Article modified since it was loaded"
+// @Failure 428 {object} string "If-Match header missing"
 // @Failure 500 {object} string "Internal server error"
 // @Router /v1/articles/id/{id}/unpublish [patch]
 func (h *Handler) UnpublishArticle(w http.ResponseWriter, r *http.Request) {
@@ -241,12 +324,18 @@ func (h *Handler) UnpublishArticle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	version, ok := h.extractIfMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
 	ctx := r.Context()
-	err := h.datastore.ArticleRepo().UnpublishArticle(ctx, id)
+	version, err := h.datastore.ArticleRepo().UnpublishArticle(ctx, id, version)
 	if err != nil {
 		h.HandleDomainError(w, r, err)
 		return
 	}
 
+	setArticleETag(w, version)
 	w.WriteHeader(http.StatusOK)
 }
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"personal_website/internal/app/core/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Keys of the public article and series responses in the shared cache
+const (
+	articleListCacheKey    = "list"
+	articleSlugCachePrefix = "slug:"
+	seriesListCacheKey     = "series"
+	seriesSlugCachePrefix  = "series:"
+)
+
+// serveCachedResponse serves a public article response from the shared cache,
+// or renders and caches it on a miss. The client gets 304 Not Modified when
+// its copy is still current. It reports whether the response was served, the
+// errors of render are already answered otherwise.
+func (h *Handler) serveCachedResponse(w http.ResponseWriter, r *http.Request, key string, render func(ctx context.Context) (domain.CachedResponse, error)) (domain.CachedResponse, bool) {
+	ctx := r.Context()
+	cache := h.datastore.ResponseCacheRepo()
+
+	// The cache only saves queries, the articles are served without it
+	response, found, err := cache.GetResponse(ctx, key)
+	cacheable := err == nil
+	if err != nil {
+		h.logger.WarnContext(ctx, "Failed to read the response cache", "key", key, "error", err)
+	}
+
+	if !found {
+		generation := response.Generation
+		response, err = render(ctx)
+		if err != nil {
+			h.HandleDomainError(w, r, err)
+			return domain.CachedResponse{}, false
+		}
+
+		if cacheable {
+			response.Generation = generation
+			if err := cache.SetResponse(ctx, key, response, h.config.App().Cache.TTL); err != nil {
+				h.logger.WarnContext(ctx, "Failed to cache the response", "key", key, "error", err)
+			}
+		}
+	}
+
+	h.writeCachedResponse(w, r, response)
+	return response, true
+}
+
+func (h *Handler) writeCachedResponse(w http.ResponseWriter, r *http.Request, response domain.CachedResponse) {
+	header := w.Header()
+	if response.Location != "" {
+		// The redirect is cached like the responses, it ends when the slug is
+		// taken back by its article
+		header.Set("Location", response.Location)
+		header.Set("Cache-Control", h.cacheControl())
+		header.Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMovedPermanently)
+		if _, err := w.Write(response.Body); err != nil {
+			h.logger.WarnContext(r.Context(), "Failed to write the response", "error", err)
+		}
+		return
+	}
+
+	header.Set("ETag", response.ETag)
+	header.Set("Last-Modified", response.LastModified.UTC().Format(http.TimeFormat))
+	header.Set("Cache-Control", h.cacheControl())
+
+	if notModified(r, response) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	header.Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response.Body); err != nil {
+		h.logger.WarnContext(r.Context(), "Failed to write the response", "error", err)
+	}
+}
+
+// cacheControl lets the browsers and proxies reuse the public responses, and
+// serve them stale while they revalidate them with their ETag.
+func (h *Handler) cacheControl() string {
+	cache := h.config.App().Cache
+
+	value := "public, max-age=" + strconv.Itoa(int(cache.MaxAge.Seconds()))
+	if cache.StaleWhileRevalidate > 0 {
+		value += ", stale-while-revalidate=" + strconv.Itoa(int(cache.StaleWhileRevalidate.Seconds()))
+	}
+	return value
+}
+
+// notModified evaluates If-None-Match, or If-Modified-Since when it is absent.
+// If-None-Match uses the weak comparison, so that a proxy compressing the
+// response and weakening its ETag still gets 304.
+func notModified(r *http.Request, response domain.CachedResponse) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, etag := range strings.Split(ifNoneMatch, ",") {
+			etag = strings.TrimSpace(etag)
+			if etag == "*" || strings.TrimPrefix(etag, "W/") == response.ETag {
+				return true
+			}
+		}
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !response.LastModified.Truncate(time.Second).After(since)
+}
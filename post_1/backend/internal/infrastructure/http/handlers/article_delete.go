@@ -6,15 +6,18 @@ import (
 
 // SoftDeleteArticle godoc
 // @Summary Soft delete an article
-// @Description Move an article to trash (soft delete)
+// @Description Move an article to trash (soft delete). The If-Match header must hold the ETag of the article.
 // @Tags articles
 // @Accept json
 // @Produce json
 // @Security Bearer
 // @Param id path int true "Article ID"
+// @Param If-Match header string true "ETag of the article, or * for any version"
 // @Success 200 "Article moved to trash successfully"
 // @Failure 400 {object} string "Invalid ID parameter"
 // @Failure 404 {object} string "Article not found"
+// @Failure 412 {object} string "Article modified since it was loaded"
+// @Failure 428 {object} string "If-Match header missing"
 // @Failure 500 {object} string "Internal server error"
 // @Router /v1/articles/id/{id} [delete]
 func (h *Handler) SoftDeleteArticle(w http.ResponseWriter, r *http.Request) {
@@ -23,8 +26,13 @@ func (h *Handler) SoftDeleteArticle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	version, ok := h.extractIfMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
 	ctx := r.Context()
-	err := h.datastore.ArticleRepo().SoftDeleteArticle(ctx, id)
+	err := h.datastore.ArticleRepo().SoftDeleteArticle(ctx, id, version)
 	if err != nil {
 		h.HandleDomainError(w, r, err)
 		return
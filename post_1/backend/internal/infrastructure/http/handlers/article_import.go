@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"personal_website/internal/app/core/domain"
+	domain_validation "personal_website/internal/app/core/validation"
+	"personal_website/internal/infrastructure/http/dto"
+	"personal_website/internal/infrastructure/markdown"
+	"personal_website/pkg/utils"
+	"strings"
+	"time"
+)
+
+const maxImportSize = 20 << 20
+
+// articleTransferTimeout bounds the upload of an import and the download of an
+// export, which outlast the timeouts of the server on slow connections.
+const articleTransferTimeout = 2 * time.Minute
+
+// ImportArticles godoc
+// @Summary Import articles from Markdown
+// @Description Create or update, by slug, the articles of Markdown files with a YAML front matter (title, slug,
+// @Description tags, date, draft). The files can be sent as is or in a tar, tar.gz or zip archive. Each file is
+// @Description imported on its own, the errors are reported per file. Imported articles are not announced to
+// @Description the newsletter subscribers.
+// @Tags articles
+// @Accept multipart/form-data
+// @Produce json
+// @Security Bearer
+// @Param files formData file true "Markdown files or archives (20 MB max in total)"
+// @Success 200 {object} utils.Envelope{data=dto.ArticleImportResponse} "Result of the import of each file"
+// @Failure 400 {object} string "No file or request too large"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/articles/import [post]
+func (h *Handler) ImportArticles(w http.ResponseWriter, r *http.Request) {
+	// The response is written once the files are read and imported
+	h.extendDeadlines(w, r, articleTransferTimeout, 2*articleTransferTimeout)
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize+1<<20)
+
+	if err := r.ParseMultipartForm(maxImportSize); err != nil || r.MultipartForm == nil || len(r.MultipartForm.File["files"]) == 0 {
+		h.errorResponder.BadRequestResponse(w, r, fmt.Errorf("Markdown files or archives of at most %d MB are required in the \"files\" field", maxImportSize>>20))
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	response := dto.ArticleImportResponse{Files: []dto.ArticleImportResult{}}
+	record := func(result dto.ArticleImportResult) {
+		switch result.Status {
+		case "created":
+			response.Created++
+		case "updated":
+			response.Updated++
+		default:
+			response.Failed++
+		}
+		response.Files = append(response.Files, result)
+	}
+
+	ctx := r.Context()
+	slugs := make(map[string]string)
+	for _, header := range r.MultipartForm.File["files"] {
+		files, err := readImportFiles(header)
+		if err != nil {
+			record(dto.ArticleImportResult{File: header.Filename, Status: "failed", Error: err.Error()})
+			continue
+		}
+
+		for _, file := range files {
+			article, err := parseImportFile(file)
+			if err != nil {
+				record(dto.ArticleImportResult{File: file.Name, Status: "failed", Error: err.Error()})
+				continue
+			}
+
+			if previous, ok := slugs[article.Slug]; ok {
+				record(dto.ArticleImportResult{File: file.Name, Slug: article.Slug, Status: "failed", Error: fmt.Sprintf("the slug is already used by %s", previous)})
+				continue
+			}
+			slugs[article.Slug] = file.Name
+
+			_, created, err := h.datastore.ArticleRepo().UpsertArticleBySlug(ctx, article)
+			if err != nil {
+				var domainErr domain.DomainError
+				if errors.As(err, &domainErr) && domainErr.Type == domain.ErrorTypeInternal {
+					h.logger.ErrorContext(ctx, "Failed to import article", "file", file.Name, "slug", article.Slug, "error", err)
+				}
+				record(dto.ArticleImportResult{File: file.Name, Slug: article.Slug, Status: "failed", Error: err.Error()})
+				continue
+			}
+
+			status := "updated"
+			if created {
+				status = "created"
+			}
+			record(dto.ArticleImportResult{File: file.Name, Slug: article.Slug, Status: status})
+		}
+	}
+
+	h.logger.InfoContext(ctx, "Articles imported", "created", response.Created, "updated", response.Updated, "failed", response.Failed)
+
+	err := utils.WriteJSON(w, http.StatusOK, utils.Envelope{"data": response})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
+
+// readImportFiles returns the uploaded Markdown file, or the Markdown files
+// of the uploaded archive.
+func readImportFiles(header *multipart.FileHeader) ([]markdown.File, error) {
+	name := header.Filename
+	if !markdown.IsArchive(name) && !markdown.IsArticleFile(name) {
+		return nil, markdown.ErrUnsupportedFile
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if markdown.IsArchive(name) {
+		return markdown.ReadArchive(name, data, maxImportSize)
+	}
+	return []markdown.File{{Name: name, Data: data}}, nil
+}
+
+// parseImportFile reads the article of a Markdown file and validates it like
+// a POST /v1/articles request.
+func parseImportFile(file markdown.File) (domain.Article, error) {
+	article, err := markdown.Parse(file.Name, file.Data)
+	if err != nil {
+		return domain.Article{}, err
+	}
+
+	validator := domain_validation.NewArticleValidator()
+	if !validator.ValidateArticle(article).Valid() {
+		return domain.Article{}, errors.New(strings.Join(validator.Messages(), "; "))
+	}
+	return article, nil
+}
+
+// ExportArticles godoc
+// @Summary Export articles as Markdown
+// @Description Download every article not in the trash as a Markdown file with a YAML front matter, in the
+// @Description format accepted by the import.
+// @Tags articles
+// @Produce application/gzip
+// @Produce application/zip
+// @Security Bearer
+// @Param format query string false "Archive format, tar.gz (default) or zip"
+// @Success 200 {file} binary "Archive of the articles"
+// @Failure 400 {object} string "Invalid format parameter"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/articles/export [get]
+func (h *Handler) ExportArticles(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = markdown.FormatTarGz
+	}
+
+	var contentType string
+	switch format {
+	case markdown.FormatTarGz:
+		contentType = "application/gzip"
+	case markdown.FormatZip:
+		contentType = "application/zip"
+	default:
+		h.errorResponder.BadRequestResponse(w, r, fmt.Errorf("format must be %s or %s", markdown.FormatTarGz, markdown.FormatZip))
+		return
+	}
+
+	ctx := r.Context()
+	articles, err := h.datastore.ArticleRepo().ListAllArticlesWithContent(ctx)
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	files := make([]markdown.File, 0, len(articles))
+	for _, article := range articles {
+		data, err := markdown.Format(article)
+		if err != nil {
+			h.errorResponder.ServerErrorResponse(w, r, err)
+			return
+		}
+		files = append(files, markdown.File{Name: markdown.FileName(article), Data: data})
+	}
+
+	h.extendDeadlines(w, r, 0, articleTransferTimeout)
+	fileName := fmt.Sprintf("articles-%s.%s", time.Now().UTC().Format("20060102"), format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+
+	// The headers are sent with the first write, an error can only be logged
+	if err := markdown.WriteArchive(w, format, files); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to write articles export", "error", err)
+	}
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"personal_website/internal/infrastructure/http/mappers"
+	"personal_website/pkg/utils"
+	"strconv"
+	"time"
+)
+
+// CreatePreviewLink godoc
+// @Summary Create a preview link
+// @Description Mint a signed, expiring link that shares the article, published or not, with a reviewer
+// @Description without an account
+// @Tags articles
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Article ID"
+// @Success 201 {object} utils.Envelope{data=dto.PreviewLinkResponse} "Preview link created"
+// @Failure 400 {object} string "Invalid ID parameter"
+// @Failure 404 {object} string "Article not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/articles/id/{id}/previews [post]
+func (h *Handler) CreatePreviewLink(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.extractIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	var userID int
+	if session := h.contextGetAuthenticatedSession(r); session != nil {
+		userID = session.UserID
+	}
+
+	link, err := h.previewService.CreatePreviewLink(r.Context(), id, userID)
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "Preview link created", "article_id", id, "preview_id", link.ID, "expires_at", link.ExpiresAt)
+
+	err = utils.WriteJSON(w, http.StatusCreated, utils.Envelope{"data": mappers.PreviewLinkToResponse(link, time.Now())})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
+
+// ListPreviewLinks godoc
+// @Summary List the preview links of an article
+// @Description Get every preview link of the article, newest first. Only the active links hold their token.
+// @Tags articles
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Article ID"
+// @Success 200 {object} utils.Envelope{data=[]dto.PreviewLinkResponse} "List of preview links"
+// @Failure 400 {object} string "Invalid ID parameter"
+// @Failure 404 {object} string "Article not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/articles/id/{id}/previews [get]
+func (h *Handler) ListPreviewLinks(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.extractIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	links, err := h.previewService.ListPreviewLinks(r.Context(), id)
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	err = utils.WriteJSON(w, http.StatusOK, utils.Envelope{"data": mappers.PreviewLinksToResponses(links, time.Now())})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
+
+// RevokePreviewLink godoc
+// @Summary Revoke a preview link
+// @Description Revoke a preview link of the article, its token no longer gives access to the article
+// @Tags articles
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Article ID"
+// @Param previewID path int true "Preview link ID"
+// @Success 204 "Preview link revoked"
+// @Failure 400 {object} string "Invalid ID parameter"
+// @Failure 404 {object} string "Preview link not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/articles/id/{id}/previews/{previewID} [delete]
+func (h *Handler) RevokePreviewLink(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.extractIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	previewID, err := strconv.Atoi(r.PathValue("previewID"))
+	if err != nil {
+		h.errorResponder.BadRequestResponse(w, r, fmt.Errorf("invalid previewID parameter"))
+		return
+	}
+
+	err = h.previewService.RevokePreviewLink(r.Context(), id, int32(previewID))
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "Preview link revoked", "article_id", id, "preview_id", previewID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetArticlePreview godoc
+// @Summary Preview an article
+// @Description Retrieve the article of a preview link, published or not. The response is neither cached
+// @Description nor indexed.
+// @Tags articles
+// @Accept json
+// @Produce json
+// @Param token path string true "Preview token"
+// @Success 200 {object} utils.Envelope{data=dto.ArticleResponse} "Article content"
+// @Failure 404 {object} string "Preview link invalid, expired or revoked"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/articles/preview/{token} [get]
+func (h *Handler) GetArticlePreview(w http.ResponseWriter, r *http.Request) {
+	// The draft must not end up in a search engine or a shared cache, even
+	// when the request fails
+	w.Header().Set("X-Robots-Tag", "noindex")
+	w.Header().Set("Cache-Control", "no-store")
+
+	article, err := h.previewService.GetPreviewArticle(r.Context(), r.PathValue("token"))
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	err = utils.WriteJSON(w, http.StatusOK, utils.Envelope{"data": mappers.ArticleToResponse(article)})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
@@ -1,35 +1,65 @@
 package handlers
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"personal_website/internal/app/core/domain"
 	"personal_website/internal/infrastructure/http/mappers"
 	"personal_website/pkg/utils"
 )
 
 // ListArticles godoc
 // @Summary List published articles
-// @Description Get a list of all published articles with previews
+// @Description Get a list of all published articles with previews. The response is cached, and revalidated
+// @Description with its ETag or Last-Modified.
 // @Tags articles
 // @Accept json
 // @Produce json
+// @Param If-None-Match header string false "ETag of the cached list"
+// @Param If-Modified-Since header string false "Last-Modified of the cached list"
 // @Success 200 {object} utils.Envelope{data=[]dto.ArticlePreview} "List of published articles"
+// @Success 304 "The cached list is current"
 // @Failure 500 {object} string "Internal server error"
 // @Router /v1/articles [get]
 func (h *Handler) ListArticles(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	articles, err := h.datastore.ArticleRepo().ListArticles(ctx)
+	h.serveCachedResponse(w, r, articleListCacheKey, h.renderArticleList)
+}
+
+// renderArticleList renders the published articles. The ETag changes with the
+// version of any of them, the last modification is the one of any article as
+// unpublishing one removes it from the list.
+func (h *Handler) renderArticleList(ctx context.Context) (domain.CachedResponse, error) {
+	repo := h.datastore.ArticleRepo()
+
+	articles, err := repo.ListArticles(ctx)
 	if err != nil {
-		h.HandleDomainError(w, r, err)
-		return
+		return domain.CachedResponse{}, err
 	}
 
-	articleList := mappers.ArticlesToPreviews(articles)
+	lastModified, err := repo.GetArticlesLastModified(ctx)
+	if err != nil {
+		return domain.CachedResponse{}, err
+	}
 
-	data := utils.Envelope{"data": articleList}
-	err = utils.WriteJSON(w, http.StatusOK, data)
+	body, err := json.Marshal(utils.Envelope{"data": mappers.ArticlesToPreviews(articles)})
 	if err != nil {
-		h.errorResponder.ServerErrorResponse(w, r, err)
+		return domain.CachedResponse{}, domain.NewInternalError(err)
 	}
+
+	hash := sha256.New()
+	for _, article := range articles {
+		fmt.Fprintf(hash, "%d:%d,", article.ID, article.Version)
+	}
+
+	return domain.CachedResponse{
+		Body:         body,
+		ETag:         `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`,
+		LastModified: lastModified,
+	}, nil
 }
 
 // ListAllArticles godoc
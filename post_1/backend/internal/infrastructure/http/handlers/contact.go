@@ -2,26 +2,59 @@ package handlers
 
 import (
 	"net/http"
+	"personal_website/internal/app/core/domain"
 	"personal_website/internal/infrastructure/http/dto"
 	"personal_website/internal/infrastructure/http/mappers"
 	"personal_website/pkg/utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
+// ContactChallengeHandler godoc
+// @Summary Get a contact form challenge
+// @Description Issue a signed proof-of-work challenge. The client must find a nonce such that
+// @Description sha256(challenge + ":" + nonce) starts with `difficulty` zero bits and send both
+// @Description with the contact form.
+// @Tags contact
+// @Produce json
+// @Success 200 {object} utils.Envelope{data=dto.ContactChallengeResponse} "Challenge issued"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/contact/challenge [get]
+func (h *Handler) ContactChallengeHandler(w http.ResponseWriter, r *http.Request) {
+	challenge, err := h.contactGuard.IssueChallenge(r.Context())
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+
+	err = utils.WriteJSON(w, http.StatusOK, utils.Envelope{"data": mappers.ContactChallengeToResponse(challenge)})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
+
 // ContactHandler godoc
 // @Summary Submit contact form
-// @Description Submit a contact form message that will be sent via email
+// @Description Submit a contact form message that will be sent via email. A solved challenge from
+// @Description /v1/contact/challenge is required. Submissions detected as spam are discarded
+// @Description but still answered with a success response.
 // @Tags contact
 // @Accept json
 // @Produce json
 // @Param contact body dto.ContactForm true "Contact form data"
 // @Success 200 {object} utils.Envelope{message=string} "Message sent successfully"
 // @Failure 400 {object} string "Invalid request data"
+// @Failure 422 {object} string "Invalid or expired challenge"
+// @Failure 429 {object} string "Too many messages sent"
 // @Failure 500 {object} string "Email sending failed"
 // @Router /v1/contact [post]
 func (h *Handler) ContactHandler(w http.ResponseWriter, r *http.Request) {
 	var contactForm dto.ContactForm
 
-	h.logger.Info("Contact form submission received", "method", r.Method, "user_agent", r.UserAgent())
+	h.logger.InfoContext(r.Context(), "Contact form submission received", "method", r.Method, "user_agent", r.UserAgent())
 
 	err := utils.ReadJSON(w, r, &contactForm)
 	if err != nil {
@@ -29,27 +62,50 @@ func (h *Handler) ContactHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.logger.Info("Contact form data received", "name", contactForm.Name, "email", contactForm.Email, "message_length", len(contactForm.Message))
+	h.logger.InfoContext(r.Context(), "Contact form data received", "name", contactForm.Name, "email", contactForm.Email, "message_length", len(contactForm.Message))
 
 	if !h.validateDTO(w, r, contactForm, "contact form") {
 		return
 	}
 
-	h.logger.Info("Attempting to send email", "recipient_email", contactForm.Email)
-	contactMessage := mappers.ContactFormToDomain(contactForm)
-
 	ctx := r.Context()
-	err = h.emailService.SendContactEmail(ctx, contactMessage)
+	submission := mappers.ContactFormToSubmission(contactForm, h.contextGetClientIP(r))
+
+	reason, err := h.contactGuard.Inspect(ctx, submission)
 	if err != nil {
 		h.HandleDomainError(w, r, err)
 		return
 	}
 
-	h.logger.Info("Email sent successfully", "from", contactForm.Email, "name", contactForm.Name)
 	response := utils.Envelope{
 		"message": "Message sent successfully!",
 	}
 
+	// Spam gets the same answer as a legitimate message so that bots cannot
+	// tell which check caught them.
+	if reason != domain.SpamReasonNone {
+		h.logger.WarnContext(r.Context(), "Contact form submission discarded as spam", "reason", string(reason), "email", contactForm.Email)
+		if h.telemetry != nil {
+			h.telemetry.ContactSpamDiscarded.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", string(reason))))
+		}
+
+		err = utils.WriteJSON(w, http.StatusOK, response)
+		if err != nil {
+			h.errorResponder.ServerErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "Attempting to send email", "recipient_email", contactForm.Email)
+
+	err = h.emailService.SendContactEmail(ctx, submission.ContactMessage)
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "Email sent successfully", "from", contactForm.Email, "name", contactForm.Name)
+
 	err = utils.WriteJSON(w, http.StatusOK, response)
 	if err != nil {
 		h.errorResponder.ServerErrorResponse(w, r, err)
@@ -8,7 +8,10 @@ import (
 
 type contextKey string
 
-const authenticatedUserContextKey = contextKey("authenticatedUser")
+const (
+	authenticatedUserContextKey = contextKey("authenticatedUser")
+	clientIPContextKey          = contextKey("clientIP")
+)
 
 func (h *Handler) contextSetAuthenticatedSession(r *http.Request, user *domain.Session) *http.Request {
 	ctx := context.WithValue(r.Context(), authenticatedUserContextKey, user)
@@ -23,3 +26,18 @@ func (h *Handler) contextGetAuthenticatedSession(r *http.Request) *domain.Sessio
 
 	return user
 }
+
+func (h *Handler) contextSetClientIP(r *http.Request, ip string) *http.Request {
+	ctx := context.WithValue(r.Context(), clientIPContextKey, ip)
+	return r.WithContext(ctx)
+}
+
+// contextGetClientIP returns the IP stored by the resolveClientIP middleware.
+func (h *Handler) contextGetClientIP(r *http.Request) string {
+	ip, ok := r.Context().Value(clientIPContextKey).(string)
+	if !ok {
+		panic("missing client IP value in request context")
+	}
+
+	return ip
+}
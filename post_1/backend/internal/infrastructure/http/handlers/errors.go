@@ -4,9 +4,12 @@ import (
 	"errors"
 	"net/http"
 	"personal_website/internal/app/core/domain"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
-func (h *Handler) mapDomainErrorToHttp(err domain.DomainError) int {
+func (h *Handler) mapDomainErrorToHttp(r *http.Request, err domain.DomainError) int {
 	switch err.Type {
 	case domain.ErrorTypeValidation:
 		return http.StatusUnprocessableEntity
@@ -16,11 +19,17 @@ func (h *Handler) mapDomainErrorToHttp(err domain.DomainError) int {
 		return http.StatusConflict
 	case domain.ErrorTypeAuth:
 		return http.StatusUnauthorized
+	case domain.ErrorTypeRateLimit:
+		return http.StatusTooManyRequests
+	case domain.ErrorTypePrecondition:
+		return http.StatusPreconditionFailed
+	case domain.ErrorTypePreconditionRequired:
+		return http.StatusPreconditionRequired
 	case domain.ErrorTypeInternal:
-		h.logger.Error("Internal domain error", "code", err.Code, "msg", err.Message, "underlying", err.Underlying)
+		h.logger.ErrorContext(r.Context(), "Internal domain error", "code", err.Code, "msg", err.Message, "underlying", err.Underlying)
 		return http.StatusInternalServerError
 	default:
-		h.logger.Error("BUG: unmapped domain error type",
+		h.logger.ErrorContext(r.Context(), "BUG: unmapped domain error type",
 			"type", string(err.Type),
 			"code", err.Code,
 			"msg", err.Message,
@@ -31,7 +40,7 @@ func (h *Handler) mapDomainErrorToHttp(err domain.DomainError) int {
 }
 
 func (h *Handler) RespondError(w http.ResponseWriter, r *http.Request, err domain.DomainError) {
-	status := h.mapDomainErrorToHttp(err)
+	status := h.mapDomainErrorToHttp(r, err)
 
 	clientMsg := "internal server error"
 
@@ -44,9 +53,17 @@ func (h *Handler) RespondError(w http.ResponseWriter, r *http.Request, err domai
 
 func (h *Handler) HandleDomainError(w http.ResponseWriter, r *http.Request, err error) {
 	var domainErr domain.DomainError
-	if errors.As(err, &domainErr) {
-		h.RespondError(w, r, domainErr)
-	} else {
-		h.RespondError(w, r, domain.NewInternalError(err))
+	if !errors.As(err, &domainErr) {
+		domainErr = domain.NewInternalError(err)
+	}
+
+	if h.telemetry != nil {
+		h.telemetry.DomainErrors.Add(r.Context(), 1, metric.WithAttributes(
+			attribute.String("code", domainErr.Code),
+			attribute.String("type", string(domainErr.Type)),
+			attribute.String("route", routeLabel(r)),
+		))
 	}
+
+	h.RespondError(w, r, domainErr)
 }
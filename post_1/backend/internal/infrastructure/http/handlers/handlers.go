@@ -4,39 +4,66 @@ import (
 	"log/slog"
 	"personal_website/config"
 	"personal_website/internal/app/core/ports"
+	"personal_website/pkg/clientip"
+	"personal_website/pkg/health"
 	"personal_website/pkg/telemetry"
 	"personal_website/pkg/utils"
 )
 
 type Handler struct {
-	config         *config.AppConfig
-	logger         *slog.Logger
-	datastore      ports.Datastore
-	emailService   ports.EmailService
-	resumeService  ports.ResumeService
-	userService    ports.UserService
-	errorResponder *utils.ErrorResponder
-	telemetry      *telemetry.Telemetry
+	// config is read on every request, its reloadable settings may change
+	config            *config.Live
+	logger            *slog.Logger
+	datastore         ports.Datastore
+	emailService      ports.EmailService
+	resumeService     ports.ResumeService
+	userService       ports.UserService
+	contactGuard      ports.ContactGuard
+	newsletterService ports.NewsletterService
+	analyticsService  ports.AnalyticsService
+	syncService       ports.ContentSyncService
+	previewService    ports.PreviewService
+	rateLimiter       ports.RateLimiter
+	clientIPResolver  *clientip.Resolver
+	healthChecks      *health.Registry
+	errorResponder    *utils.ErrorResponder
+	telemetry         *telemetry.Telemetry
 }
 
 func NewHandler(
-	cfg *config.AppConfig,
+	cfg *config.Live,
 	logger *slog.Logger,
 	datastore ports.Datastore,
 	emailService ports.EmailService,
 	resumeService ports.ResumeService,
 	userService ports.UserService,
+	contactGuard ports.ContactGuard,
+	newsletterService ports.NewsletterService,
+	analyticsService ports.AnalyticsService,
+	syncService ports.ContentSyncService,
+	previewService ports.PreviewService,
+	rateLimiter ports.RateLimiter,
+	clientIPResolver *clientip.Resolver,
+	healthChecks *health.Registry,
 	errorResponder *utils.ErrorResponder,
 	telemetry *telemetry.Telemetry,
 ) *Handler {
 	return &Handler{
-		config:         cfg,
-		logger:         logger,
-		datastore:      datastore,
-		emailService:   emailService,
-		resumeService:  resumeService,
-		userService:    userService,
-		errorResponder: errorResponder,
-		telemetry:      telemetry,
+		config:            cfg,
+		logger:            logger,
+		datastore:         datastore,
+		emailService:      emailService,
+		resumeService:     resumeService,
+		userService:       userService,
+		contactGuard:      contactGuard,
+		newsletterService: newsletterService,
+		analyticsService:  analyticsService,
+		syncService:       syncService,
+		previewService:    previewService,
+		rateLimiter:       rateLimiter,
+		clientIPResolver:  clientIPResolver,
+		healthChecks:      healthChecks,
+		errorResponder:    errorResponder,
+		telemetry:         telemetry,
 	}
 }
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"personal_website/pkg/health"
 	"personal_website/pkg/utils"
 )
 
@@ -11,19 +12,70 @@ import (
 // @Tags system
 // @Accept json
 // @Produce json
-// @Success 200 {object} utils.Envelope{status=string,system_info=object} "API health status"
+// @Success 200 {object} utils.Envelope{status=string,system_info=object,features=[]string} "API health status"
 // @Failure 500 {object} string "Internal server error"
 // @Router /health [get]
 func (h *Handler) HealthcheckHandler(w http.ResponseWriter, r *http.Request) {
+	app := h.config.App()
+
+	// The frontend reads the feature flags here, they follow the reloads
+	features := app.Features
+	if features == nil {
+		features = []string{}
+	}
+
 	health_envelope := utils.Envelope{
 		"status": "available",
 		"system_info": map[string]string{
-			"environment": h.config.Environment,
-			"version":     h.config.Version,
+			"environment": app.Environment,
+			"version":     app.Version,
 		},
+		"features": features,
 	}
 	err := utils.WriteJSON(w, http.StatusOK, health_envelope)
 	if err != nil {
 		h.errorResponder.ServerErrorResponse(w, r, err)
 	}
 }
+
+// LivenessHandler godoc
+// @Summary Liveness probe
+// @Description Report that the process is running, without checking its dependencies
+// @Tags system
+// @Produce json
+// @Success 200 {object} utils.Envelope{status=string} "Process is alive"
+// @Router /health/live [get]
+func (h *Handler) LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	err := utils.WriteJSON(w, http.StatusOK, utils.Envelope{"status": health.StatusUp})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
+
+// ReadinessHandler godoc
+// @Summary Readiness probe
+// @Description Report whether the dependencies needed to serve traffic are available. A failing non critical dependency only degrades the status.
+// @Tags system
+// @Produce json
+// @Success 200 {object} utils.Envelope{status=string,checks=object} "Ready, possibly degraded"
+// @Failure 503 {object} utils.Envelope{status=string,checks=object} "A critical dependency is down"
+// @Router /health/ready [get]
+func (h *Handler) ReadinessHandler(w http.ResponseWriter, r *http.Request) {
+	report := h.healthChecks.Run(r.Context())
+
+	// The errors are only exposed on the metrics server
+	checks := make(map[string]health.Status, len(report.Checks))
+	for name, result := range report.Checks {
+		checks[name] = result.Status
+	}
+
+	status := http.StatusOK
+	if report.Status == health.StatusDown {
+		status = http.StatusServiceUnavailable
+	}
+
+	err := utils.WriteJSON(w, status, utils.Envelope{"status": report.Status, "checks": checks})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
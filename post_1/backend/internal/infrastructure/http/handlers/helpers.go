@@ -3,14 +3,19 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"personal_website/internal/app/core/domain"
 	"personal_website/internal/infrastructure/dto_validation"
 	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
 )
 
 func (h *Handler) validateDTO(w http.ResponseWriter, r *http.Request, dto any, context string) bool {
 	validator := dto_validation.NewDtoValidator()
 	if !validator.ValidateStruct(dto).Valid() {
-		h.logger.Warn("Validation failed", "context", context, "errors", validator.Errors)
+		h.logger.WarnContext(r.Context(), "Validation failed", "context", context, "errors", validator.Errors)
 		h.errorResponder.DtoValidationErrorResponse(w, r, validator.Errors)
 		return false
 	}
@@ -33,6 +38,69 @@ func (h *Handler) extractIDParam(w http.ResponseWriter, r *http.Request) (int32,
 	return int32(id), true
 }
 
+// setArticleETag sets the ETag of the article to its version, the CMS sends it
+// back in If-Match to change the article.
+func setArticleETag(w http.ResponseWriter, version int32) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(int(version))))
+}
+
+// extractIfMatchVersion returns the version of the article in the If-Match
+// header, or 0 for "*" which matches any version. The header is required so
+// that two editors cannot overwrite each other. A weak or invalid ETag never
+// matches.
+func (h *Handler) extractIfMatchVersion(w http.ResponseWriter, r *http.Request) (int32, bool) {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" {
+		h.HandleDomainError(w, r, domain.ErrArticleVersionRequired)
+		return 0, false
+	}
+	if ifMatch == "*" {
+		return 0, true
+	}
+
+	unquoted, err := strconv.Unquote(ifMatch)
+	if err != nil || !strings.HasPrefix(ifMatch, `"`) {
+		h.HandleDomainError(w, r, domain.ErrArticleVersionMismatch)
+		return 0, false
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 32)
+	if err != nil || version < 1 {
+		h.HandleDomainError(w, r, domain.ErrArticleVersionMismatch)
+		return 0, false
+	}
+
+	return int32(version), true
+}
+
+// extendDeadlines lifts the read and write timeouts of the server, which are
+// too short for the requests transferring large bodies. A zero duration keeps
+// the deadline of the server.
+func (h *Handler) extendDeadlines(w http.ResponseWriter, r *http.Request, read time.Duration, write time.Duration) {
+	controller := http.NewResponseController(w)
+	if read > 0 {
+		if err := controller.SetReadDeadline(time.Now().Add(read)); err != nil {
+			h.logger.WarnContext(r.Context(), "Failed to extend the read deadline", "error", err)
+		}
+	}
+	if write > 0 {
+		if err := controller.SetWriteDeadline(time.Now().Add(write)); err != nil {
+			h.logger.WarnContext(r.Context(), "Failed to extend the write deadline", "error", err)
+		}
+	}
+}
+
+// preferredLanguage returns the highest weighted language of the Accept-Language
+// header, or "" when the header is missing or cannot be parsed, so that the
+// default locale applies.
+func preferredLanguage(r *http.Request) string {
+	tags, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if err != nil || len(tags) == 0 || tags[0] == language.Und {
+		return ""
+	}
+
+	return tags[0].String()
+}
+
 func (h *Handler) NotFoundResponse(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusNotFound)
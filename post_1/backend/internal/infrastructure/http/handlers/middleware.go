@@ -14,26 +14,33 @@ import (
 	"personal_website/pkg/telemetry"
 	"personal_website/pkg/utils"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
-	"golang.org/x/time/rate"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const tracerName = "personal_website/internal/infrastructure/http"
+
 func (h *Handler) enableCORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Vary", "Origin")
 
 		origin := r.Header.Get("Origin")
 
-		if origin != "" && len(h.config.Cors.TrustedOrigins) > 0 {
-			for i := range h.config.Cors.TrustedOrigins {
-				if origin == h.config.Cors.TrustedOrigins[i] {
+		trustedOrigins := h.config.App().Cors.TrustedOrigins
+		if origin != "" && len(trustedOrigins) > 0 {
+			for i := range trustedOrigins {
+				if origin == trustedOrigins[i] {
 					w.Header().Set("Access-Control-Allow-Origin", origin)
 
 					if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
 						w.Header().Set("Access-Control-Allow-Methods", "OPTIONS, GET, POST, PUT, PATCH, DELETE")
-						w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-Requested-With")
+						w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, If-Match, X-Requested-With")
 						w.Header().Set("Access-Control-Allow-Credentials", "true")
 
 						w.WriteHeader(http.StatusOK)
@@ -42,6 +49,8 @@ func (h *Handler) enableCORS(next http.Handler) http.Handler {
 
 					// Set credentials header for all requests from trusted origins
 					w.Header().Set("Access-Control-Allow-Credentials", "true")
+					// The CMS reads the version of the articles it edits
+					w.Header().Set("Access-Control-Expose-Headers", "ETag")
 
 					break
 				}
@@ -66,24 +75,51 @@ func (h *Handler) recoverPanic(next http.Handler) http.Handler {
 }
 
 func (h *Handler) rateLimit(next http.Handler) http.Handler {
-	var ipRateLimiter *IPRateLimiter
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limiter := &h.config.App().Limiter
+		limit := domain.RateLimit{
+			Requests: limiter.Rps,
+			Period:   time.Second,
+			Burst:    limiter.Burst,
+		}
 
-	if h.config.Limiter.Enabled {
-		ipRateLimiter = NewIPRateLimiter(rate.Limit(h.config.Limiter.Rps), h.config.Limiter.Burst)
-	}
+		if !h.enforceRateLimit(w, r, globalRateLimitPolicy, "ip:"+h.contextGetClientIP(r), limit) {
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// traceRequest starts the server span of the request, continuing the trace
+// of the caller when it sent a W3C traceparent header.
+func (h *Handler) traceRequest(next http.Handler) http.Handler {
+	tracer := otel.Tracer(tracerName)
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if h.config.Limiter.Enabled && ipRateLimiter != nil {
-			clientIP := getClientIP(r)
-			limiter := ipRateLimiter.getLimiter(clientIP)
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer.Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+			),
+		)
+		defer span.End()
 
-			if !limiter.Allow() {
-				h.errorResponder.RateLimitExceededResponse(w, r)
-				return
-			}
+		rw := telemetry.NewResponseWriter(w)
+		next.ServeHTTP(rw, r.WithContext(ctx))
+
+		// The route pattern is only known once chi routed the request
+		if pattern := chi.RouteContext(r.Context()).RoutePattern(); pattern != "" {
+			span.SetName(r.Method + " " + pattern)
+			span.SetAttributes(attribute.String("http.route", pattern))
 		}
 
-		next.ServeHTTP(w, r)
+		span.SetAttributes(attribute.Int("http.response.status_code", rw.StatusCode))
+		if rw.StatusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(rw.StatusCode))
+		}
 	})
 }
 
@@ -93,6 +129,9 @@ func (h *Handler) correlationID(next http.Handler) http.Handler {
 
 		w.Header().Set("X-Correlation-ID", correlationID)
 
+		// Links the correlation ID returned to the client with its trace
+		trace.SpanFromContext(r.Context()).SetAttributes(attribute.String("correlation_id", correlationID))
+
 		ctx := context.WithValue(r.Context(), utils.CorrelationIDKey, correlationID)
 		r = r.WithContext(ctx)
 
@@ -100,16 +139,27 @@ func (h *Handler) correlationID(next http.Handler) http.Handler {
 	})
 }
 
+// resolveClientIP resolves the client IP once, through the trusted proxies,
+// for the request logs, the rate limits and the handlers.
+func (h *Handler) resolveClientIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = h.contextSetClientIP(r, h.clientIPResolver.ClientIP(r))
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func (h *Handler) requestLogger(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
 		correlationID := utils.GetCorrelationID(r.Context())
 
-		h.logger.Info("Request started",
+		h.logger.InfoContext(r.Context(), "Request started",
 			"method", r.Method,
 			"uri", r.URL.RequestURI(),
 			"remote_addr", r.RemoteAddr,
+			"client_ip", h.contextGetClientIP(r),
 			"user_agent", r.UserAgent(),
 			"correlation_id", correlationID,
 		)
@@ -117,7 +167,7 @@ func (h *Handler) requestLogger(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 
 		duration := time.Since(start)
-		h.logger.Info("Request completed",
+		h.logger.InfoContext(r.Context(), "Request completed",
 			"method", r.Method,
 			"uri", r.URL.RequestURI(),
 			"duration", duration.String(),
@@ -253,13 +303,24 @@ func (h *Handler) metricsMiddleware(t *telemetry.Telemetry) func(http.Handler) h
 
 			labels := []attribute.KeyValue{
 				attribute.String("method", r.Method),
-				attribute.String("route", r.URL.Path),
+				attribute.String("route", routeLabel(r)),
 				attribute.String("status_code", statusCode),
 			}
 
 			t.RequestsTotal.Add(ctx, 1, metric.WithAttributes(labels...))
 			t.RequestDuration.Record(ctx, duration, metric.WithAttributes(labels...))
+			t.RequestSize.Record(ctx, max(r.ContentLength, 0), metric.WithAttributes(labels...))
 			t.ResponseSize.Record(ctx, rw.Written, metric.WithAttributes(labels...))
 		})
 	}
 }
+
+// routeLabel is the chi route pattern, e.g. /v1/articles/slug/{slug}, so that
+// the number of series does not grow with the IDs and slugs requested.
+// Requests matching no route share a single label.
+func routeLabel(r *http.Request) string {
+	if pattern := chi.RouteContext(r.Context()).RoutePattern(); pattern != "" {
+		return pattern
+	}
+	return "unmatched"
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"net/http"
+	"personal_website/internal/infrastructure/http/dto"
+	"personal_website/internal/infrastructure/http/mappers"
+	"personal_website/pkg/utils"
+	"strings"
+)
+
+// SubscribeNewsletter godoc
+// @Summary Subscribe to the newsletter
+// @Description Register an email address to be notified of new articles. A confirmation email is sent
+// @Description and the subscription only becomes active once confirmed (double opt-in). The language
+// @Description defaults to the Accept-Language header.
+// @Tags newsletter
+// @Accept json
+// @Produce json
+// @Param subscription body dto.NewsletterSubscribeRequest true "Subscription data"
+// @Success 202 {object} utils.Envelope{message=string} "Confirmation email sent"
+// @Failure 400 {object} string "Invalid request data"
+// @Failure 429 {object} string "Too many subscription requests"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/newsletter/subscribe [post]
+func (h *Handler) SubscribeNewsletter(w http.ResponseWriter, r *http.Request) {
+	var request dto.NewsletterSubscribeRequest
+
+	err := utils.ReadJSON(w, r, &request)
+	if err != nil {
+		h.errorResponder.BadRequestResponse(w, r, err)
+		return
+	}
+
+	if !h.validateDTO(w, r, request, "newsletter subscription") {
+		return
+	}
+
+	language := request.Language
+	if language == "" {
+		language = preferredLanguage(r)
+	}
+	if language == "" {
+		language = h.config.Config().SMTP.DefaultLocale
+	}
+
+	ctx := r.Context()
+	err = h.newsletterService.Subscribe(ctx, request.Email, strings.ToLower(language))
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	response := utils.Envelope{
+		"message": "Please check your inbox to confirm your subscription.",
+	}
+
+	err = utils.WriteJSON(w, http.StatusAccepted, response)
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
+
+// ConfirmNewsletterSubscription godoc
+// @Summary Confirm a newsletter subscription
+// @Description Confirm a subscription with the token received by email
+// @Tags newsletter
+// @Accept json
+// @Produce json
+// @Param token body dto.NewsletterTokenRequest true "Confirmation token"
+// @Success 200 {object} utils.Envelope{message=string} "Subscription confirmed"
+// @Failure 400 {object} string "Invalid request data"
+// @Failure 422 {object} string "Invalid or expired token"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/newsletter/confirm [post]
+func (h *Handler) ConfirmNewsletterSubscription(w http.ResponseWriter, r *http.Request) {
+	var request dto.NewsletterTokenRequest
+
+	err := utils.ReadJSON(w, r, &request)
+	if err != nil {
+		h.errorResponder.BadRequestResponse(w, r, err)
+		return
+	}
+
+	if !h.validateDTO(w, r, request, "newsletter confirmation") {
+		return
+	}
+
+	ctx := r.Context()
+	_, err = h.newsletterService.Confirm(ctx, request.Token)
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	err = utils.WriteJSON(w, http.StatusOK, utils.Envelope{"message": "Subscription confirmed."})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
+
+// UnsubscribeNewsletter godoc
+// @Summary Unsubscribe from the newsletter
+// @Description Remove a subscriber with the signed token of the unsubscribe link
+// @Tags newsletter
+// @Accept json
+// @Produce json
+// @Param token body dto.NewsletterTokenRequest true "Unsubscribe token"
+// @Success 200 {object} utils.Envelope{message=string} "Unsubscribed"
+// @Failure 400 {object} string "Invalid request data"
+// @Failure 422 {object} string "Invalid token"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/newsletter/unsubscribe [post]
+func (h *Handler) UnsubscribeNewsletter(w http.ResponseWriter, r *http.Request) {
+	var request dto.NewsletterTokenRequest
+
+	err := utils.ReadJSON(w, r, &request)
+	if err != nil {
+		h.errorResponder.BadRequestResponse(w, r, err)
+		return
+	}
+
+	if !h.validateDTO(w, r, request, "newsletter unsubscribe") {
+		return
+	}
+
+	ctx := r.Context()
+	err = h.newsletterService.Unsubscribe(ctx, request.Token)
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	err = utils.WriteJSON(w, http.StatusOK, utils.Envelope{"message": "You have been unsubscribed."})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
+
+// ListSubscribers godoc
+// @Summary List newsletter subscribers
+// @Description Get every subscriber, confirmed or not (admin endpoint)
+// @Tags newsletter
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} utils.Envelope{data=[]dto.SubscriberResponse} "List of subscribers"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/newsletter/subscribers [get]
+func (h *Handler) ListSubscribers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	subscribers, err := h.newsletterService.ListSubscribers(ctx)
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	data := utils.Envelope{"data": mappers.SubscribersToResponses(subscribers)}
+	err = utils.WriteJSON(w, http.StatusOK, data)
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
@@ -1,95 +1,117 @@
 package handlers
 
 import (
-	"net"
 	"net/http"
-	"strings"
-	"sync"
+	"personal_website/config"
+	"personal_website/internal/app/core/domain"
+	"strconv"
 	"time"
 
-	"golang.org/x/time/rate"
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
-type ipLimiter struct {
-	limiter  *rate.Limiter
-	lastSeen time.Time
-}
-
-type IPRateLimiter struct {
-	limiters map[string]*ipLimiter
-	mu       sync.RWMutex
-	rps      rate.Limit
-	burst    int
-}
+// globalRateLimitPolicy names the limit applied to every request in the
+// metrics.
+const globalRateLimitPolicy = "global"
 
-func NewIPRateLimiter(rps rate.Limit, burst int) *IPRateLimiter {
-	irl := &IPRateLimiter{
-		limiters: make(map[string]*ipLimiter),
-		rps:      rps,
-		burst:    burst,
+// rateLimitPolicy applies the named policy of config.LimiterConfig on top of
+// the global limit. Each route pattern has its own buckets, so that a policy
+// shared by several routes does not make them compete, and requests are
+// counted per user or per client IP, see rateLimitIdentity. The
+// policy is read on every request, so that a configuration reload applies to
+// it. A policy that is not configured falls back to the global limit.
+func (h *Handler) rateLimitPolicy(name string) func(http.Handler) http.Handler {
+	if _, ok := h.rateLimitPolicyLimit(name); !ok {
+		h.logger.Error("Unknown rate limit policy, the global limit applies instead", "policy", name)
 	}
 
-	go func() {
-		ticker := time.NewTicker(time.Hour)
-		defer ticker.Stop()
-		for range ticker.C {
-			irl.cleanupOldEntries()
-		}
-	}()
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limit, _ := h.rateLimitPolicyLimit(name)
+
+			key := name + ":" + chi.RouteContext(r.Context()).RoutePattern() + ":" + h.rateLimitIdentity(r)
+			if !h.enforceRateLimit(w, r, name, key, limit) {
+				return
+			}
 
-	return irl
+			next.ServeHTTP(w, r)
+		})
+	}
 }
 
-func (irl *IPRateLimiter) getLimiter(ip string) *rate.Limiter {
-	irl.mu.Lock()
-	defer irl.mu.Unlock()
+// rateLimitPolicyLimit returns the limit of the named policy, configured or
+// default, and the global limit when there is no such policy.
+func (h *Handler) rateLimitPolicyLimit(name string) (domain.RateLimit, bool) {
+	limiter := &h.config.App().Limiter
 
-	limiter, exists := irl.limiters[ip]
-	if !exists {
-		limiter = &ipLimiter{
-			limiter:  rate.NewLimiter(irl.rps, irl.burst),
-			lastSeen: time.Now(),
-		}
-		irl.limiters[ip] = limiter
-	} else {
-		limiter.lastSeen = time.Now()
+	policy, ok := limiter.Policies[name]
+	if !ok {
+		policy, ok = config.DefaultRateLimitPolicies()[name]
+	}
+	if !ok {
+		return domain.RateLimit{Requests: limiter.Rps, Period: time.Second, Burst: limiter.Burst}, false
 	}
 
-	return limiter.limiter
+	return domain.RateLimit{
+		Requests: policy.Requests,
+		Period:   policy.Period,
+		Burst:    policy.Burst,
+	}, true
 }
 
-func (irl *IPRateLimiter) cleanupOldEntries() {
-	irl.mu.Lock()
-	defer irl.mu.Unlock()
-
-	cutoff := time.Now().Add(-time.Hour)
-	for ip, limiter := range irl.limiters {
-		if limiter.lastSeen.Before(cutoff) {
-			delete(irl.limiters, ip)
-		}
+// rateLimitIdentity identifies who the request is counted against: the user
+// of the session on the routes behind the authenticate middleware, so that
+// every login of a user shares the same bucket, and the client IP otherwise.
+func (h *Handler) rateLimitIdentity(r *http.Request) string {
+	session, ok := r.Context().Value(authenticatedUserContextKey).(*domain.Session)
+	if !ok || session.IsAnonymous() {
+		return "ip:" + h.contextGetClientIP(r)
 	}
+	return "user:" + strconv.Itoa(session.UserID)
 }
 
-func getClientIP(r *http.Request) string {
-	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
-		ips := strings.Split(xff, ",")
-		if len(ips) > 0 {
-			clientIP := strings.TrimSpace(ips[0])
-			if net.ParseIP(clientIP) != nil {
-				return clientIP
-			}
-		}
+// enforceRateLimit counts the request in the key bucket and answers 429 when
+// the limit is exceeded. It returns whether the request may proceed.
+func (h *Handler) enforceRateLimit(w http.ResponseWriter, r *http.Request, policy string, key string, limit domain.RateLimit) bool {
+	if !h.config.App().Limiter.Enabled || h.rateLimiter == nil {
+		return true
+	}
+
+	result, err := h.rateLimiter.Allow(r.Context(), key, limit)
+	if err != nil {
+		// Failing open: an unavailable limiter must not take the API down
+		h.logger.ErrorContext(r.Context(), "Rate limiter failed", "policy", policy, "error", err)
+		return true
 	}
 
-	if xri := r.Header.Get("X-Real-IP"); xri != "" {
-		if net.ParseIP(xri) != nil {
-			return xri
+	setRateLimitHeaders(w, result)
+
+	if !result.Allowed {
+		if h.telemetry != nil {
+			h.telemetry.RateLimitRejected.Add(r.Context(), 1, metric.WithAttributes(attribute.String("policy", policy)))
 		}
+		h.errorResponder.RateLimitExceededResponse(w, r)
+		return false
 	}
 
-	ip, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		return r.RemoteAddr
+	return true
+}
+
+// setRateLimitHeaders sets the RateLimit header fields of the IETF
+// draft, plus Retry-After on rejected requests. Durations are rounded up to
+// the second so that clients never retry too early.
+func setRateLimitHeaders(w http.ResponseWriter, result domain.RateLimitResult) {
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+	if !result.Allowed {
+		w.Header().Set("Retry-After", strconv.Itoa(max(ceilSeconds(result.RetryAfter), 1)))
 	}
-	return ip
-}
\ No newline at end of file
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
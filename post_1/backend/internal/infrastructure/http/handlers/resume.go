@@ -1,48 +1,223 @@
 package handlers
 
 import (
+	"bufio"
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"path"
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/infrastructure/http/mappers"
+	"personal_website/pkg/utils"
 	"strconv"
+	"strings"
 	"time"
 )
 
+const maxResumeSize = 10 << 20
+
+// resumeTimeout bounds the transfer of a resume, which outlasts the timeouts
+// of the server on slow connections.
+const resumeTimeout = 30 * time.Second
+
 // ResumeHandler godoc
 // @Summary Download resume
-// @Description Download the latest resume as a PDF file
+// @Description Download the current version of the resume as a PDF file. Supports HEAD, byte ranges
+// @Description and conditional requests with If-None-Match and If-Modified-Since.
 // @Tags resume
 // @Accept json
 // @Produce application/pdf
+// @Param Range header string false "Byte range, e.g. bytes=0-1023"
+// @Param If-None-Match header string false "ETag of a cached copy"
+// @Param If-Modified-Since header string false "Date of a cached copy"
 // @Success 200 {file} binary "PDF resume file"
+// @Success 206 {file} binary "Requested range of the PDF resume file"
+// @Success 304 "Cached copy is up to date"
 // @Failure 404 {object} string "Resume file not found"
+// @Failure 416 {object} string "Requested range not satisfiable"
 // @Failure 500 {object} string "Resume storage service unavailable or internal server error"
 // @Router /v1/resume [get]
 func (h *Handler) ResumeHandler(w http.ResponseWriter, r *http.Request) {
-	h.logger.Info("Resume download request received", "method", r.Method, "user_agent", r.UserAgent())
+	h.logger.InfoContext(r.Context(), "Resume download request received", "method", r.Method, "user_agent", r.UserAgent(), "range", r.Header.Get("Range"))
 
-	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	h.extendDeadlines(w, r, 0, resumeTimeout)
+	ctx, cancel := context.WithTimeout(r.Context(), resumeTimeout)
 	defer cancel()
 
-	// Get the resume PDF from Minio
-	resumeData, err := h.resumeService.GetResume(ctx, "resume_without_personal_data.pdf")
+	// Open the current resume in Minio
+	file, err := h.resumeService.OpenCurrentResume(ctx)
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+	defer file.Content.Close()
+
+	h.logger.InfoContext(r.Context(), "Resume opened successfully", "version", file.Version.ID, "size_bytes", file.Size)
+
+	// Set appropriate headers for PDF download, ServeContent handles the
+	// ranges, the conditional requests and HEAD
+	w.Header().Set("Content-Type", file.Version.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.Version.FileName))
+	w.Header().Set("ETag", file.ETag)
+
+	http.ServeContent(w, r.WithContext(ctx), file.Version.FileName, file.LastModified, file.Content)
+}
+
+// UploadResume godoc
+// @Summary Upload a resume
+// @Description Store a new PDF version of the resume. The new version becomes the downloaded one
+// @Description unless "current" is set to false.
+// @Tags resume
+// @Accept multipart/form-data
+// @Produce json
+// @Security Bearer
+// @Param file formData file true "PDF file (10 MB max)"
+// @Param filename formData string false "Download filename, defaults to the uploaded file name"
+// @Param current formData bool false "Make this version the current one (default true)"
+// @Success 201 {object} utils.Envelope{data=dto.ResumeVersionResponse} "Uploaded version"
+// @Failure 400 {object} string "Invalid request data"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 422 {object} string "The file is not a PDF"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/resume [post]
+func (h *Handler) UploadResume(w http.ResponseWriter, r *http.Request) {
+	// The response is written once the whole body is read
+	h.extendDeadlines(w, r, resumeTimeout, resumeTimeout+5*time.Second)
+	r.Body = http.MaxBytesReader(w, r.Body, maxResumeSize+1<<20)
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		h.errorResponder.BadRequestResponse(w, r, fmt.Errorf("a PDF file of at most %d MB is required in the \"file\" field", maxResumeSize>>20))
+		return
+	}
+	defer file.Close()
+
+	if header.Size > maxResumeSize {
+		h.errorResponder.BadRequestResponse(w, r, fmt.Errorf("the resume must not exceed %d MB", maxResumeSize>>20))
+		return
+	}
+
+	content := bufio.NewReader(file)
+	head, _ := content.Peek(512)
+	if http.DetectContentType(head) != "application/pdf" {
+		h.HandleDomainError(w, r, domain.ErrInvalidResumeFile)
+		return
+	}
+
+	fileName := r.FormValue("filename")
+	if fileName == "" {
+		fileName = header.Filename
+	}
+
+	makeCurrent := true
+	if value := r.FormValue("current"); value != "" {
+		makeCurrent, err = strconv.ParseBool(value)
+		if err != nil {
+			h.errorResponder.BadRequestResponse(w, r, errors.New("invalid current parameter"))
+			return
+		}
+	}
+
+	upload := domain.ResumeUpload{
+		FileName:    resumeFileName(fileName),
+		ContentType: "application/pdf",
+		Size:        header.Size,
+		Content:     content,
+		MakeCurrent: makeCurrent,
+	}
+	if session := h.contextGetAuthenticatedSession(r); session != nil {
+		upload.UploadedBy = session.UserID
+	}
+
+	version, err := h.resumeService.UploadResume(r.Context(), upload)
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "Resume uploaded", "version", version.ID, "size_bytes", version.Size, "current", version.IsCurrent)
+
+	err = utils.WriteJSON(w, http.StatusCreated, utils.Envelope{"data": mappers.ResumeVersionToResponse(version)})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
+
+// ListResumeVersions godoc
+// @Summary List resume versions
+// @Description Get every uploaded version of the resume, newest first
+// @Tags resume
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} utils.Envelope{data=[]dto.ResumeVersionResponse} "List of versions"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/resume/versions [get]
+func (h *Handler) ListResumeVersions(w http.ResponseWriter, r *http.Request) {
+	versions, err := h.resumeService.ListVersions(r.Context())
 	if err != nil {
 		h.HandleDomainError(w, r, err)
 		return
 	}
 
-	h.logger.Info("Resume fetched successfully", "size_bytes", len(resumeData))
+	err = utils.WriteJSON(w, http.StatusOK, utils.Envelope{"data": mappers.ResumeVersionsToResponses(versions)})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
 
-	// Set appropriate headers for PDF download
-	w.Header().Set("Content-Type", "application/pdf")
-	w.Header().Set("Content-Disposition", "attachment; filename=\"jordan_delbar_resume.pdf\"")
-	w.Header().Set("Content-Length", strconv.Itoa(len(resumeData)))
+// SetCurrentResumeVersion godoc
+// @Summary Set the current resume version
+// @Description Make a previously uploaded version the one served by the download endpoint
+// @Tags resume
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Version ID"
+// @Success 200 {object} utils.Envelope{data=dto.ResumeVersionResponse} "Current version"
+// @Failure 400 {object} string "Invalid ID"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 404 {object} string "Version not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/resume/versions/{id}/current [patch]
+func (h *Handler) SetCurrentResumeVersion(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.extractIDParam(w, r)
+	if !ok {
+		return
+	}
 
-	// Write the PDF data
-	_, err = w.Write(resumeData)
+	version, err := h.resumeService.SetCurrentVersion(r.Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to write PDF response", "error", err)
+		h.HandleDomainError(w, r, err)
 		return
 	}
 
-	h.logger.Info("Resume download completed successfully")
+	err = utils.WriteJSON(w, http.StatusOK, utils.Envelope{"data": mappers.ResumeVersionToResponse(version)})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
+
+// resumeFileName keeps the download filename safe to put in a
+// Content-Disposition header.
+func resumeFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.TrimSuffix(name, path.Ext(name))
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		case r == ' ':
+			return '_'
+		}
+		return -1
+	}, name)
+	name = strings.Trim(name, ".")
+
+	if name == "" {
+		name = "resume"
+	}
+	return name + ".pdf"
 }
@@ -13,7 +13,9 @@ func (h *Handler) Routes() http.Handler {
 	r := chi.NewRouter()
 
 	r.Use(h.recoverPanic)
+	r.Use(h.traceRequest)
 	r.Use(h.correlationID)
+	r.Use(h.resolveClientIP)
 	r.Use(h.requestLogger)
 	r.Use(h.enableCORS)
 	r.Use(h.rateLimit)
@@ -23,6 +25,8 @@ func (h *Handler) Routes() http.Handler {
 	r.MethodNotAllowed(h.MethodNotAllowedResponse)
 
 	r.Get("/health", h.HealthcheckHandler)
+	r.Get("/health/live", h.LivenessHandler)
+	r.Get("/health/ready", h.ReadinessHandler)
 	r.Get("/docs/*", httpSwagger.WrapHandler)
 
 	r.Route("/v1", func(r chi.Router) {
@@ -34,11 +38,17 @@ func (h *Handler) Routes() http.Handler {
 
 func (h *Handler) registerV1Routes(r chi.Router) {
 	// Public endpoints
-	r.Post("/contact", h.ContactHandler)
+	r.Get("/contact/challenge", h.ContactChallengeHandler)
+	r.With(h.rateLimitPolicy("contact")).Post("/contact", h.ContactHandler)
 	r.Get("/resume", h.ResumeHandler)
+	r.Head("/resume", h.ResumeHandler)
 
 	// Public article endpoints
 	h.registerPublicArticleRoutes(r)
+	h.registerPublicSeriesRoutes(r)
+
+	// Newsletter subscription
+	h.registerNewsletterRoutes(r)
 
 	// User registration and authentication
 	h.registerAuthRoutes(r)
@@ -47,36 +57,81 @@ func (h *Handler) registerV1Routes(r chi.Router) {
 	r.Group(func(r chi.Router) {
 		r.Use(h.authenticate)
 		h.registerProtectedArticleRoutes(r)
+		h.registerProtectedSeriesRoutes(r)
 		h.registerProtectedUserRoutes(r)
+		h.registerProtectedNewsletterRoutes(r)
+		h.registerProtectedResumeRoutes(r)
+		h.registerProtectedAnalyticsRoutes(r)
 	})
 }
 
 func (h *Handler) registerPublicArticleRoutes(r chi.Router) {
 	r.Get("/articles", h.ListArticles)
 	r.Get("/articles/slug/{slug}", h.GetArticleBySlug)
+	r.Get("/articles/preview/{token}", h.GetArticlePreview)
 }
 
 func (h *Handler) registerProtectedArticleRoutes(r chi.Router) {
-	r.With(h.requirePermissionMiddleware("articles:write")).Post("/articles", h.CreateArticle)
+	r.With(h.requirePermissionMiddleware("articles:write"), h.rateLimitPolicy("write")).Post("/articles", h.CreateArticle)
+	r.With(h.requirePermissionMiddleware("articles:write"), h.rateLimitPolicy("write")).Post("/articles/import", h.ImportArticles)
+	r.With(h.requirePermissionMiddleware("articles:write")).Get("/articles/export", h.ExportArticles)
 	r.With(h.requirePermissionMiddleware("articles:read")).Get("/articles/all", h.ListAllArticles)
 	r.With(h.requirePermissionMiddleware("articles:read")).Get("/articles/id/preview/{id}", h.GetArticleById)
 	r.With(h.requirePermissionMiddleware("articles:read")).Get("/articles/id/edit/{id}", h.GetArticleForEdit)
-	r.With(h.requirePermissionMiddleware("articles:write")).Put("/articles/id/{id}", h.UpdateArticle)
-	r.With(h.requirePermissionMiddleware("articles:write")).Patch("/articles/id/{id}/publish", h.PublishArticle)
-	r.With(h.requirePermissionMiddleware("articles:write")).Patch("/articles/id/{id}/unpublish", h.UnpublishArticle)
-	r.With(h.requirePermissionMiddleware("articles:write")).Delete("/articles/id/{id}", h.SoftDeleteArticle)
-	r.With(h.requirePermissionMiddleware("articles:write")).Delete("/articles/id/{id}/permanent", h.DeleteArticle)
-	r.With(h.requirePermissionMiddleware("articles:write")).Post("/articles/id/{id}/restore", h.RestoreArticle)
+	r.With(h.requirePermissionMiddleware("articles:write"), h.rateLimitPolicy("write")).Put("/articles/id/{id}", h.UpdateArticle)
+	r.With(h.requirePermissionMiddleware("articles:write"), h.rateLimitPolicy("write")).Patch("/articles/id/{id}/publish", h.PublishArticle)
+	r.With(h.requirePermissionMiddleware("articles:write"), h.rateLimitPolicy("write")).Patch("/articles/id/{id}/unpublish", h.UnpublishArticle)
+	r.With(h.requirePermissionMiddleware("articles:write"), h.rateLimitPolicy("write")).Delete("/articles/id/{id}", h.SoftDeleteArticle)
+	r.With(h.requirePermissionMiddleware("articles:write"), h.rateLimitPolicy("write")).Delete("/articles/id/{id}/permanent", h.DeleteArticle)
+	r.With(h.requirePermissionMiddleware("articles:write"), h.rateLimitPolicy("write")).Post("/articles/id/{id}/restore", h.RestoreArticle)
 	r.With(h.requirePermissionMiddleware("articles:read")).Get("/articles/trash", h.ListDeletedArticles)
+	r.With(h.requirePermissionMiddleware("articles:write"), h.rateLimitPolicy("write")).Post("/articles/id/{id}/previews", h.CreatePreviewLink)
+	r.With(h.requirePermissionMiddleware("articles:read")).Get("/articles/id/{id}/previews", h.ListPreviewLinks)
+	r.With(h.requirePermissionMiddleware("articles:write"), h.rateLimitPolicy("write")).Delete("/articles/id/{id}/previews/{previewID}", h.RevokePreviewLink)
+	r.With(h.requirePermissionMiddleware("articles:write"), h.rateLimitPolicy("write")).Post("/sync", h.SyncContent)
+}
+
+func (h *Handler) registerPublicSeriesRoutes(r chi.Router) {
+	r.Get("/series", h.ListSeries)
+	r.Get("/series/{slug}", h.GetSeriesBySlug)
+}
+
+func (h *Handler) registerProtectedSeriesRoutes(r chi.Router) {
+	r.With(h.requirePermissionMiddleware("articles:write"), h.rateLimitPolicy("write")).Post("/series", h.CreateSeries)
+	r.With(h.requirePermissionMiddleware("articles:read")).Get("/series/all", h.ListAllSeries)
+	r.With(h.requirePermissionMiddleware("articles:read")).Get("/series/id/{id}", h.GetSeriesByID)
+	r.With(h.requirePermissionMiddleware("articles:write"), h.rateLimitPolicy("write")).Put("/series/id/{id}/articles", h.SetSeriesArticles)
+	r.With(h.requirePermissionMiddleware("articles:write"), h.rateLimitPolicy("write")).Delete("/series/id/{id}", h.DeleteSeries)
+}
+
+func (h *Handler) registerNewsletterRoutes(r chi.Router) {
+	r.With(h.rateLimitPolicy("newsletter")).Post("/newsletter/subscribe", h.SubscribeNewsletter)
+	r.Post("/newsletter/confirm", h.ConfirmNewsletterSubscription)
+	r.Post("/newsletter/unsubscribe", h.UnsubscribeNewsletter)
+}
+
+func (h *Handler) registerProtectedNewsletterRoutes(r chi.Router) {
+	r.With(h.requirePermissionMiddleware("newsletter:read")).Get("/newsletter/subscribers", h.ListSubscribers)
+}
+
+func (h *Handler) registerProtectedResumeRoutes(r chi.Router) {
+	r.With(h.requirePermissionMiddleware("resume:write"), h.rateLimitPolicy("write")).Post("/resume", h.UploadResume)
+	r.With(h.requirePermissionMiddleware("resume:write")).Get("/resume/versions", h.ListResumeVersions)
+	r.With(h.requirePermissionMiddleware("resume:write"), h.rateLimitPolicy("write")).Patch("/resume/versions/{id}/current", h.SetCurrentResumeVersion)
+}
+
+func (h *Handler) registerProtectedAnalyticsRoutes(r chi.Router) {
+	r.With(h.requirePermissionMiddleware("analytics:read")).Get("/analytics/articles/top", h.TopArticles)
+	r.With(h.requirePermissionMiddleware("analytics:read")).Get("/analytics/articles/id/{id}/views", h.ArticleViews)
 }
 
 func (h *Handler) registerAuthRoutes(r chi.Router) {
 	// User registration and activation
-	r.Post("/users", h.RegisterUser)
-	r.Patch("/users/activate", h.ActivateUser)
+	r.With(h.rateLimitPolicy("registration")).Post("/users", h.RegisterUser)
+	r.With(h.rateLimitPolicy("activation")).Patch("/users/activate", h.ActivateUser)
 
 	// Authentication token creation, refresh, logout, and status
-	r.Post("/auth/login", h.AuthenticationToken)
+	r.With(h.rateLimitPolicy("login")).Post("/auth/login", h.AuthenticationToken)
 	r.Post("/auth/refresh", h.RefreshToken)
 	r.Post("/auth/logout", h.LogoutToken)
 	r.Get("/auth/status", h.AuthStatus)
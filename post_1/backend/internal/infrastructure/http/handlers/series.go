@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/infrastructure/http/dto"
+	"personal_website/internal/infrastructure/http/mappers"
+	"personal_website/pkg/utils"
+	"time"
+)
+
+// ListSeries godoc
+// @Summary List the series
+// @Description Get the series with a published article, with their published articles in their order. The
+// @Description response is cached, and revalidated with its ETag or Last-Modified.
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param If-None-Match header string false "ETag of the cached list"
+// @Param If-Modified-Since header string false "Last-Modified of the cached list"
+// @Success 200 {object} utils.Envelope{data=[]dto.SeriesResponse} "List of series"
+// @Success 304 "The cached list is current"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/series [get]
+func (h *Handler) ListSeries(w http.ResponseWriter, r *http.Request) {
+	h.serveCachedResponse(w, r, seriesListCacheKey, func(ctx context.Context) (domain.CachedResponse, error) {
+		series, err := h.datastore.SeriesRepo().ListSeries(ctx)
+		if err != nil {
+			return domain.CachedResponse{}, err
+		}
+		return h.renderSeries(ctx, utils.Envelope{"data": mappers.SeriesListToResponses(series)}, series...)
+	})
+}
+
+// GetSeriesBySlug godoc
+// @Summary Get a series by slug
+// @Description Retrieve a series with its published articles in their order. A series without a published
+// @Description article is not found. The response is cached, and revalidated with its ETag or Last-Modified.
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param slug path string true "Series slug"
+// @Param If-None-Match header string false "ETag of the cached series"
+// @Param If-Modified-Since header string false "Last-Modified of the cached series"
+// @Success 200 {object} utils.Envelope{data=dto.SeriesResponse} "Series details"
+// @Success 304 "The cached series is current"
+// @Failure 404 {object} string "Series not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/series/{slug} [get]
+func (h *Handler) GetSeriesBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("slug")
+	if slug == "" {
+		h.errorResponder.BadRequestResponse(w, r, fmt.Errorf("slug parameter is required"))
+		return
+	}
+
+	h.serveCachedResponse(w, r, seriesSlugCachePrefix+slug, func(ctx context.Context) (domain.CachedResponse, error) {
+		series, err := h.datastore.SeriesRepo().GetSeriesBySlug(ctx, slug)
+		if err != nil {
+			return domain.CachedResponse{}, err
+		}
+		return h.renderSeries(ctx, utils.Envelope{"data": mappers.SeriesToResponse(series)}, series)
+	})
+}
+
+// renderSeries renders public series. The ETag changes with the series and
+// the versions of their articles, the last modification is the one of any
+// article as unpublishing one removes it from its series.
+func (h *Handler) renderSeries(ctx context.Context, data utils.Envelope, series ...domain.Series) (domain.CachedResponse, error) {
+	lastModified, err := h.datastore.ArticleRepo().GetArticlesLastModified(ctx)
+	if err != nil {
+		return domain.CachedResponse{}, err
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return domain.CachedResponse{}, domain.NewInternalError(err)
+	}
+
+	hash := sha256.New()
+	for _, s := range series {
+		writeSeriesHash(hash, s)
+		lastModified = latest(lastModified, s.UpdatedAt)
+	}
+
+	return domain.CachedResponse{
+		Body:         body,
+		ETag:         `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`,
+		LastModified: lastModified,
+	}, nil
+}
+
+// articleSeriesPart returns the place of a published article in its series,
+// and whether it is in one.
+func (h *Handler) articleSeriesPart(ctx context.Context, article domain.Article) (domain.SeriesPart, bool, error) {
+	series, err := h.datastore.SeriesRepo().GetArticleSeries(ctx, article.ID)
+	if errors.Is(err, domain.ErrSeriesNotFound) {
+		return domain.SeriesPart{}, false, nil
+	}
+	if err != nil {
+		return domain.SeriesPart{}, false, err
+	}
+
+	part, ok := series.Part(article.ID)
+	return part, ok, nil
+}
+
+// seriesArticleValidators returns the ETag and the last modification of an
+// article of a series, which follow the other articles of the series.
+func (h *Handler) seriesArticleValidators(ctx context.Context, article domain.Article, series domain.Series) (string, time.Time, error) {
+	lastModified, err := h.datastore.ArticleRepo().GetArticlesLastModified(ctx)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	hash := sha256.New()
+	writeSeriesHash(hash, series)
+
+	etag := fmt.Sprintf(`"%d-%d-%s"`, article.ID, article.Version, hex.EncodeToString(hash.Sum(nil)[:8]))
+	return etag, latest(article.UpdatedAt, lastModified, series.UpdatedAt), nil
+}
+
+func writeSeriesHash(hash hash.Hash, series domain.Series) {
+	fmt.Fprintf(hash, "%d:%d:", series.ID, series.UpdatedAt.Unix())
+	for _, article := range series.Articles {
+		fmt.Fprintf(hash, "%d:%d,", article.ID, article.Version)
+	}
+	hash.Write([]byte{';'})
+}
+
+func latest(times ...time.Time) time.Time {
+	var latest time.Time
+	for _, t := range times {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// ListAllSeries godoc
+// @Summary List all series (including unpublished articles)
+// @Description Get every series with all its articles in their order, including the unpublished ones and the
+// @Description ones in the trash (admin endpoint)
+// @Tags series
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} utils.Envelope{data=[]dto.SeriesResponse} "List of series"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/series/all [get]
+func (h *Handler) ListAllSeries(w http.ResponseWriter, r *http.Request) {
+	series, err := h.datastore.SeriesRepo().ListAllSeries(r.Context())
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	err = utils.WriteJSON(w, http.StatusOK, utils.Envelope{"data": mappers.SeriesListToResponses(series)})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
+
+// GetSeriesByID godoc
+// @Summary Get a series by ID
+// @Description Retrieve a series with all its articles in their order, including the unpublished ones and the
+// @Description ones in the trash
+// @Tags series
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Series ID"
+// @Success 200 {object} utils.Envelope{data=dto.SeriesResponse} "Series details"
+// @Failure 400 {object} string "Invalid ID parameter"
+// @Failure 404 {object} string "Series not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/series/id/{id} [get]
+func (h *Handler) GetSeriesByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.extractIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	series, err := h.datastore.SeriesRepo().GetSeriesByID(r.Context(), id)
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	err = utils.WriteJSON(w, http.StatusOK, utils.Envelope{"data": mappers.SeriesToResponse(series)})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
+
+// CreateSeries godoc
+// @Summary Create a series
+// @Description Create a series with its articles in their order. An article belongs to a single series, the
+// @Description unpublished ones only appear once published.
+// @Tags series
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param series body dto.SeriesRequest true "Series data"
+// @Success 201 {object} utils.Envelope{data=dto.SeriesResponse} "Series created"
+// @Failure 400 {object} string "Invalid JSON body or validation error"
+// @Failure 404 {object} string "Article not found"
+// @Failure 409 {object} string "Series with slug already exists, or an article is in another series"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/series [post]
+func (h *Handler) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	var request dto.SeriesRequest
+
+	err := utils.ReadJSON(w, r, &request)
+	if err != nil {
+		h.errorResponder.BadRequestResponse(w, r, err)
+		return
+	}
+
+	if !h.validateDTO(w, r, request, "create series") {
+		return
+	}
+
+	series, err := h.datastore.SeriesRepo().CreateSeries(r.Context(), mappers.SeriesRequestToDomain(request), request.ArticleIDs)
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	err = utils.WriteJSON(w, http.StatusCreated, utils.Envelope{"data": mappers.SeriesToResponse(series)})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
+
+// SetSeriesArticles godoc
+// @Summary Set the articles of a series
+// @Description Replace the articles of a series by the given ones, in their order, to reorder, add or remove
+// @Description articles
+// @Tags series
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Series ID"
+// @Param articles body dto.SeriesArticlesRequest true "Articles of the series in their order"
+// @Success 200 {object} utils.Envelope{data=dto.SeriesResponse} "Series updated"
+// @Failure 400 {object} string "Invalid JSON body or validation error"
+// @Failure 404 {object} string "Series or article not found"
+// @Failure 409 {object} string "An article is in another series"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/series/id/{id}/articles [put]
+func (h *Handler) SetSeriesArticles(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.extractIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	var request dto.SeriesArticlesRequest
+
+	err := utils.ReadJSON(w, r, &request)
+	if err != nil {
+		h.errorResponder.BadRequestResponse(w, r, err)
+		return
+	}
+
+	if !h.validateDTO(w, r, request, "set series articles") {
+		return
+	}
+
+	ctx := r.Context()
+	repo := h.datastore.SeriesRepo()
+
+	if err := repo.SetSeriesArticles(ctx, id, request.ArticleIDs); err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	series, err := repo.GetSeriesByID(ctx, id)
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	err = utils.WriteJSON(w, http.StatusOK, utils.Envelope{"data": mappers.SeriesToResponse(series)})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
+
+// DeleteSeries godoc
+// @Summary Delete a series
+// @Description Delete a series, its articles are kept
+// @Tags series
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Series ID"
+// @Success 200 "Series deleted"
+// @Failure 400 {object} string "Invalid ID parameter"
+// @Failure 404 {object} string "Series not found"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/series/id/{id} [delete]
+func (h *Handler) DeleteSeries(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.extractIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.datastore.SeriesRepo().DeleteSeries(r.Context(), id)
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"personal_website/internal/infrastructure/http/mappers"
+	"personal_website/pkg/utils"
+	"strconv"
+	"time"
+)
+
+// syncTimeout bounds a sync, which outlasts the write timeout of the server
+// on large repositories.
+const syncTimeout = 2 * time.Minute
+
+// SyncContent godoc
+// @Summary Sync the articles with the content repository
+// @Description Create, update and soft delete the articles so that they match the Markdown files of the
+// @Description configured git repository. The articles created through the API are only changed when a
+// @Description file has the same slug. A dry run reports the changes without applying them.
+// @Tags articles
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param dry_run query bool false "Only report the changes (default false)"
+// @Success 200 {object} utils.Envelope{data=dto.SyncResponse} "Changes applied, or planned by a dry run"
+// @Failure 400 {object} string "Invalid dry_run parameter"
+// @Failure 401 {object} string "Unauthorized"
+// @Failure 404 {object} string "Content sync is not configured"
+// @Failure 409 {object} string "A sync is already running"
+// @Failure 422 {object} string "The repository has no Markdown file"
+// @Failure 500 {object} string "Internal server error"
+// @Router /v1/sync [post]
+func (h *Handler) SyncContent(w http.ResponseWriter, r *http.Request) {
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(value)
+		if err != nil {
+			h.errorResponder.BadRequestResponse(w, r, errors.New("invalid dry_run parameter"))
+			return
+		}
+	}
+
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(syncTimeout + 5*time.Second)); err != nil {
+		h.logger.WarnContext(r.Context(), "Failed to extend the write deadline of the sync", "error", err)
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), syncTimeout)
+	defer cancel()
+
+	report, err := h.syncService.Sync(ctx, dryRun)
+	if err != nil {
+		h.HandleDomainError(w, r, err)
+		return
+	}
+
+	data := mappers.SyncReportToResponse(report)
+
+	err = utils.WriteJSON(w, http.StatusOK, utils.Envelope{"data": data})
+	if err != nil {
+		h.errorResponder.ServerErrorResponse(w, r, err)
+	}
+}
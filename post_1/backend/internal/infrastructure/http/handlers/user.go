@@ -9,7 +9,8 @@ import (
 
 // RegisterUser godoc
 // @Summary Register a new user
-// @Description Create a new user account with email and password
+// @Description Create a new user account with email and password. The optional language
+// @Description (falling back to the Accept-Language header) selects the locale of emails sent to the user.
 // @Tags users
 // @Accept json
 // @Produce json
@@ -32,14 +33,14 @@ func (h *Handler) RegisterUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := mappers.UserRequestToDomain(userRequest)
+	user, err := mappers.UserRequestToDomain(userRequest, preferredLanguage(r), h.config.Config().SMTP.DefaultLocale)
 	if err != nil {
 		h.errorResponder.ServerErrorResponse(w, r, err)
 		return
 	}
 
 	ctx := r.Context()
-	err = h.userService.RegisterUser(ctx, user, h.config.ActivationUrl)
+	err = h.userService.RegisterUser(ctx, user, h.config.App().ActivationUrl)
 	if err != nil {
 		h.HandleDomainError(w, r, err)
 		return
@@ -0,0 +1,38 @@
+package mappers
+
+import (
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/infrastructure/http/dto"
+)
+
+func ArticleViewsToResponse(articleID int32, dateRange domain.DateRange, views []domain.ArticleViews) dto.ArticleViewsResponse {
+	response := dto.ArticleViewsResponse{
+		ArticleID: articleID,
+		From:      dateRange.From.Format(domain.DayLayout),
+		To:        dateRange.To.Format(domain.DayLayout),
+		Days:      make([]dto.DailyViewsResponse, len(views)),
+	}
+
+	for i, day := range views {
+		response.Days[i] = dto.DailyViewsResponse{
+			Day:   day.Day.Format(domain.DayLayout),
+			Views: day.Views,
+		}
+		response.Total += day.Views
+	}
+
+	return response
+}
+
+func TopArticlesToResponses(articles []domain.TopArticle) []dto.TopArticleResponse {
+	responses := make([]dto.TopArticleResponse, len(articles))
+	for i, article := range articles {
+		responses[i] = dto.TopArticleResponse{
+			ArticleID: article.ArticleID,
+			Title:     article.Title,
+			Slug:      article.Slug,
+			Views:     article.Views,
+		}
+	}
+	return responses
+}
@@ -10,6 +10,7 @@ func ArticleRequestToDomain(req dto.ArticleRequest) domain.Article {
 		Title:   req.Title,
 		Slug:    req.Slug,
 		Content: req.Content,
+		Tags:    req.Tags,
 	}
 }
 
@@ -19,6 +20,7 @@ func ArticleRequestToDomainWithID(req dto.ArticleRequest, id int32) domain.Artic
 		Title:   req.Title,
 		Slug:    req.Slug,
 		Content: req.Content,
+		Tags:    req.Tags,
 	}
 }
 
@@ -27,7 +29,9 @@ func ArticleToPreview(article domain.Article) dto.ArticlePreview {
 		ID:          article.ID,
 		Title:       article.Title,
 		Slug:        article.Slug,
+		Tags:        articleTags(article),
 		IsPublished: article.IsPublished,
+		Version:     article.Version,
 	}
 
 	if !article.CreatedAt.IsZero() {
@@ -62,7 +66,9 @@ func ArticleToResponse(article domain.Article) dto.ArticleResponse {
 		Title:       article.Title,
 		Slug:        article.Slug,
 		Content:     article.Content,
+		Tags:        articleTags(article),
 		IsPublished: article.IsPublished,
+		Version:     article.Version,
 	}
 
 	if !article.CreatedAt.IsZero() {
@@ -82,3 +88,12 @@ func ArticleToResponse(article domain.Article) dto.ArticleResponse {
 
 	return response
 }
+
+// articleTags answers an empty list rather than null for an article without
+// tags.
+func articleTags(article domain.Article) []string {
+	if article.Tags == nil {
+		return []string{}
+	}
+	return article.Tags
+}
@@ -12,3 +12,21 @@ func ContactFormToDomain(form dto.ContactForm) domain.ContactMessage {
 		Message: form.Message,
 	}
 }
+
+func ContactFormToSubmission(form dto.ContactForm, clientIP string) domain.ContactSubmission {
+	return domain.ContactSubmission{
+		ContactMessage: ContactFormToDomain(form),
+		Honeypot:       form.Website,
+		Challenge:      form.Challenge,
+		Nonce:          form.Nonce,
+		ClientIP:       clientIP,
+	}
+}
+
+func ContactChallengeToResponse(challenge domain.ContactChallenge) dto.ContactChallengeResponse {
+	return dto.ContactChallengeResponse{
+		Challenge:  challenge.Token,
+		Difficulty: challenge.Difficulty,
+		ExpiresAt:  challenge.ExpiresAt,
+	}
+}
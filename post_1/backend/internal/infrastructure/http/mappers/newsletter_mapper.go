@@ -0,0 +1,34 @@
+package mappers
+
+import (
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/infrastructure/http/dto"
+)
+
+func SubscriberToResponse(subscriber domain.Subscriber) dto.SubscriberResponse {
+	response := dto.SubscriberResponse{
+		ID:        subscriber.ID,
+		Email:     subscriber.Email,
+		Language:  subscriber.Language,
+		Confirmed: subscriber.Confirmed,
+	}
+
+	if !subscriber.CreatedAt.IsZero() {
+		response.CreatedAt = subscriber.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	if !subscriber.ConfirmedAt.IsZero() {
+		confirmedAt := subscriber.ConfirmedAt.Format("2006-01-02T15:04:05Z")
+		response.ConfirmedAt = &confirmedAt
+	}
+
+	return response
+}
+
+func SubscribersToResponses(subscribers []domain.Subscriber) []dto.SubscriberResponse {
+	responses := make([]dto.SubscriberResponse, len(subscribers))
+	for i, subscriber := range subscribers {
+		responses[i] = SubscriberToResponse(subscriber)
+	}
+	return responses
+}
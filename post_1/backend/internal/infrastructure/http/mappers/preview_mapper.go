@@ -0,0 +1,35 @@
+package mappers
+
+import (
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/infrastructure/http/dto"
+	"time"
+)
+
+func PreviewLinkToResponse(link domain.PreviewLink, now time.Time) dto.PreviewLinkResponse {
+	response := dto.PreviewLinkResponse{
+		ID:        link.ID,
+		ArticleID: link.ArticleID,
+		Token:     link.Token,
+		ExpiresAt: link.ExpiresAt.Format("2006-01-02T15:04:05Z"),
+		Active:    link.Active(now),
+	}
+
+	if !link.CreatedAt.IsZero() {
+		response.CreatedAt = link.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if !link.RevokedAt.IsZero() {
+		revokedAt := link.RevokedAt.Format("2006-01-02T15:04:05Z")
+		response.RevokedAt = &revokedAt
+	}
+
+	return response
+}
+
+func PreviewLinksToResponses(links []domain.PreviewLink, now time.Time) []dto.PreviewLinkResponse {
+	responses := make([]dto.PreviewLinkResponse, len(links))
+	for i, link := range links {
+		responses[i] = PreviewLinkToResponse(link, now)
+	}
+	return responses
+}
@@ -0,0 +1,31 @@
+package mappers
+
+import (
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/infrastructure/http/dto"
+)
+
+func ResumeVersionToResponse(version domain.ResumeVersion) dto.ResumeVersionResponse {
+	response := dto.ResumeVersionResponse{
+		ID:          version.ID,
+		FileName:    version.FileName,
+		ContentType: version.ContentType,
+		Size:        version.Size,
+		Checksum:    version.Checksum,
+		IsCurrent:   version.IsCurrent,
+	}
+
+	if !version.CreatedAt.IsZero() {
+		response.CreatedAt = version.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	return response
+}
+
+func ResumeVersionsToResponses(versions []domain.ResumeVersion) []dto.ResumeVersionResponse {
+	responses := make([]dto.ResumeVersionResponse, len(versions))
+	for i, version := range versions {
+		responses[i] = ResumeVersionToResponse(version)
+	}
+	return responses
+}
@@ -0,0 +1,56 @@
+package mappers
+
+import (
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/infrastructure/http/dto"
+)
+
+func SeriesRequestToDomain(req dto.SeriesRequest) domain.Series {
+	return domain.Series{
+		Title:       req.Title,
+		Slug:        req.Slug,
+		Description: req.Description,
+	}
+}
+
+func SeriesToResponse(series domain.Series) dto.SeriesResponse {
+	response := dto.SeriesResponse{
+		ID:          series.ID,
+		Title:       series.Title,
+		Slug:        series.Slug,
+		Description: series.Description,
+		Articles:    ArticlesToPreviews(series.Articles),
+	}
+
+	if !series.CreatedAt.IsZero() {
+		response.CreatedAt = series.CreatedAt.Format("2006-01-02")
+	}
+
+	return response
+}
+
+func SeriesListToResponses(series []domain.Series) []dto.SeriesResponse {
+	responses := make([]dto.SeriesResponse, len(series))
+	for i, s := range series {
+		responses[i] = SeriesToResponse(s)
+	}
+	return responses
+}
+
+func SeriesPartToResponse(part domain.SeriesPart) *dto.ArticleSeriesResponse {
+	return &dto.ArticleSeriesResponse{
+		Title:    part.Series.Title,
+		Slug:     part.Series.Slug,
+		Position: part.Position,
+		Total:    len(part.Series.Articles),
+		Previous: seriesArticleLink(part.Previous),
+		Next:     seriesArticleLink(part.Next),
+	}
+}
+
+func seriesArticleLink(article *domain.Article) *dto.SeriesArticleLink {
+	if article == nil {
+		return nil
+	}
+	return &dto.SeriesArticleLink{Title: article.Title, Slug: article.Slug}
+}
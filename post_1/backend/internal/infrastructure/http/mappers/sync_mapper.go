@@ -0,0 +1,36 @@
+package mappers
+
+import (
+	"personal_website/internal/app/core/domain"
+	"personal_website/internal/infrastructure/http/dto"
+)
+
+// SyncReportToResponse lists the changes, the unchanged articles are only
+// counted.
+func SyncReportToResponse(report domain.SyncReport) dto.SyncResponse {
+	response := dto.SyncResponse{
+		Commit:    report.Commit,
+		DryRun:    report.DryRun,
+		Created:   report.Count(domain.SyncCreate),
+		Updated:   report.Count(domain.SyncUpdate),
+		Deleted:   report.Count(domain.SyncDelete),
+		Unchanged: report.Count(domain.SyncUnchanged),
+		Failed:    report.Count(domain.SyncFailed),
+		Changes:   []dto.SyncChangeResponse{},
+	}
+
+	for _, change := range report.Changes {
+		if change.Action == domain.SyncUnchanged {
+			continue
+		}
+		response.Changes = append(response.Changes, dto.SyncChangeResponse{
+			Action:    string(change.Action),
+			Path:      change.Path,
+			Slug:      change.Slug,
+			ArticleID: change.ArticleID,
+			Error:     change.Error,
+		})
+	}
+
+	return response
+}
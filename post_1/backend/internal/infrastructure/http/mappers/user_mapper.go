@@ -3,12 +3,25 @@ package mappers
 import (
 	"personal_website/internal/app/core/domain"
 	"personal_website/internal/infrastructure/http/dto"
+	"strings"
 )
 
-func UserRequestToDomain(req dto.UserRequest) (domain.User, error) {
+// UserRequestToDomain maps a registration. The language of the request wins,
+// then the preferred one of the client, which may be empty, and the default
+// locale of the emails.
+func UserRequestToDomain(req dto.UserRequest, preferredLanguage string, defaultLanguage string) (domain.User, error) {
+	language := req.Language
+	if language == "" {
+		language = preferredLanguage
+	}
+	if language == "" {
+		language = defaultLanguage
+	}
+
 	user := domain.User{
-		Name:  req.Name,
-		Email: req.Email,
+		Name:     req.Name,
+		Email:    req.Email,
+		Language: strings.ToLower(language),
 	}
 
 	err := user.Password.Set(req.Password)
@@ -8,9 +8,11 @@ import (
 	"time"
 
 	"personal_website/config"
+	"personal_website/pkg/health"
+	"personal_website/pkg/telemetry"
+	"personal_website/pkg/utils"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 type MetricsServer struct {
@@ -19,11 +21,11 @@ type MetricsServer struct {
 	config *config.Config
 }
 
-func NewMetricsServer(logger *slog.Logger, cfg *config.Config) *MetricsServer {
+func NewMetricsServer(logger *slog.Logger, cfg *config.Config, healthChecks *health.Registry, telemetryInstance *telemetry.Telemetry) *MetricsServer {
 	r := chi.NewRouter()
 
 	// Metrics endpoint
-	r.Handle("/metrics", promhttp.Handler())
+	r.Handle("/metrics", telemetryInstance.Handler())
 
 	// Health check for the metrics server
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -31,6 +33,20 @@ func NewMetricsServer(logger *slog.Logger, cfg *config.Config) *MetricsServer {
 		w.Write([]byte("OK"))
 	})
 
+	// Detailed dependency health report for operators
+	r.Get("/health/details", func(w http.ResponseWriter, r *http.Request) {
+		report := healthChecks.Run(r.Context())
+
+		status := http.StatusOK
+		if report.Status == health.StatusDown {
+			status = http.StatusServiceUnavailable
+		}
+
+		if err := utils.WriteJSON(w, status, report); err != nil {
+			logger.Error("Failed to write health report", "error", err)
+		}
+	})
+
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.App.MetricsPort),
 		Handler:      r,
@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -10,6 +11,8 @@ import (
 	"personal_website/config"
 	"personal_website/internal/app/core/ports"
 	"personal_website/internal/infrastructure/http/handlers"
+	"personal_website/pkg/clientip"
+	"personal_website/pkg/health"
 	"personal_website/pkg/telemetry"
 	"personal_website/pkg/utils"
 
@@ -17,37 +20,55 @@ import (
 )
 
 type Server struct {
-	server         *http.Server
-	handler        *handlers.Handler
-	logger         *slog.Logger
-	config         *config.Config
-	datastore      ports.Datastore
-	errorResponder *utils.ErrorResponder
+	server            *http.Server
+	handler           *handlers.Handler
+	logger            *slog.Logger
+	config            *config.Config
+	datastore         ports.Datastore
+	newsletterService ports.NewsletterService
+	analyticsService  ports.AnalyticsService
+	errorResponder    *utils.ErrorResponder
 }
 
 func NewServer(
 	logger *slog.Logger,
-	cfg *config.Config,
+	cfg *config.Live,
 	datastore ports.Datastore,
 	resumeService ports.ResumeService,
 	emailService ports.EmailService,
 	userService ports.UserService,
+	contactGuard ports.ContactGuard,
+	newsletterService ports.NewsletterService,
+	analyticsService ports.AnalyticsService,
+	syncService ports.ContentSyncService,
+	previewService ports.PreviewService,
+	rateLimiter ports.RateLimiter,
+	clientIPResolver *clientip.Resolver,
+	healthChecks *health.Registry,
 	errorResponder *utils.ErrorResponder,
 	telemetryInstance *telemetry.Telemetry,
 ) *Server {
 	handler := handlers.NewHandler(
-		&cfg.App,
+		cfg,
 		logger,
 		datastore,
 		emailService,
 		resumeService,
 		userService,
+		contactGuard,
+		newsletterService,
+		analyticsService,
+		syncService,
+		previewService,
+		rateLimiter,
+		clientIPResolver,
+		healthChecks,
 		errorResponder,
 		telemetryInstance,
 	)
 
 	srv := &http.Server{
-		Addr:         fmt.Sprintf(":%d", cfg.App.Port),
+		Addr:         fmt.Sprintf(":%d", cfg.App().Port),
 		Handler:      handler.Routes(),
 		ReadTimeout:  1 * time.Second,
 		WriteTimeout: 5 * time.Second,
@@ -56,12 +77,14 @@ func NewServer(
 	}
 
 	return &Server{
-		server:         srv,
-		handler:        handler,
-		logger:         logger,
-		datastore:      datastore,
-		config:         cfg,
-		errorResponder: errorResponder,
+		server:            srv,
+		handler:           handler,
+		logger:            logger,
+		datastore:         datastore,
+		newsletterService: newsletterService,
+		analyticsService:  analyticsService,
+		config:            cfg.Config(),
+		errorResponder:    errorResponder,
 	}
 }
 
@@ -70,6 +93,15 @@ func (s *Server) Serve(ctx context.Context) error {
 	return s.server.ListenAndServe()
 }
 
+// Shutdown stops accepting requests, then waits for the background work
+// started by the handled ones.
 func (s *Server) Shutdown(ctx context.Context) error {
-	return s.server.Shutdown(ctx)
+	err := s.server.Shutdown(ctx)
+	if closeErr := s.newsletterService.Close(ctx); closeErr != nil {
+		err = errors.Join(err, fmt.Errorf("newsletter: %w", closeErr))
+	}
+	if closeErr := s.analyticsService.Close(ctx); closeErr != nil {
+		err = errors.Join(err, fmt.Errorf("analytics: %w", closeErr))
+	}
+	return err
 }
\ No newline at end of file
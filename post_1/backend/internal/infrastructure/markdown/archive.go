@@ -0,0 +1,169 @@
+package markdown
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// Archive formats of an export
+const (
+	FormatTarGz = "tar.gz"
+	FormatZip   = "zip"
+)
+
+var (
+	ErrUnsupportedFile = errors.New("only .md, .markdown, .tar, .tar.gz, .tgz and .zip files are accepted")
+	ErrArchiveTooLarge = errors.New("the archive exceeds the maximum import size once extracted")
+)
+
+// File is a file of an import or an export.
+type File struct {
+	Name string
+	Data []byte
+}
+
+// IsArchive reports whether the file at name is a tar, gzipped tar or zip
+// archive.
+func IsArchive(name string) bool {
+	name = strings.ToLower(name)
+	for _, ext := range []string{".tar", ".tar.gz", ".tgz", ".zip"} {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadArchive returns the article files of the archive at name, the other
+// files are ignored. limit is the maximum size of the extracted files, so
+// that a small compressed archive cannot exhaust the memory.
+func ReadArchive(name string, data []byte, limit int64) ([]File, error) {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return readZip(data, limit)
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip archive: %w", err)
+		}
+		defer gz.Close()
+		return ReadTar(gz, limit)
+	case strings.HasSuffix(lower, ".tar"):
+		return ReadTar(bytes.NewReader(data), limit)
+	default:
+		return nil, ErrUnsupportedFile
+	}
+}
+
+// ReadTar returns the article files of a tar stream, like ReadArchive. Only
+// the article files are kept in memory.
+func ReadTar(r io.Reader, limit int64) ([]File, error) {
+	var files []File
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid tar archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg || !IsArticleFile(header.Name) {
+			continue
+		}
+
+		data, err := readLimited(tr, &limit)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Name: path.Clean(header.Name), Data: data})
+	}
+}
+
+func readZip(data []byte, limit int64) ([]File, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid zip archive: %w", err)
+	}
+
+	var files []File
+	for _, entry := range zr.File {
+		if entry.FileInfo().IsDir() || !IsArticleFile(entry.Name) {
+			continue
+		}
+
+		rc, err := entry.Open()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name, err)
+		}
+		data, err := readLimited(rc, &limit)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Name: path.Clean(entry.Name), Data: data})
+	}
+	return files, nil
+}
+
+// readLimited reads r and subtracts its size from the remaining limit.
+func readLimited(r io.Reader, limit *int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, *limit+1))
+	if err != nil {
+		return nil, err
+	}
+	*limit -= int64(len(data))
+	if *limit < 0 {
+		return nil, ErrArchiveTooLarge
+	}
+	return data, nil
+}
+
+// WriteArchive writes the files to w as an archive in the given format.
+func WriteArchive(w io.Writer, format string, files []File) error {
+	switch format {
+	case FormatZip:
+		zw := zip.NewWriter(w)
+		for _, file := range files {
+			fw, err := zw.Create(file.Name)
+			if err != nil {
+				return err
+			}
+			if _, err := fw.Write(file.Data); err != nil {
+				return err
+			}
+		}
+		return zw.Close()
+	case FormatTarGz:
+		gz := gzip.NewWriter(w)
+		tw := tar.NewWriter(gz)
+		for _, file := range files {
+			header := &tar.Header{
+				Name:     file.Name,
+				Mode:     0o644,
+				Size:     int64(len(file.Data)),
+				Typeflag: tar.TypeReg,
+			}
+			if err := tw.WriteHeader(header); err != nil {
+				return err
+			}
+			if _, err := tw.Write(file.Data); err != nil {
+				return err
+			}
+		}
+		if err := tw.Close(); err != nil {
+			return err
+		}
+		return gz.Close()
+	default:
+		return fmt.Errorf("unsupported archive format %q", format)
+	}
+}
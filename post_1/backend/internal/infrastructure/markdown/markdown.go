@@ -0,0 +1,131 @@
+// Package markdown converts the articles from and to Markdown files with a
+// YAML front matter, the format the authors keep in git:
+//
+//	---
+//	title: Hello world
+//	slug: hello-world
+//	tags: [go, web]
+//	date: 2024-05-01
+//	draft: false
+//	---
+//
+//	The content of the article.
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"personal_website/internal/app/core/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+const delimiter = "---"
+
+var ErrMissingFrontMatter = errors.New("the file must start with a YAML front matter between --- lines")
+
+// frontMatter is the metadata of an article. The slug defaults to the name of
+// the file and date, the publication date, to the current one.
+type frontMatter struct {
+	Title string     `yaml:"title"`
+	Slug  string     `yaml:"slug,omitempty"`
+	Tags  []string   `yaml:"tags,omitempty"`
+	Date  *time.Time `yaml:"date,omitempty"`
+	Draft bool       `yaml:"draft"`
+}
+
+// IsArticleFile reports whether the file at name is imported as an article.
+// Hidden files and directories, such as .git, are skipped.
+func IsArticleFile(name string) bool {
+	for _, part := range strings.Split(path.Clean(name), "/") {
+		if strings.HasPrefix(part, ".") && part != "." && part != ".." {
+			return false
+		}
+	}
+	ext := strings.ToLower(path.Ext(name))
+	return ext == ".md" || ext == ".markdown"
+}
+
+// Parse reads the article in the Markdown file at name. The article is not
+// validated.
+func Parse(name string, data []byte) (domain.Article, error) {
+	text := strings.ReplaceAll(string(bytes.TrimPrefix(data, []byte("\ufeff"))), "\r\n", "\n")
+
+	rest, ok := strings.CutPrefix(text, delimiter+"\n")
+	if !ok {
+		return domain.Article{}, ErrMissingFrontMatter
+	}
+	header, content, ok := strings.Cut(rest, "\n"+delimiter+"\n")
+	if !ok {
+		// The front matter of an article without content
+		header, ok = strings.CutSuffix(rest, "\n"+delimiter)
+		if !ok {
+			return domain.Article{}, ErrMissingFrontMatter
+		}
+	}
+
+	var meta frontMatter
+	decoder := yaml.NewDecoder(strings.NewReader(header))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&meta); err != nil && !errors.Is(err, io.EOF) {
+		return domain.Article{}, fmt.Errorf("invalid front matter: %w", err)
+	}
+
+	article := domain.Article{
+		Title: strings.TrimSpace(meta.Title),
+		Slug:  strings.TrimSpace(meta.Slug),
+		// Format separates the content with a blank line and ends the file
+		// with a newline
+		Content:     strings.TrimSuffix(strings.TrimPrefix(content, "\n"), "\n"),
+		Tags:        meta.Tags,
+		IsPublished: !meta.Draft,
+	}
+	if article.Slug == "" {
+		article.Slug = strings.TrimSuffix(path.Base(name), path.Ext(name))
+	}
+	if article.Tags == nil {
+		article.Tags = []string{}
+	}
+	if meta.Date != nil && !meta.Draft {
+		article.PublishedAt = *meta.Date
+	}
+	return article, nil
+}
+
+// Format writes the article as a Markdown file, which Parse reads back.
+func Format(article domain.Article) ([]byte, error) {
+	meta := frontMatter{
+		Title: article.Title,
+		Slug:  article.Slug,
+		Tags:  article.Tags,
+		Draft: !article.IsPublished,
+	}
+	if article.IsPublished && !article.PublishedAt.IsZero() {
+		date := article.PublishedAt.UTC()
+		meta.Date = &date
+	}
+
+	header, err := yaml.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(delimiter + "\n")
+	buf.Write(header)
+	buf.WriteString(delimiter + "\n\n")
+	buf.WriteString(article.Content)
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// FileName is the name of the file of the article in an export.
+func FileName(article domain.Article) string {
+	return article.Slug + ".md"
+}
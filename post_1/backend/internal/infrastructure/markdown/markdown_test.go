@@ -0,0 +1,138 @@
+package markdown
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"personal_website/internal/app/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	data := []byte("---\ntitle: Hello world\ntags: [go, web]\ndate: 2024-05-01\n---\n\n# Hello\n\nThe content.\n")
+
+	article, err := Parse("posts/hello-world.md", data)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Hello world", article.Title)
+	assert.Equal(t, "hello-world", article.Slug, "the slug defaults to the file name")
+	assert.Equal(t, []string{"go", "web"}, article.Tags)
+	assert.Equal(t, "# Hello\n\nThe content.", article.Content)
+	assert.True(t, article.IsPublished)
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), article.PublishedAt)
+}
+
+func TestParse_Draft(t *testing.T) {
+	data := []byte("---\r\ntitle: Draft\r\nslug: my-draft\r\ndate: 2024-05-01\r\ndraft: true\r\n---\r\nContent\r\n")
+
+	article, err := Parse("draft.md", data)
+	require.NoError(t, err)
+
+	assert.Equal(t, "my-draft", article.Slug)
+	assert.False(t, article.IsPublished)
+	assert.True(t, article.PublishedAt.IsZero(), "a draft has no publication date")
+	assert.Equal(t, []string{}, article.Tags)
+}
+
+func TestParse_Errors(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{name: "no front matter", data: "# Title\n\nContent\n"},
+		{name: "unterminated front matter", data: "---\ntitle: Title\n\nContent\n"},
+		{name: "unknown field", data: "---\ntitle: Title\nauthor: me\n---\nContent\n"},
+		{name: "invalid date", data: "---\ntitle: Title\ndate: yesterday\n---\nContent\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse("article.md", []byte(tc.data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFormat_RoundTrip(t *testing.T) {
+	articles := []domain.Article{
+		{
+			Title:       "Published: with a colon",
+			Slug:        "published",
+			Content:     "Line one\n\n---\n\nLine two",
+			Tags:        []string{"go"},
+			IsPublished: true,
+			PublishedAt: time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			Title:   "Draft",
+			Slug:    "draft",
+			Content: "Draft content",
+			Tags:    []string{},
+		},
+	}
+
+	for _, article := range articles {
+		t.Run(article.Slug, func(t *testing.T) {
+			data, err := Format(article)
+			require.NoError(t, err)
+
+			parsed, err := Parse(FileName(article), data)
+			require.NoError(t, err)
+			assert.Equal(t, article, parsed)
+		})
+	}
+}
+
+func TestIsArticleFile(t *testing.T) {
+	assert.True(t, IsArticleFile("hello.md"))
+	assert.True(t, IsArticleFile("posts/2024/Hello.MARKDOWN"))
+	assert.False(t, IsArticleFile("README.txt"))
+	assert.False(t, IsArticleFile(".git/hello.md"))
+	assert.False(t, IsArticleFile("posts/.hidden.md"))
+}
+
+func TestArchive_RoundTrip(t *testing.T) {
+	files := []File{
+		{Name: "first.md", Data: []byte("first")},
+		{Name: "second.md", Data: []byte("second")},
+	}
+
+	for _, format := range []string{FormatTarGz, FormatZip} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WriteArchive(&buf, format, files))
+			require.True(t, IsArchive("export."+format))
+
+			read, err := ReadArchive("export."+format, buf.Bytes(), 1<<20)
+			require.NoError(t, err)
+			assert.Equal(t, files, read)
+		})
+	}
+}
+
+func TestReadArchive_SkipsOtherFiles(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteArchive(&buf, FormatZip, []File{
+		{Name: "README.txt", Data: []byte("readme")},
+		{Name: ".github/template.md", Data: []byte("template")},
+		{Name: "posts/article.md", Data: []byte("article")},
+	}))
+
+	read, err := ReadArchive("content.zip", buf.Bytes(), 1<<20)
+	require.NoError(t, err)
+	assert.Equal(t, []File{{Name: "posts/article.md", Data: []byte("article")}}, read)
+}
+
+func TestReadArchive_Limit(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteArchive(&buf, FormatTarGz, []File{
+		{Name: "first.md", Data: bytes.Repeat([]byte("a"), 600)},
+		{Name: "second.md", Data: bytes.Repeat([]byte("b"), 600)},
+	}))
+
+	_, err := ReadArchive("content.tgz", buf.Bytes(), 1000)
+	assert.ErrorIs(t, err, ErrArchiveTooLarge)
+}
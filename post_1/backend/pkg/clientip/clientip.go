@@ -0,0 +1,166 @@
+package clientip
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// Resolver finds the IP of the client behind a chain of reverse proxies.
+// Forwarding headers are only read when the request comes from a trusted
+// proxy, and the chain is walked from the right so that the entries a client
+// added itself are never used.
+type Resolver struct {
+	trusted []netip.Prefix
+}
+
+// NewResolver returns a Resolver trusting the proxies in cidrs. Bare IPs are
+// accepted as single address prefixes. With no cidrs, forwarding headers are
+// ignored and the peer address is used.
+func NewResolver(cidrs []string) (*Resolver, error) {
+	trusted := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+
+		if !strings.Contains(cidr, "/") {
+			addr, err := netip.ParseAddr(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+			}
+			addr = addr.Unmap()
+			trusted = append(trusted, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+		}
+		trusted = append(trusted, prefix.Masked())
+	}
+
+	return &Resolver{trusted: trusted}, nil
+}
+
+// ClientIP returns the IP of the client that made r.
+//
+// When the peer is a trusted proxy, the hops of the Forwarded header (RFC 7239),
+// or of X-Forwarded-For when it is absent, are read from right to left and the
+// first untrusted one is the client. X-Real-IP is only used when the proxy
+// sent no chain.
+func (res *Resolver) ClientIP(r *http.Request) string {
+	peer, ok := parseHost(r.RemoteAddr)
+	if !ok {
+		return r.RemoteAddr
+	}
+	if !res.isTrusted(peer) {
+		return peer.String()
+	}
+
+	chain, ok := forwardedChain(r.Header)
+	if !ok {
+		chain, ok = forwardedForChain(r.Header)
+	}
+
+	if !ok {
+		if realIP, ok := parseHost(r.Header.Get("X-Real-IP")); ok {
+			return realIP.String()
+		}
+		return peer.String()
+	}
+
+	// The peer is the last hop and is trusted
+	client := peer
+	for i := len(chain) - 1; i >= 0; i-- {
+		hop, ok := parseHost(chain[i])
+		if !ok {
+			// Unknown or obfuscated hop: nothing on its left can be trusted
+			return client.String()
+		}
+
+		client = hop
+		if !res.isTrusted(hop) {
+			break
+		}
+	}
+
+	return client.String()
+}
+
+func (res *Resolver) isTrusted(addr netip.Addr) bool {
+	for _, prefix := range res.trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardedChain returns the for= values of every Forwarded header, in order.
+func forwardedChain(header http.Header) ([]string, bool) {
+	values := header.Values("Forwarded")
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	var chain []string
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			for _, pair := range strings.Split(element, ";") {
+				name, value, found := strings.Cut(strings.TrimSpace(pair), "=")
+				if !found || !strings.EqualFold(name, "for") {
+					continue
+				}
+				chain = append(chain, strings.Trim(value, `"`))
+			}
+		}
+	}
+
+	return chain, len(chain) > 0
+}
+
+// forwardedForChain returns the hops of every X-Forwarded-For header, in order.
+func forwardedForChain(header http.Header) ([]string, bool) {
+	values := header.Values("X-Forwarded-For")
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	var chain []string
+	for _, value := range values {
+		for _, hop := range strings.Split(value, ",") {
+			chain = append(chain, strings.TrimSpace(hop))
+		}
+	}
+
+	return chain, len(chain) > 0
+}
+
+// parseHost parses an IP with an optional port, as found in RemoteAddr and
+// the forwarding headers: 192.0.2.1, 192.0.2.1:80, 2001:db8::1 or
+// [2001:db8::1]:80.
+func parseHost(host string) (netip.Addr, bool) {
+	host = strings.TrimSpace(host)
+	if host == "" {
+		return netip.Addr{}, false
+	}
+
+	if addr, err := netip.ParseAddr(strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")); err == nil {
+		return addr.Unmap(), true
+	}
+
+	h, _, err := net.SplitHostPort(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	addr, err := netip.ParseAddr(h)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
@@ -0,0 +1,125 @@
+package clientip
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIP(t *testing.T) {
+	resolver, err := NewResolver([]string{"10.0.0.0/8", "192.0.2.1", "2001:db8::/32"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string][]string
+		want       string
+	}{
+		{
+			name:       "untrusted peer ignores headers",
+			remoteAddr: "203.0.113.7:4000",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.1"}, "X-Real-IP": {"198.51.100.2"}},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "trusted peer without headers",
+			remoteAddr: "10.0.0.1:4000",
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "spoofed leftmost entry is skipped",
+			remoteAddr: "10.0.0.1:4000",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.2.3.4, 198.51.100.1, 10.0.0.2"}},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "multiple X-Forwarded-For headers",
+			remoteAddr: "10.0.0.1:4000",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.2.3.4", "198.51.100.1"}},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "every hop trusted",
+			remoteAddr: "10.0.0.1:4000",
+			headers:    map[string][]string{"X-Forwarded-For": {"10.0.0.3, 192.0.2.1"}},
+			want:       "10.0.0.3",
+		},
+		{
+			name:       "invalid hop stops the walk",
+			remoteAddr: "10.0.0.1:4000",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.1, garbage, 10.0.0.2"}},
+			want:       "10.0.0.2",
+		},
+		{
+			name:       "Forwarded takes precedence",
+			remoteAddr: "10.0.0.1:4000",
+			headers: map[string][]string{
+				"Forwarded":       {`for=1.2.3.4, for="[2001:db8:cafe::17]:4711";proto=https, for=198.51.100.9:80;by=10.0.0.1`},
+				"X-Forwarded-For": {"203.0.113.1"},
+			},
+			want: "198.51.100.9",
+		},
+		{
+			name:       "Forwarded with obfuscated hop",
+			remoteAddr: "10.0.0.1:4000",
+			headers:    map[string][]string{"Forwarded": {"for=198.51.100.1, for=_hidden"}},
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "X-Real-IP from trusted proxy",
+			remoteAddr: "10.0.0.1:4000",
+			headers:    map[string][]string{"X-Real-IP": {"198.51.100.3"}},
+			want:       "198.51.100.3",
+		},
+		{
+			name:       "IPv6 peer",
+			remoteAddr: "[2001:db8::1]:4000",
+			headers:    map[string][]string{"X-Forwarded-For": {"2001:db9::5"}},
+			want:       "2001:db9::5",
+		},
+		{
+			name:       "IPv4-mapped IPv6 peer",
+			remoteAddr: "[::ffff:10.0.0.1]:4000",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.4"}},
+			want:       "198.51.100.4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+			r.RemoteAddr = tt.remoteAddr
+			for name, values := range tt.headers {
+				for _, value := range values {
+					r.Header.Add(name, value)
+				}
+			}
+
+			assert.Equal(t, tt.want, resolver.ClientIP(r))
+		})
+	}
+}
+
+func TestNoTrustedProxies(t *testing.T) {
+	resolver, err := NewResolver(nil)
+	require.NoError(t, err)
+
+	r, err := http.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, err)
+	r.RemoteAddr = "127.0.0.1:4000"
+	r.Header.Set("X-Forwarded-For", "198.51.100.1")
+
+	assert.Equal(t, "127.0.0.1", resolver.ClientIP(r))
+}
+
+func TestNewResolverRejectsInvalidCIDR(t *testing.T) {
+	_, err := NewResolver([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+
+	_, err = NewResolver([]string{"proxy.local"})
+	assert.Error(t, err)
+}
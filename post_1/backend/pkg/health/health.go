@@ -0,0 +1,176 @@
+package health
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusUp       Status = "up"
+	StatusDegraded Status = "degraded"
+	StatusDown     Status = "down"
+)
+
+// defaultTimeout bounds the checks registered without a timeout.
+const defaultTimeout = 2 * time.Second
+
+// Checker returns an error when the dependency is unavailable.
+type Checker func(ctx context.Context) error
+
+// Check is a named Checker. A failing critical check makes the application
+// not ready, a failing non critical one only degrades it.
+type Check struct {
+	Name     string
+	Checker  Checker
+	Timeout  time.Duration
+	Critical bool
+}
+
+// Result is the outcome of a single check.
+type Result struct {
+	Status    Status    `json:"status"`
+	Critical  bool      `json:"critical"`
+	Duration  string    `json:"duration"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Report aggregates the results of every registered check.
+type Report struct {
+	Status Status            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+	// Info describes the running instance, e.g. its schema version
+	Info map[string]string `json:"info,omitempty"`
+}
+
+// Registry runs the registered checks and caches the report for cacheTTL,
+// so that frequent probes do not hammer the dependencies.
+type Registry struct {
+	cacheTTL time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	checks    []Check
+	info      map[string]string
+	report    Report
+	expiresAt time.Time
+}
+
+func NewRegistry(cacheTTL time.Duration) *Registry {
+	return &Registry{
+		cacheTTL: cacheTTL,
+		now:      time.Now,
+	}
+}
+
+// Register adds a check. It invalidates the cached report.
+func (reg *Registry) Register(check Check) {
+	if check.Timeout <= 0 {
+		check.Timeout = defaultTimeout
+	}
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	reg.checks = append(reg.checks, check)
+	reg.expiresAt = time.Time{}
+}
+
+// SetInfo adds an entry to the info of the reports. It invalidates the cached
+// report.
+func (reg *Registry) SetInfo(key string, value string) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	if reg.info == nil {
+		reg.info = make(map[string]string)
+	}
+	reg.info[key] = value
+	reg.expiresAt = time.Time{}
+}
+
+// Run returns the cached report, or runs every check concurrently when it
+// expired. Concurrent callers wait for the same run.
+//
+// The report is shared by every caller, so the checks are not canceled with
+// ctx: a probe that disconnects must not cache a "down" report. They are only
+// bounded by their timeout.
+func (reg *Registry) Run(ctx context.Context) Report {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	if reg.now().Before(reg.expiresAt) {
+		return reg.report
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	results := make([]Result, len(reg.checks))
+	var wg sync.WaitGroup
+	for i, check := range reg.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = reg.runCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]Result, len(reg.checks)),
+		Info:   maps.Clone(reg.info),
+	}
+	for i, check := range reg.checks {
+		result := results[i]
+		report.Checks[check.Name] = result
+
+		if result.Status == StatusDown {
+			if check.Critical {
+				report.Status = StatusDown
+			} else if report.Status == StatusUp {
+				report.Status = StatusDegraded
+			}
+		}
+	}
+
+	reg.report = report
+	reg.expiresAt = reg.now().Add(reg.cacheTTL)
+
+	return report
+}
+
+func (reg *Registry) runCheck(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	start := reg.now()
+
+	// Do not wait for checkers that ignore the context
+	done := make(chan error, 1)
+	go func() { done <- check.Checker(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := Result{
+		Status:    StatusUp,
+		Critical:  check.Critical,
+		Duration:  reg.now().Sub(start).String(),
+		CheckedAt: start,
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
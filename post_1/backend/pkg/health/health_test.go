@@ -0,0 +1,123 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunAggregatesStatus(t *testing.T) {
+	up := func(ctx context.Context) error { return nil }
+	down := func(ctx context.Context) error { return errors.New("connection refused") }
+
+	tests := []struct {
+		name   string
+		checks []Check
+		want   Status
+	}{
+		{
+			name:   "no checks",
+			checks: nil,
+			want:   StatusUp,
+		},
+		{
+			name:   "all up",
+			checks: []Check{{Name: "db", Checker: up, Critical: true}, {Name: "smtp", Checker: up}},
+			want:   StatusUp,
+		},
+		{
+			name:   "non critical down",
+			checks: []Check{{Name: "db", Checker: up, Critical: true}, {Name: "smtp", Checker: down}},
+			want:   StatusDegraded,
+		},
+		{
+			name:   "critical down",
+			checks: []Check{{Name: "db", Checker: down, Critical: true}, {Name: "smtp", Checker: down}},
+			want:   StatusDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry(0)
+			for _, check := range tt.checks {
+				registry.Register(check)
+			}
+
+			report := registry.Run(context.Background())
+			assert.Equal(t, tt.want, report.Status)
+			assert.Len(t, report.Checks, len(tt.checks))
+		})
+	}
+}
+
+func TestRunReportsErrors(t *testing.T) {
+	registry := NewRegistry(0)
+	registry.Register(Check{Name: "db", Critical: true, Checker: func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}})
+
+	result := registry.Run(context.Background()).Checks["db"]
+	assert.Equal(t, StatusDown, result.Status)
+	assert.True(t, result.Critical)
+	assert.Equal(t, "connection refused", result.Error)
+}
+
+func TestRunIgnoresCallerCancellation(t *testing.T) {
+	registry := NewRegistry(time.Minute)
+	registry.Register(Check{Name: "db", Critical: true, Checker: func(ctx context.Context) error {
+		return ctx.Err()
+	}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Equal(t, StatusUp, registry.Run(ctx).Status, "a disconnected probe does not fail the checks")
+	assert.Equal(t, StatusUp, registry.Run(context.Background()).Status)
+}
+
+func TestRunEnforcesTimeout(t *testing.T) {
+	registry := NewRegistry(0)
+	registry.Register(Check{Name: "slow", Timeout: 10 * time.Millisecond, Checker: func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}})
+
+	start := time.Now()
+	result := registry.Run(context.Background()).Checks["slow"]
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, StatusDown, result.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), result.Error)
+}
+
+func TestRunCachesReport(t *testing.T) {
+	calls := 0
+	registry := NewRegistry(time.Minute)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	registry.now = func() time.Time { return now }
+	registry.Register(Check{Name: "db", Checker: func(ctx context.Context) error {
+		calls++
+		return nil
+	}})
+
+	registry.Run(context.Background())
+	registry.Run(context.Background())
+	assert.Equal(t, 1, calls)
+
+	now = now.Add(2 * time.Minute)
+	registry.Run(context.Background())
+	assert.Equal(t, 2, calls)
+}
+
+func TestRunReportsInfo(t *testing.T) {
+	registry := NewRegistry(time.Minute)
+	assert.Nil(t, registry.Run(context.Background()).Info)
+
+	registry.SetInfo("schema_version", "7")
+
+	report := registry.Run(context.Background())
+	assert.Equal(t, map[string]string{"schema_version": "7"}, report.Info, "cached report invalidated")
+}
@@ -0,0 +1,54 @@
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Signer produces and verifies tamper-proof tokens of the form <payload>.<signature>
+// using HMAC-SHA256.
+type Signer struct {
+	key []byte
+}
+
+// NewSigner returns a Signer using key. When key is empty a random key is
+// generated, so tokens are only valid for the lifetime of the process.
+func NewSigner(key []byte) (*Signer, error) {
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Signer{key: key}, nil
+}
+
+// Sign appends the signature of payload to it.
+func (s *Signer) Sign(payload string) string {
+	return payload + "." + s.signature(payload)
+}
+
+// Verify checks the signature of token and returns its payload.
+func (s *Signer) Verify(token string) (string, bool) {
+	i := strings.LastIndex(token, ".")
+	if i < 0 {
+		return "", false
+	}
+
+	payload, signature := token[:i], token[i+1:]
+	if !hmac.Equal([]byte(signature), []byte(s.signature(payload))) {
+		return "", false
+	}
+
+	return payload, true
+}
+
+func (s *Signer) signature(payload string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
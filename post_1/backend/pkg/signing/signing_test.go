@@ -0,0 +1,53 @@
+package signing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	signer, err := NewSigner([]byte("secret"))
+	require.NoError(t, err)
+
+	token := signer.Sign("v1.42.payload")
+
+	payload, ok := signer.Verify(token)
+	assert.True(t, ok)
+	assert.Equal(t, "v1.42.payload", payload)
+}
+
+func TestVerifyRejectsInvalidTokens(t *testing.T) {
+	signer, err := NewSigner([]byte("secret"))
+	require.NoError(t, err)
+	other, err := NewSigner([]byte("other"))
+	require.NoError(t, err)
+
+	token := signer.Sign("payload")
+
+	tests := map[string]string{
+		"empty":            "",
+		"no signature":     "payload",
+		"tampered payload": "payload2" + token[len("payload"):],
+		"tampered sig":     token + "0",
+		"other key":        other.Sign("payload"),
+	}
+
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, ok := signer.Verify(token)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestNewSignerGeneratesRandomKey(t *testing.T) {
+	first, err := NewSigner(nil)
+	require.NoError(t, err)
+	second, err := NewSigner(nil)
+	require.NoError(t, err)
+
+	_, ok := second.Verify(first.Sign("payload"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,159 @@
+package telemetry
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const instrumentationName = "personal_website/pkg/telemetry"
+
+// Bucket boundaries of the dependency calls, which are much faster than the
+// HTTP requests they serve.
+var clientDurationBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5}
+
+// ClientMetrics measures the calls made to a dependency: <name>_duration_seconds
+// and <name>_errors_total, labelled by operation. The instruments come from the
+// global meter provider set by NewTelemetry.
+type ClientMetrics struct {
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+}
+
+func NewClientMetrics(name string, description string) *ClientMetrics {
+	meter := otel.Meter(instrumentationName)
+
+	duration, err := meter.Float64Histogram(
+		name+"_duration_seconds",
+		metric.WithDescription(description+" duration in seconds"),
+		metric.WithExplicitBucketBoundaries(clientDurationBuckets...),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	failures, err := meter.Int64Counter(
+		name+"_errors_total",
+		metric.WithDescription("Total number of failed "+description+"s"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return &ClientMetrics{
+		duration: duration,
+		errors:   failures,
+	}
+}
+
+// Record measures an operation started at start. A nil err is a success.
+func (m *ClientMetrics) Record(ctx context.Context, operation string, start time.Time, err error) {
+	attrs := metric.WithAttributes(attribute.String("operation", operation))
+
+	m.duration.Record(ctx, time.Since(start).Seconds(), attrs)
+	if err != nil {
+		m.errors.Add(ctx, 1, attrs)
+	}
+}
+
+// CacheMetrics counts the lookups of a cache: <name>_hits_total and
+// <name>_misses_total, labelled by operation.
+type CacheMetrics struct {
+	hits   metric.Int64Counter
+	misses metric.Int64Counter
+}
+
+func NewCacheMetrics(name string, description string) *CacheMetrics {
+	meter := otel.Meter(instrumentationName)
+
+	hits, err := meter.Int64Counter(
+		name+"_hits_total",
+		metric.WithDescription("Total number of "+description+" lookups served from the cache"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	misses, err := meter.Int64Counter(
+		name+"_misses_total",
+		metric.WithDescription("Total number of "+description+" lookups missing the cache"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return &CacheMetrics{
+		hits:   hits,
+		misses: misses,
+	}
+}
+
+// Record counts a lookup of the operation, found or not.
+func (m *CacheMetrics) Record(ctx context.Context, operation string, hit bool) {
+	attrs := metric.WithAttributes(attribute.String("operation", operation))
+
+	if hit {
+		m.hits.Add(ctx, 1, attrs)
+	} else {
+		m.misses.Add(ctx, 1, attrs)
+	}
+}
+
+// InstrumentDBStats exports the connection pool statistics of db, labelled
+// with the database name.
+func InstrumentDBStats(db *sql.DB, name string) error {
+	meter := otel.Meter(instrumentationName)
+
+	openConnections, err := meter.Int64ObservableGauge(
+		"db_pool_open_connections",
+		metric.WithDescription("Number of established connections, in use or idle"),
+	)
+	if err != nil {
+		return err
+	}
+	inUse, err := meter.Int64ObservableGauge(
+		"db_pool_in_use_connections",
+		metric.WithDescription("Number of connections currently in use"),
+	)
+	if err != nil {
+		return err
+	}
+	idle, err := meter.Int64ObservableGauge(
+		"db_pool_idle_connections",
+		metric.WithDescription("Number of idle connections"),
+	)
+	if err != nil {
+		return err
+	}
+	waitCount, err := meter.Int64ObservableCounter(
+		"db_pool_wait_count",
+		metric.WithDescription("Total number of connections waited for"),
+	)
+	if err != nil {
+		return err
+	}
+	waitDuration, err := meter.Float64ObservableCounter(
+		"db_pool_wait_duration_seconds",
+		metric.WithDescription("Total time blocked waiting for a new connection"),
+	)
+	if err != nil {
+		return err
+	}
+
+	attrs := metric.WithAttributes(attribute.String("db_name", name))
+	_, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		stats := db.Stats()
+		o.ObserveInt64(openConnections, int64(stats.OpenConnections), attrs)
+		o.ObserveInt64(inUse, int64(stats.InUse), attrs)
+		o.ObserveInt64(idle, int64(stats.Idle), attrs)
+		o.ObserveInt64(waitCount, stats.WaitCount, attrs)
+		o.ObserveFloat64(waitDuration, stats.WaitDuration.Seconds(), attrs)
+		return nil
+	}, openConnections, inUse, idle, waitCount, waitDuration)
+
+	return err
+}
@@ -2,29 +2,67 @@ package telemetry
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/prometheus"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Bucket boundaries of the HTTP histograms. Sizes go up to the 10MB resume
+// uploads.
+var (
+	durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+	sizeBuckets     = []float64{100, 1000, 10_000, 100_000, 1_000_000, 10_000_000}
 )
 
 type Telemetry struct {
-	meterProvider *sdkmetric.MeterProvider
-	meter         metric.Meter
+	registry       *prometheus.Registry
+	meterProvider  *sdkmetric.MeterProvider
+	meter          metric.Meter
+	tracerProvider *sdktrace.TracerProvider
 
 	// HTTP metrics
 	RequestsTotal    metric.Int64Counter
 	RequestDuration  metric.Float64Histogram
 	RequestsInFlight metric.Int64UpDownCounter
+	RequestSize      metric.Int64Histogram
 	ResponseSize     metric.Int64Histogram
+
+	// Errors answered by HandleDomainError, by DomainError code
+	DomainErrors metric.Int64Counter
+
+	// Contact form metrics
+	ContactSpamDiscarded metric.Int64Counter
+
+	// Rate limiting metrics
+	RateLimitRejected metric.Int64Counter
+
+	// Article views, by whether the visitor was new that day. The views of
+	// each article are in the analytics endpoints, a label per article would
+	// grow with every article published.
+	ArticleViews metric.Int64Counter
 }
 
-func NewTelemetry(logger *slog.Logger) (*Telemetry, error) {
+func NewTelemetry(logger *slog.Logger, tracing TracingOptions) (*Telemetry, error) {
+	// Each instance has its own registry, so that the series of several
+	// servers running in the same process do not mix
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
 	// Create Prometheus exporter
-	promExporter, err := prometheus.New()
+	promExporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
 	if err != nil {
 		return nil, err
 	}
@@ -37,6 +75,14 @@ func NewTelemetry(logger *slog.Logger) (*Telemetry, error) {
 	// Set global meter provider
 	otel.SetMeterProvider(meterProvider)
 
+	// Create tracer provider and propagate the W3C trace context
+	tracerProvider, err := newTracerProvider(context.Background(), tracing)
+	if err != nil {
+		return nil, err
+	}
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
 	// Create meter
 	meter := meterProvider.Meter("personal-website")
 
@@ -52,6 +98,7 @@ func NewTelemetry(logger *slog.Logger) (*Telemetry, error) {
 	requestDuration, err := meter.Float64Histogram(
 		"http_request_duration_seconds",
 		metric.WithDescription("HTTP request duration in seconds"),
+		metric.WithExplicitBucketBoundaries(durationBuckets...),
 	)
 	if err != nil {
 		return nil, err
@@ -65,26 +112,88 @@ func NewTelemetry(logger *slog.Logger) (*Telemetry, error) {
 		return nil, err
 	}
 
+	requestSize, err := meter.Int64Histogram(
+		"http_request_size_bytes",
+		metric.WithDescription("HTTP request body size in bytes"),
+		metric.WithExplicitBucketBoundaries(sizeBuckets...),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	responseSize, err := meter.Int64Histogram(
 		"http_response_size_bytes",
 		metric.WithDescription("HTTP response size in bytes"),
+		metric.WithExplicitBucketBoundaries(sizeBuckets...),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	domainErrors, err := meter.Int64Counter(
+		"http_domain_errors_total",
+		metric.WithDescription("Total number of domain errors answered, by error code"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	contactSpamDiscarded, err := meter.Int64Counter(
+		"contact_spam_discarded_total",
+		metric.WithDescription("Total number of contact form submissions silently discarded as spam"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimitRejected, err := meter.Int64Counter(
+		"rate_limit_rejected_total",
+		metric.WithDescription("Total number of requests rejected by a rate limit policy"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	articleViews, err := meter.Int64Counter(
+		"article_views_total",
+		metric.WithDescription("Total number of article views, unique ones are counted once per visitor and day"),
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Telemetry{
-		meterProvider:    meterProvider,
-		meter:            meter,
-		RequestsTotal:    requestsTotal,
-		RequestDuration:  requestDuration,
-		RequestsInFlight: requestsInFlight,
-		ResponseSize:     responseSize,
+		registry:             registry,
+		meterProvider:        meterProvider,
+		meter:                meter,
+		tracerProvider:       tracerProvider,
+		RequestsTotal:        requestsTotal,
+		RequestDuration:      requestDuration,
+		RequestsInFlight:     requestsInFlight,
+		RequestSize:          requestSize,
+		ResponseSize:         responseSize,
+		DomainErrors:         domainErrors,
+		ContactSpamDiscarded: contactSpamDiscarded,
+		RateLimitRejected:    rateLimitRejected,
+		ArticleViews:         articleViews,
 	}, nil
 }
 
 func (t *Telemetry) Shutdown(ctx context.Context) error {
-	return t.meterProvider.Shutdown(ctx)
+	return errors.Join(
+		t.tracerProvider.Shutdown(ctx),
+		t.meterProvider.Shutdown(ctx),
+	)
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (t *Telemetry) Handler() http.Handler {
+	return promhttp.HandlerFor(t.registry, promhttp.HandlerOpts{})
+}
+
+// Gatherer gives access to the collected metrics.
+func (t *Telemetry) Gatherer() prometheus.Gatherer {
+	return t.registry
 }
 
 // ResponseWriter wraps http.ResponseWriter to capture metrics data
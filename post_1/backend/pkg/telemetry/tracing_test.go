@@ -0,0 +1,40 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogHandlerAddsTraceIDs(t *testing.T) {
+	provider, err := newTracerProvider(context.Background(), TracingOptions{SampleRatio: 1})
+	require.NoError(t, err)
+	defer provider.Shutdown(context.Background())
+
+	var buf bytes.Buffer
+	logger := slog.New(NewLogHandler(slog.NewJSONHandler(&buf, nil))).With("component", "test")
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "operation")
+	logger.InfoContext(ctx, "inside span")
+	span.End()
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, span.SpanContext().TraceID().String(), record["trace_id"])
+	assert.Equal(t, span.SpanContext().SpanID().String(), record["span_id"])
+	assert.Equal(t, "test", record["component"])
+
+	buf.Reset()
+	logger.Info("outside span")
+	assert.NotContains(t, buf.String(), "trace_id")
+}
+
+func TestNewTracerProviderRejectsUnknownExporter(t *testing.T) {
+	_, err := newTracerProvider(context.Background(), TracingOptions{Exporter: "zipkin"})
+	assert.Error(t, err)
+}
@@ -32,7 +32,7 @@ func (e *ErrorResponder) logError(r *http.Request, err error) {
 	)
 
 	correlationID := GetCorrelationID(r.Context())
-	e.Logger.Error(err.Error(), "method", method, "uri", uri, "correlation_id", correlationID)
+	e.Logger.ErrorContext(r.Context(), err.Error(), "method", method, "uri", uri, "correlation_id", correlationID)
 }
 
 func (e *ErrorResponder) ErrorResponse(w http.ResponseWriter, r *http.Request, status int, message any) {
@@ -27,9 +27,14 @@ func validateNoScriptTags(fl validator.FieldLevel) bool {
 }
 
 func validateAlphanumHyphen(fl validator.FieldLevel) bool {
-	slug := fl.Field().String()
-	matched, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", slug)
-	return matched && !strings.HasPrefix(slug, "-") && !strings.HasSuffix(slug, "-")
+	return IsAlphanumHyphen(fl.Field().String())
+}
+
+// IsAlphanumHyphen reports whether s is made of letters, digits and inner
+// hyphens, like a slug.
+func IsAlphanumHyphen(s string) bool {
+	matched, _ := regexp.MatchString("^[a-zA-Z0-9-]+$", s)
+	return matched && !strings.HasPrefix(s, "-") && !strings.HasSuffix(s, "-")
 }
 
 func validateStrongPassword(fl validator.FieldLevel) bool {
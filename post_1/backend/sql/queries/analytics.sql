@@ -0,0 +1,23 @@
+-- name: AddArticleViews :exec
+INSERT INTO analytics.article_views (article_id, day, views)
+SELECT v.article_id, v.day, v.views
+FROM unnest(@article_ids::int[], @days::date[], @views::bigint[]) AS v (article_id, day, views)
+JOIN content.articles a ON a.id = v.article_id
+ON CONFLICT (article_id, day) DO UPDATE
+SET views = analytics.article_views.views + EXCLUDED.views;
+
+-- name: ListArticleViews :many
+SELECT day, views
+FROM analytics.article_views
+WHERE article_id = @article_id
+  AND day BETWEEN @from_day::date AND @to_day::date
+ORDER BY day;
+
+-- name: ListTopArticles :many
+SELECT a.id, a.title, a.slug, SUM(v.views)::bigint AS views
+FROM analytics.article_views v
+JOIN content.articles a ON a.id = v.article_id
+WHERE v.day BETWEEN @from_day::date AND @to_day::date
+GROUP BY a.id, a.title, a.slug
+ORDER BY views DESC, a.id
+LIMIT @row_limit;
@@ -0,0 +1,13 @@
+-- name: GetArticleSlugRedirect :one
+-- The current slug of the published article a retired slug belongs to.
+SELECT a.slug
+FROM content.article_slugs s
+JOIN content.articles a ON a.id = s.article_id
+WHERE s.slug = $1
+    AND a.is_published = true
+    AND a.is_deleted = false;
+
+-- name: GetRetiredSlugArticleID :one
+SELECT article_id
+FROM content.article_slugs
+WHERE slug = $1;
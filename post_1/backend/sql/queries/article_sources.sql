@@ -0,0 +1,26 @@
+-- name: ListArticleSources :many
+SELECT
+  s.article_id,
+  a.slug,
+  s.path,
+  s.content_hash,
+  s.commit_sha,
+  s.synced_at,
+  a.is_deleted
+FROM content.article_sources s
+JOIN content.articles a ON a.id = s.article_id
+ORDER BY s.path;
+
+-- name: SaveArticleSource :exec
+INSERT INTO content.article_sources (
+    article_id,
+    path,
+    content_hash,
+    commit_sha
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (article_id) DO UPDATE
+SET
+    path = EXCLUDED.path,
+    content_hash = EXCLUDED.content_hash,
+    commit_sha = EXCLUDED.commit_sha,
+    synced_at = now();
@@ -3,10 +3,12 @@ SELECT
   id,
   title,
   slug,
+  tags,
   published_at,
   is_published,
   created_at,
-  updated_at
+  updated_at,
+  version
 FROM content.articles
 WHERE is_deleted = false OR is_deleted IS NULL
 ORDER BY created_at DESC;
@@ -17,9 +19,11 @@ SELECT
   title,
   slug,
   content,
+  tags,
   created_at,
   published_at,
-  is_published
+  is_published,
+  version
 FROM content.articles
 WHERE id = $1
     AND (is_deleted = false OR is_deleted IS NULL);
@@ -42,42 +46,77 @@ WHERE id = $1;
 INSERT INTO content.articles (
     title,
     slug,
-    content
-) VALUES ($1, $2, $3);
+    content,
+    tags
+) VALUES (@title, @slug, @content, COALESCE(@tags::text[], '{}'));
 
--- name: UpdateArticle :exec
-UPDATE content.articles
-SET
-    title = $2,
-    slug = $3,
-    content = $4,
-    updated_at = now()
-WHERE id = $1;
+-- name: UpdateArticle :one
+-- The change is only applied to the given version, a zero version applies it
+-- to any version. No row is returned when the article does not exist or has
+-- another version, like for the publication and the deletion.
+-- A replaced slug is retired to redirect to the article, which can take it
+-- back. previous reads the article as it was before the update.
+WITH updated AS (
+    UPDATE content.articles
+    SET
+        title = @title,
+        slug = @slug,
+        content = @content,
+        tags = COALESCE(@tags::text[], tags),
+        version = version + 1,
+        updated_at = now()
+    WHERE id = @id
+        AND (@version::integer = 0 OR version = @version)
+    RETURNING id, version
+), previous AS (
+    SELECT id, slug FROM content.articles WHERE id = @id
+), retired AS (
+    INSERT INTO content.article_slugs (slug, article_id)
+    SELECT previous.slug, updated.id
+    FROM previous
+    JOIN updated ON updated.id = previous.id
+    WHERE previous.slug <> @slug
+    ON CONFLICT (slug) DO NOTHING
+), reclaimed AS (
+    DELETE FROM content.article_slugs s
+    USING updated
+    WHERE s.slug = @slug AND s.article_id = updated.id
+)
+SELECT version FROM updated;
 
--- name: PublishArticle :exec
+-- name: PublishArticle :one
 UPDATE content.articles
 SET
     is_published = true,
     published_at = now(),
+    version = version + 1,
     updated_at = now()
-WHERE id = $1;
+WHERE id = @id
+    AND (@version::integer = 0 OR version = @version)
+RETURNING version;
 
--- name: UnpublishArticle :exec
+-- name: UnpublishArticle :one
 UPDATE content.articles
 SET
     is_published = false,
     published_at = NULL,
+    version = version + 1,
     updated_at = now()
-WHERE id = $1;
+WHERE id = @id
+    AND (@version::integer = 0 OR version = @version)
+RETURNING version;
 
--- name: SoftDeleteArticle :execrows
+-- name: SoftDeleteArticle :one
 UPDATE content.articles
 SET
     is_deleted = true,
     is_published = false,
     deleted_at = now(),
+    version = version + 1,
     updated_at = now()
-WHERE id = $1;
+WHERE id = @id
+    AND (@version::integer = 0 OR version = @version)
+RETURNING version;
 
 -- name: DeleteArticle :execrows
 DELETE FROM content.articles
@@ -90,6 +129,7 @@ UPDATE content.articles
 SET
     is_deleted = false,
     deleted_at = NULL,
+    version = version + 1,
     updated_at = now()
 WHERE id = $1;
 
@@ -106,3 +146,45 @@ SELECT
 FROM content.articles
 WHERE is_deleted = true
 ORDER BY deleted_at DESC;
+
+-- name: ListAllArticlesWithContent :many
+SELECT
+  id,
+  title,
+  slug,
+  content,
+  tags,
+  created_at,
+  published_at,
+  is_published
+FROM content.articles
+WHERE is_deleted = false OR is_deleted IS NULL
+ORDER BY slug;
+
+-- name: UpsertArticleBySlug :one
+-- A published article keeps its publication date unless one is given. The
+-- articles in the trash are not updated, no row is returned for them.
+INSERT INTO content.articles (
+    title,
+    slug,
+    content,
+    tags,
+    is_published,
+    published_at
+) VALUES (
+    @title, @slug, @content, @tags::text[], @is_published::boolean,
+    CASE WHEN @is_published::boolean THEN COALESCE(sqlc.narg(published_at)::timestamptz, now()) END
+)
+ON CONFLICT (slug) DO UPDATE
+SET
+    title = EXCLUDED.title,
+    content = EXCLUDED.content,
+    tags = EXCLUDED.tags,
+    is_published = EXCLUDED.is_published,
+    published_at = CASE
+        WHEN EXCLUDED.is_published THEN COALESCE(sqlc.narg(published_at)::timestamptz, content.articles.published_at, now())
+    END,
+    version = content.articles.version + 1,
+    updated_at = now()
+WHERE content.articles.is_deleted = false OR content.articles.is_deleted IS NULL
+RETURNING id, (xmax = 0)::boolean AS created;
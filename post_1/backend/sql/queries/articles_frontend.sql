@@ -3,7 +3,10 @@ SELECT
   id,
   title,
   slug,
-  published_at
+  tags,
+  published_at,
+  updated_at,
+  version
 FROM content.articles
 WHERE is_published = true
     AND is_deleted = false
@@ -15,10 +18,19 @@ SELECT
   title,
   slug,
   content,
+  tags,
   created_at,
   published_at,
-  is_published
+  is_published,
+  updated_at,
+  version
 FROM content.articles
 WHERE slug = $1
     AND is_published = true
     AND is_deleted = false;
+
+-- name: GetArticlesLastModified :one
+-- The unpublished and deleted articles are included, their last change may
+-- have removed them from the published ones.
+SELECT COALESCE(max(updated_at), 'epoch')::timestamptz AS last_modified
+FROM content.articles;
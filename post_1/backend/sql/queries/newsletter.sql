@@ -0,0 +1,68 @@
+-- name: UpsertSubscriber :one
+INSERT INTO newsletter.subscribers (
+    email,
+    language,
+    confirmation_token_hash,
+    confirmation_expires_at
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (email) DO UPDATE
+SET language = EXCLUDED.language,
+    confirmation_token_hash = EXCLUDED.confirmation_token_hash,
+    confirmation_expires_at = EXCLUDED.confirmation_expires_at
+WHERE newsletter.subscribers.confirmed = false
+RETURNING id;
+
+-- name: ConfirmSubscriber :one
+UPDATE newsletter.subscribers
+SET confirmed = true,
+    confirmed_at = now(),
+    confirmation_token_hash = NULL,
+    confirmation_expires_at = NULL
+WHERE confirmation_token_hash = $1
+  AND confirmation_expires_at > now()
+RETURNING id, email;
+
+-- name: DeleteSubscriber :execrows
+DELETE FROM newsletter.subscribers
+WHERE id = $1;
+
+-- name: ListSubscribers :many
+SELECT id, email, language, confirmed, created_at, confirmed_at
+FROM newsletter.subscribers
+ORDER BY created_at DESC, id DESC;
+
+-- name: ListConfirmedSubscribers :many
+SELECT id, email, language
+FROM newsletter.subscribers
+WHERE confirmed = true
+  AND id > $1
+ORDER BY id
+LIMIT $2;
+
+-- name: ClaimArticleNotification :execrows
+INSERT INTO newsletter.article_notifications (article_id)
+VALUES ($1)
+ON CONFLICT (article_id) DO UPDATE
+SET article_id = EXCLUDED.article_id
+WHERE newsletter.article_notifications.completed_at IS NULL;
+
+-- name: CompleteArticleNotification :exec
+UPDATE newsletter.article_notifications
+SET completed_at = now()
+WHERE article_id = $1;
+
+-- name: ListPendingArticleNotifications :many
+SELECT article_id
+FROM newsletter.article_notifications
+WHERE completed_at IS NULL
+ORDER BY notified_at, article_id;
+
+-- name: ClaimArticleDelivery :execrows
+INSERT INTO newsletter.article_deliveries (article_id, subscriber_id)
+VALUES ($1, $2)
+ON CONFLICT (article_id, subscriber_id) DO NOTHING;
+
+-- name: ReleaseArticleDelivery :exec
+DELETE FROM newsletter.article_deliveries
+WHERE article_id = $1
+  AND subscriber_id = $2;
@@ -10,3 +10,24 @@ INSERT INTO auth.users_permissions (user_id, permission_id)
 SELECT $1, p.id
 FROM auth.permissions AS p
 WHERE p.code = $2;
+
+-- name: GrantPermission :execrows
+INSERT INTO auth.users_permissions (user_id, permission_id)
+SELECT $1, p.id
+FROM auth.permissions AS p
+WHERE p.code = $2
+ON CONFLICT DO NOTHING;
+
+-- name: RevokePermission :execrows
+DELETE FROM auth.users_permissions AS up
+USING auth.permissions AS p
+WHERE up.permission_id = p.id
+    AND up.user_id = $1
+    AND p.code = $2;
+
+-- name: PermissionExists :one
+SELECT EXISTS(
+    SELECT 1
+    FROM auth.permissions
+    WHERE code = $1
+);
@@ -0,0 +1,25 @@
+-- name: CreatePreviewLink :one
+INSERT INTO content.preview_links (
+    article_id,
+    created_by,
+    expires_at
+) VALUES ($1, $2, $3)
+RETURNING id, article_id, created_by, created_at, expires_at, revoked_at;
+
+-- name: GetPreviewLink :one
+SELECT id, article_id, created_by, created_at, expires_at, revoked_at
+FROM content.preview_links
+WHERE id = $1;
+
+-- name: ListPreviewLinks :many
+SELECT id, article_id, created_by, created_at, expires_at, revoked_at
+FROM content.preview_links
+WHERE article_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: RevokePreviewLink :execrows
+-- Revoking a link twice keeps the first revocation date.
+UPDATE content.preview_links
+SET revoked_at = COALESCE(revoked_at, now())
+WHERE id = $1
+    AND article_id = $2;
@@ -0,0 +1,42 @@
+-- name: CreateResumeVersion :one
+INSERT INTO resume.versions (
+    object_name,
+    file_name,
+    content_type,
+    size,
+    checksum,
+    uploaded_by
+) VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, object_name, file_name, content_type, size, checksum, is_current, uploaded_by, created_at;
+
+-- name: GetResumeVersionByID :one
+SELECT id, object_name, file_name, content_type, size, checksum, is_current, uploaded_by, created_at
+FROM resume.versions
+WHERE id = $1;
+
+-- name: GetCurrentResumeVersion :one
+SELECT id, object_name, file_name, content_type, size, checksum, is_current, uploaded_by, created_at
+FROM resume.versions
+WHERE is_current = true
+LIMIT 1;
+
+-- name: ListResumeVersions :many
+SELECT id, object_name, file_name, content_type, size, checksum, is_current, uploaded_by, created_at
+FROM resume.versions
+ORDER BY created_at DESC, id DESC;
+
+-- name: SetCurrentResumeVersion :execrows
+-- The unique index on is_current is checked row by row: the previous current
+-- version is cleared before the new one is set.
+WITH previous AS (
+    UPDATE resume.versions
+    SET is_current = false
+    WHERE is_current = true
+      AND id <> $1
+      AND EXISTS (SELECT 1 FROM resume.versions WHERE id = $1)
+    RETURNING id
+)
+UPDATE resume.versions
+SET is_current = true
+WHERE id = $1
+  AND (SELECT count(*) FROM previous) >= 0;
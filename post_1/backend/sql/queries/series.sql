@@ -0,0 +1,91 @@
+-- name: CreateSeries :one
+-- The series is created with its articles, in their order.
+WITH created AS (
+    INSERT INTO content.series (title, slug, description)
+    VALUES (@title, @slug, @description)
+    RETURNING id, title, slug, description, created_at, updated_at
+), members AS (
+    INSERT INTO content.series_articles (series_id, article_id, position)
+    SELECT created.id, m.article_id, m.position
+    FROM created, unnest(@article_ids::int[]) WITH ORDINALITY AS m (article_id, position)
+)
+SELECT id, title, slug, description, created_at, updated_at
+FROM created;
+
+-- name: GetSeriesByID :one
+SELECT id, title, slug, description, created_at, updated_at
+FROM content.series
+WHERE id = $1;
+
+-- name: GetSeriesBySlug :one
+SELECT id, title, slug, description, created_at, updated_at
+FROM content.series
+WHERE slug = $1;
+
+-- name: GetArticleSeries :one
+SELECT s.id, s.title, s.slug, s.description, s.created_at, s.updated_at
+FROM content.series s
+JOIN content.series_articles sa ON sa.series_id = s.id
+WHERE sa.article_id = $1;
+
+-- name: ListSeries :many
+-- The series without a published article are only listed when all is set.
+SELECT s.id, s.title, s.slug, s.description, s.created_at, s.updated_at
+FROM content.series s
+WHERE @all::boolean
+    OR EXISTS (
+        SELECT 1
+        FROM content.series_articles sa
+        JOIN content.articles a ON a.id = sa.article_id
+        WHERE sa.series_id = s.id
+            AND a.is_published = true
+            AND a.is_deleted = false
+    )
+ORDER BY s.created_at DESC, s.id DESC;
+
+-- name: ListSeriesArticles :many
+-- The articles of the series in their order, only the published ones unless
+-- all is set.
+SELECT
+  sa.series_id,
+  a.id,
+  a.title,
+  a.slug,
+  a.tags,
+  a.created_at,
+  a.published_at,
+  a.updated_at,
+  a.deleted_at,
+  a.is_published,
+  a.version
+FROM content.series_articles sa
+JOIN content.articles a ON a.id = sa.article_id
+WHERE sa.series_id = ANY(@series_ids::int[])
+    AND (@all::boolean OR (a.is_published = true AND a.is_deleted = false))
+ORDER BY sa.series_id, sa.position;
+
+-- name: SetSeriesArticles :execrows
+-- Replaces the articles of the series by the given ones, in their order. No
+-- row is affected when the series does not exist.
+WITH members AS (
+    SELECT m.article_id, m.position::int AS position
+    FROM unnest(@article_ids::int[]) WITH ORDINALITY AS m (article_id, position)
+), removed AS (
+    DELETE FROM content.series_articles sa
+    WHERE sa.series_id = @series_id
+        AND sa.article_id <> ALL(@article_ids::int[])
+), kept AS (
+    INSERT INTO content.series_articles (series_id, article_id, position)
+    SELECT @series_id, members.article_id, members.position
+    FROM members
+    WHERE EXISTS (SELECT 1 FROM content.series WHERE id = @series_id)
+    ON CONFLICT (series_id, article_id) DO UPDATE
+    SET position = EXCLUDED.position
+)
+UPDATE content.series
+SET updated_at = now()
+WHERE id = @series_id;
+
+-- name: DeleteSeries :execrows
+DELETE FROM content.series
+WHERE id = $1;
@@ -2,8 +2,9 @@
 INSERT INTO app.users (
     name,
     email,
-    password_hash
-) VALUES ($1, $2, $3)
+    password_hash,
+    language
+) VALUES ($1, $2, $3, $4)
 RETURNING id;
 
 
@@ -15,7 +16,7 @@ SELECT EXISTS(
 );
 
 -- name: GetUserByEmail :one
-SELECT id, created_at, name, email, password_hash, activated
+SELECT id, created_at, name, email, password_hash, activated, language
 FROM app.users
 WHERE email = $1;
 
@@ -0,0 +1,2 @@
+ALTER TABLE app.users
+    DROP COLUMN IF EXISTS language;
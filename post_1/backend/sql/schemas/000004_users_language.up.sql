@@ -0,0 +1,2 @@
+ALTER TABLE app.users
+    ADD COLUMN IF NOT EXISTS language text NOT NULL DEFAULT 'en';
@@ -0,0 +1,5 @@
+DELETE FROM auth.permissions WHERE code = 'newsletter:read';
+DROP TABLE IF EXISTS newsletter.article_deliveries;
+DROP TABLE IF EXISTS newsletter.article_notifications;
+DROP TABLE IF EXISTS newsletter.subscribers;
+DROP SCHEMA IF EXISTS newsletter;
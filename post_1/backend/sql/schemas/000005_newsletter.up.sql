@@ -0,0 +1,42 @@
+CREATE SCHEMA IF NOT EXISTS newsletter;
+
+CREATE TABLE IF NOT EXISTS newsletter.subscribers (
+    id serial PRIMARY KEY,
+    email citext NOT NULL UNIQUE,
+    language text NOT NULL DEFAULT 'en',
+    confirmed bool NOT NULL DEFAULT false,
+    confirmation_token_hash bytea,
+    confirmation_expires_at timestamp(0) with time zone,
+    created_at timestamp(0) with time zone DEFAULT now(),
+    confirmed_at timestamp(0) with time zone
+);
+
+CREATE INDEX IF NOT EXISTS subscribers_confirmation_token_hash_idx
+    ON newsletter.subscribers (confirmation_token_hash);
+
+-- One row per article announced to the subscribers, so that publishing an
+-- article again after unpublishing it does not send a second email. An
+-- announcement is complete once every subscriber was emailed. Until then it is
+-- resumed on start, and publishing the article again retries it.
+CREATE TABLE IF NOT EXISTS newsletter.article_notifications (
+    article_id integer PRIMARY KEY REFERENCES content.articles ON DELETE CASCADE,
+    notified_at timestamp(0) with time zone DEFAULT now(),
+    completed_at timestamp(0) with time zone
+);
+
+-- One row per subscriber emailed about an article, so that a resumed
+-- announcement skips them.
+CREATE TABLE IF NOT EXISTS newsletter.article_deliveries (
+    article_id integer NOT NULL REFERENCES newsletter.article_notifications ON DELETE CASCADE,
+    subscriber_id integer NOT NULL REFERENCES newsletter.subscribers ON DELETE CASCADE,
+    sent_at timestamp(0) with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (article_id, subscriber_id)
+);
+
+CREATE INDEX IF NOT EXISTS article_deliveries_subscriber_id_idx
+    ON newsletter.article_deliveries (subscriber_id);
+
+INSERT INTO auth.permissions (code)
+VALUES
+    ('newsletter:read')
+ON CONFLICT (code) DO NOTHING;
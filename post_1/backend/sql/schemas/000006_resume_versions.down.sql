@@ -0,0 +1,3 @@
+DELETE FROM auth.permissions WHERE code = 'resume:write';
+DROP TABLE IF EXISTS resume.versions;
+DROP SCHEMA IF EXISTS resume;
@@ -0,0 +1,28 @@
+CREATE SCHEMA IF NOT EXISTS resume;
+
+CREATE TABLE IF NOT EXISTS resume.versions (
+    id serial PRIMARY KEY,
+    object_name text NOT NULL UNIQUE,
+    file_name text NOT NULL,
+    content_type text NOT NULL,
+    size bigint NOT NULL,
+    checksum text NOT NULL,
+    is_current bool NOT NULL DEFAULT false,
+    uploaded_by integer REFERENCES app.users ON DELETE SET NULL,
+    created_at timestamp(0) with time zone DEFAULT now()
+);
+
+-- A single version is downloaded
+CREATE UNIQUE INDEX IF NOT EXISTS versions_is_current_idx
+    ON resume.versions ((true)) WHERE is_current;
+
+-- The resume uploaded before versioning existed stays the current version
+-- until a new one is uploaded. Its size and checksum are unknown.
+INSERT INTO resume.versions (object_name, file_name, content_type, size, checksum, is_current)
+VALUES ('resume_without_personal_data.pdf', 'jordan_delbar_resume.pdf', 'application/pdf', 0, '', true)
+ON CONFLICT (object_name) DO NOTHING;
+
+INSERT INTO auth.permissions (code)
+VALUES
+    ('resume:write')
+ON CONFLICT (code) DO NOTHING;
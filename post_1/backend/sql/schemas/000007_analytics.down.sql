@@ -0,0 +1,3 @@
+DELETE FROM auth.permissions WHERE code = 'analytics:read';
+DROP TABLE IF EXISTS analytics.article_views;
+DROP SCHEMA IF EXISTS analytics;
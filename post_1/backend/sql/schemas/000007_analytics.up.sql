@@ -0,0 +1,18 @@
+CREATE SCHEMA IF NOT EXISTS analytics;
+
+-- Unique visitors per article and day. Visitors are deduplicated in Valkey,
+-- only the daily totals are stored.
+CREATE TABLE IF NOT EXISTS analytics.article_views (
+    article_id integer NOT NULL REFERENCES content.articles ON DELETE CASCADE,
+    day date NOT NULL,
+    views bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY (article_id, day)
+);
+
+CREATE INDEX IF NOT EXISTS article_views_day_idx
+    ON analytics.article_views (day);
+
+INSERT INTO auth.permissions (code)
+VALUES
+    ('analytics:read')
+ON CONFLICT (code) DO NOTHING;
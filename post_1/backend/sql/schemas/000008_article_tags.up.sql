@@ -0,0 +1,2 @@
+ALTER TABLE content.articles
+    ADD COLUMN IF NOT EXISTS tags text[] NOT NULL DEFAULT '{}';
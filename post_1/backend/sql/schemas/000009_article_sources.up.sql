@@ -0,0 +1,15 @@
+-- The file of the content repository each synced article comes from. The
+-- articles created through the API have no source and are left alone by the
+-- sync.
+CREATE TABLE IF NOT EXISTS content.article_sources (
+    article_id integer PRIMARY KEY REFERENCES content.articles ON DELETE CASCADE,
+    path text NOT NULL,
+    content_hash text NOT NULL,
+    commit_sha text NOT NULL,
+    synced_at timestamp(0) with time zone NOT NULL DEFAULT now()
+);
+
+-- The files are matched to their article by path, a file that changes its
+-- slug renames its article
+CREATE UNIQUE INDEX IF NOT EXISTS article_sources_path_idx
+    ON content.article_sources (path);
@@ -0,0 +1,2 @@
+ALTER TABLE content.articles
+    DROP COLUMN IF EXISTS version;
@@ -0,0 +1,4 @@
+-- Incremented on every change of the article, the CMS sends it back to detect
+-- concurrent edits.
+ALTER TABLE content.articles
+    ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
@@ -0,0 +1,10 @@
+-- The slugs an article was renamed from. Their links redirect to the current
+-- slug of the article, so they cannot be taken by another article.
+CREATE TABLE IF NOT EXISTS content.article_slugs (
+    slug text PRIMARY KEY,
+    article_id integer NOT NULL REFERENCES content.articles ON DELETE CASCADE,
+    retired_at timestamp(0) with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS article_slugs_article_id_idx
+    ON content.article_slugs (article_id);
@@ -0,0 +1,13 @@
+-- The links sharing an article with reviewers before it is published. The
+-- token of a link is signed, its row lists and revokes it.
+CREATE TABLE IF NOT EXISTS content.preview_links (
+    id serial PRIMARY KEY,
+    article_id integer NOT NULL REFERENCES content.articles ON DELETE CASCADE,
+    created_by integer REFERENCES app.users ON DELETE SET NULL,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT now(),
+    expires_at timestamp(0) with time zone NOT NULL,
+    revoked_at timestamp(0) with time zone
+);
+
+CREATE INDEX IF NOT EXISTS preview_links_article_id_idx
+    ON content.preview_links (article_id);
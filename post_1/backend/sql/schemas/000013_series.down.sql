@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS content.series_articles;
+DROP TABLE IF EXISTS content.series;
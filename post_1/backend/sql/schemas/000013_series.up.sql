@@ -0,0 +1,20 @@
+-- The series link the parts of a multi-part article in their order. An
+-- article belongs to a single series.
+CREATE TABLE IF NOT EXISTS content.series (
+    id serial PRIMARY KEY,
+    title text NOT NULL,
+    slug text UNIQUE NOT NULL,
+    description text NOT NULL DEFAULT '',
+    created_at timestamp(0) with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp(0) with time zone NOT NULL DEFAULT now()
+);
+
+-- The positions are checked at the end of the statement, so that the
+-- articles of a series can be reordered at once.
+CREATE TABLE IF NOT EXISTS content.series_articles (
+    series_id integer NOT NULL REFERENCES content.series ON DELETE CASCADE,
+    article_id integer UNIQUE NOT NULL REFERENCES content.articles ON DELETE CASCADE,
+    position integer NOT NULL,
+    PRIMARY KEY (series_id, article_id),
+    UNIQUE (series_id, position) DEFERRABLE INITIALLY IMMEDIATE
+);
@@ -0,0 +1,8 @@
+// Package schemas embeds the database migrations, so that the binary can
+// apply them without the sql directory.
+package schemas
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,151 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"personal_website/internal/infrastructure/adapters/repository/postgres/sqlc"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type articleViewsResponse struct {
+	Data struct {
+		ArticleID int32 `json:"article_id"`
+		Total     int64 `json:"total"`
+		Days      []struct {
+			Day   string `json:"day"`
+			Views int64  `json:"views"`
+		} `json:"days"`
+	} `json:"data"`
+}
+
+func createPublishedArticle(t *testing.T, slug string) int32 {
+	t.Helper()
+	ctx := context.Background()
+
+	err := queries.CreateArticle(ctx, sqlc.CreateArticleParams{
+		Title:   "Analytics Test Article",
+		Slug:    slug,
+		Content: "Content worth reading.",
+	})
+	require.NoError(t, err)
+
+	var articleID int32
+	err = db.QueryRow("SELECT id FROM content.articles WHERE slug = $1", slug).Scan(&articleID)
+	require.NoError(t, err)
+
+	_, err = queries.PublishArticle(ctx, sqlc.PublishArticleParams{ID: articleID})
+	require.NoError(t, err)
+	return articleID
+}
+
+func viewArticle(t *testing.T, serverAddr, slug, clientIP, userAgent string) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, serverAddr+"/v1/articles/slug/"+slug, nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Forwarded-For", clientIP)
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestAnalytics_CountsUniqueViews(t *testing.T) {
+	suite := NewTestSuite(t)
+
+	// Discard the views left by the previous tests, Valkey is shared
+	_, err := datastore.ViewCounterRepo().DrainViews(context.Background())
+	require.NoError(t, err)
+
+	articleID := createPublishedArticle(t, "analytics-test-article")
+	userAgent := fmt.Sprintf("analytics-test/%d", time.Now().UnixNano())
+
+	viewArticle(t, suite.ServerAddr, "analytics-test-article", "198.51.100.1", userAgent)
+	viewArticle(t, suite.ServerAddr, "analytics-test-article", "198.51.100.1", userAgent)
+	viewArticle(t, suite.ServerAddr, "analytics-test-article", "198.51.100.2", userAgent)
+
+	viewsPath := fmt.Sprintf("/v1/analytics/articles/id/%d/views", articleID)
+	var views articleViewsResponse
+	require.Eventually(t, func() bool {
+		resp, err := suite.GET(t, viewsPath)
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+
+		views = articleViewsResponse{}
+		return resp.StatusCode == http.StatusOK &&
+			json.NewDecoder(resp.Body).Decode(&views) == nil &&
+			views.Data.Total == 2
+	}, 5*time.Second, 100*time.Millisecond, "views should be flushed to Postgres")
+
+	assert.Equal(t, articleID, views.Data.ArticleID)
+	require.Len(t, views.Data.Days, 30)
+	today := views.Data.Days[len(views.Data.Days)-1]
+	assert.Equal(t, time.Now().UTC().Format("2006-01-02"), today.Day)
+	assert.Equal(t, int64(2), today.Views)
+
+	resp, err := suite.GET(t, "/v1/analytics/articles/top?limit=5")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var top struct {
+		Data []struct {
+			ArticleID int32  `json:"article_id"`
+			Slug      string `json:"slug"`
+			Views     int64  `json:"views"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&top))
+	require.Len(t, top.Data, 1)
+	assert.Equal(t, "analytics-test-article", top.Data[0].Slug)
+	assert.Equal(t, int64(2), top.Data[0].Views)
+
+	require.NotNil(t, testTelemetry)
+	assert.True(t, labelValues(t, "article_views_total", "unique")["false"], "duplicate views are exported too")
+	assert.Empty(t, labelValues(t, "article_views_total", "article_id"), "the metric is not labelled per article")
+}
+
+func TestAnalytics_Validation(t *testing.T) {
+	suite := NewTestSuite(t)
+	articleID := createPublishedArticle(t, "analytics-validation-article")
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{"invalid date", fmt.Sprintf("/v1/analytics/articles/id/%d/views?from=yesterday", articleID), http.StatusBadRequest},
+		{"reversed range", fmt.Sprintf("/v1/analytics/articles/id/%d/views?from=2024-02-01&to=2024-01-01", articleID), http.StatusUnprocessableEntity},
+		{"range over a year", "/v1/analytics/articles/top?from=2022-01-01&to=2024-01-01", http.StatusUnprocessableEntity},
+		{"invalid limit", "/v1/analytics/articles/top?limit=1000", http.StatusBadRequest},
+		{"unknown article", "/v1/analytics/articles/id/999999/views", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := suite.GET(t, tt.path)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
+
+func TestAnalytics_RequiresAuthentication(t *testing.T) {
+	suite := NewUnauthenticatedTestSuite(t)
+
+	resp, err := http.Get(suite.ServerAddr + "/v1/analytics/articles/top")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,135 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// conditionalGet gets the public route with a conditional header.
+func conditionalGet(t *testing.T, url string, header string, value string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// publishArticleThroughAPI creates and publishes an article through the API, so
+// that the cache is invalidated like in production.
+func publishArticleThroughAPI(t *testing.T, suite *TestSuite, slug string) int32 {
+	t.Helper()
+
+	resp, err := suite.POST(t, "/v1/articles", map[string]string{
+		"title":   "Cached Article",
+		"slug":    slug,
+		"content": "The content of an article served from the cache, long enough.",
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var articleID int32
+	err = db.QueryRow("SELECT id FROM content.articles WHERE slug = $1", slug).Scan(&articleID)
+	require.NoError(t, err)
+
+	url := fmt.Sprintf("%s/v1/articles/id/%d/publish", suite.ServerAddr, articleID)
+	resp, err = NewRequestWithIfMatch(t, "PATCH", url, suite.AuthToken, `"1"`, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	return articleID
+}
+
+func TestGetArticleBySlug_Revalidation(t *testing.T) {
+	suite := NewTestSuite(t)
+	articleID := publishArticleThroughAPI(t, suite, "cached-article")
+	url := suite.ServerAddr + "/v1/articles/slug/cached-article"
+
+	resp := conditionalGet(t, url, "", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	assert.Equal(t, fmt.Sprintf(`"%d-2"`, articleID), etag)
+	assert.NotEmpty(t, lastModified)
+	assert.Equal(t, "public, max-age=60, stale-while-revalidate=300", resp.Header.Get("Cache-Control"))
+
+	// The client copy is current
+	resp = conditionalGet(t, url, "If-None-Match", etag)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Equal(t, etag, resp.Header.Get("ETag"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Empty(t, body)
+
+	resp = conditionalGet(t, url, "If-None-Match", `"other", W/`+etag)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode, "the weak comparison is used")
+
+	resp = conditionalGet(t, url, "If-Modified-Since", lastModified)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+	// An update invalidates the cached response
+	jsonData, err := json.Marshal(map[string]string{
+		"title":   "Updated Cached Article",
+		"slug":    "cached-article",
+		"content": "The updated content of an article served from the cache.",
+	})
+	require.NoError(t, err)
+	updateResp, err := NewRequestWithIfMatch(t, "PUT", fmt.Sprintf("%s/v1/articles/id/%d", suite.ServerAddr, articleID), suite.AuthToken, `"2"`, jsonData)
+	require.NoError(t, err)
+	updateResp.Body.Close()
+	require.Equal(t, http.StatusOK, updateResp.StatusCode)
+
+	resp = conditionalGet(t, url, "If-None-Match", etag)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, fmt.Sprintf(`"%d-3"`, articleID), resp.Header.Get("ETag"))
+
+	var response map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.Equal(t, "Updated Cached Article", response["data"].(map[string]any)["title"])
+}
+
+func TestListArticles_InvalidatedByUnpublish(t *testing.T) {
+	suite := NewTestSuite(t)
+	articleID := publishArticleThroughAPI(t, suite, "listed-article")
+	url := suite.ServerAddr + "/v1/articles"
+
+	resp := conditionalGet(t, url, "", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+
+	var response map[string][]map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	require.Len(t, response["data"], 1)
+
+	resp = conditionalGet(t, url, "If-None-Match", etag)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+	unpublishURL := fmt.Sprintf("%s/v1/articles/id/%d/unpublish", suite.ServerAddr, articleID)
+	unpublishResp, err := NewRequestWithIfMatch(t, "PATCH", unpublishURL, suite.AuthToken, "*", nil)
+	require.NoError(t, err)
+	unpublishResp.Body.Close()
+	require.Equal(t, http.StatusOK, unpublishResp.StatusCode)
+
+	resp = conditionalGet(t, url, "If-None-Match", etag)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotEqual(t, etag, resp.Header.Get("ETag"))
+
+	response = nil
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.Empty(t, response["data"])
+}
@@ -233,7 +233,7 @@ func TestUpdateArticle(t *testing.T) {
 	require.NoError(t, err)
 
 	url := fmt.Sprintf("%s/v1/articles/id/%d", suite.ServerAddr, articleID)
-	resp, err := NewRequestWithAuthentication(t, "PUT", url, suite.AuthToken, jsonData)
+	resp, err := NewRequestWithIfMatch(t, "PUT", url, suite.AuthToken, `"1"`, jsonData)
 	if err != nil {
 		t.Error(err)
 	}
@@ -263,7 +263,7 @@ func TestPublishArticle(t *testing.T) {
 
 	// Publish the article with authentication
 	url := fmt.Sprintf("%s/v1/articles/id/%d/publish", suite.ServerAddr, articleID)
-	resp, err := NewRequestWithAuthentication(t, "PATCH", url, suite.AuthToken, nil)
+	resp, err := NewRequestWithIfMatch(t, "PATCH", url, suite.AuthToken, `"1"`, nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -300,7 +300,7 @@ func TestUnpublishArticle(t *testing.T) {
 
 	// Unpublish the article with authentication
 	url := fmt.Sprintf("%s/v1/articles/id/%d/unpublish", suite.ServerAddr, articleID)
-	resp, err := NewRequestWithAuthentication(t, "PATCH", url, suite.AuthToken, nil)
+	resp, err := NewRequestWithIfMatch(t, "PATCH", url, suite.AuthToken, `"1"`, nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -309,6 +309,97 @@ func TestUnpublishArticle(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
+func TestUpdateArticle_ConcurrentEdit_ReturnsPreconditionFailed(t *testing.T) {
+	suite := NewTestSuite(t)
+
+	err := queries.CreateArticle(context.Background(), sqlc.CreateArticleParams{
+		Title:   "Shared Article",
+		Slug:    "shared-article",
+		Content: "Content edited by two editors at the same time.",
+	})
+	require.NoError(t, err)
+
+	var articleID int32
+	err = db.QueryRow("SELECT id FROM content.articles WHERE slug = $1", "shared-article").Scan(&articleID)
+	require.NoError(t, err)
+
+	// Both editors load the same version
+	editResp, err := suite.GET(t, fmt.Sprintf("/v1/articles/id/edit/%d", articleID))
+	require.NoError(t, err)
+	editResp.Body.Close()
+	require.Equal(t, http.StatusOK, editResp.StatusCode)
+	etag := editResp.Header.Get("ETag")
+	assert.Equal(t, `"1"`, etag)
+
+	jsonData, err := json.Marshal(map[string]string{
+		"title":   "First Editor Title",
+		"slug":    "shared-article",
+		"content": "Content saved by the first editor, at least 50 characters long.",
+	})
+	require.NoError(t, err)
+
+	url := fmt.Sprintf("%s/v1/articles/id/%d", suite.ServerAddr, articleID)
+	resp, err := NewRequestWithIfMatch(t, "PUT", url, suite.AuthToken, etag, jsonData)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"2"`, resp.Header.Get("ETag"))
+
+	// The second editor saves over the version they loaded
+	jsonData, err = json.Marshal(map[string]string{
+		"title":   "Second Editor Title",
+		"slug":    "shared-article",
+		"content": "Content saved by the second editor, at least 50 characters long.",
+	})
+	require.NoError(t, err)
+
+	resp, err = NewRequestWithIfMatch(t, "PUT", url, suite.AuthToken, etag, jsonData)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+	suite.AssertJSONError(t, resp, "the article was modified since it was loaded, reload it and try again")
+
+	var title string
+	err = db.QueryRow("SELECT title FROM content.articles WHERE id = $1", articleID).Scan(&title)
+	require.NoError(t, err)
+	assert.Equal(t, "First Editor Title", title)
+
+	// Publishing and deleting the stale version fail as well
+	publishResp, err := NewRequestWithIfMatch(t, "PATCH", url+"/publish", suite.AuthToken, etag, nil)
+	require.NoError(t, err)
+	publishResp.Body.Close()
+	assert.Equal(t, http.StatusPreconditionFailed, publishResp.StatusCode)
+
+	deleteResp, err := NewRequestWithIfMatch(t, "DELETE", url, suite.AuthToken, etag, nil)
+	require.NoError(t, err)
+	deleteResp.Body.Close()
+	assert.Equal(t, http.StatusPreconditionFailed, deleteResp.StatusCode)
+}
+
+func TestUpdateArticle_WithoutIfMatch_ReturnsPreconditionRequired(t *testing.T) {
+	suite := NewTestSuite(t)
+
+	err := queries.CreateArticle(context.Background(), sqlc.CreateArticleParams{
+		Title:   "Original Title",
+		Slug:    "original-slug",
+		Content: "Original content.",
+	})
+	require.NoError(t, err)
+
+	var articleID int32
+	err = db.QueryRow("SELECT id FROM content.articles WHERE slug = $1", "original-slug").Scan(&articleID)
+	require.NoError(t, err)
+
+	url := fmt.Sprintf("%s/v1/articles/id/%d/publish", suite.ServerAddr, articleID)
+	resp, err := NewRequestWithAuthentication(t, "PATCH", url, suite.AuthToken, nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusPreconditionRequired, resp.StatusCode)
+	suite.AssertJSONError(t, resp, "the version of the article is required, send its ETag in the If-Match header")
+}
+
 func TestCreateArticle_DuplicateSlug_ReturnsConflict(t *testing.T) {
 	suite := NewTestSuite(t)
 
@@ -31,7 +31,7 @@ func TestSoftDeleteArticle(t *testing.T) {
 	require.NoError(t, err)
 
 	url := fmt.Sprintf("/v1/articles/id/%d", articleID)
-	resp, err := NewRequestWithAuthentication(t, "DELETE", suite.ServerAddr+url, suite.AuthToken, nil)
+	resp, err := NewRequestWithIfMatch(t, "DELETE", suite.ServerAddr+url, suite.AuthToken, `"1"`, nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -46,7 +46,7 @@ func TestSoftDeleteNonExistentArticle(t *testing.T) {
 	// Try to delete an article that doesn't exist
 	nonExistentID := int32(99999)
 	url := fmt.Sprintf("/v1/articles/id/%d", nonExistentID)
-	resp, err := NewRequestWithAuthentication(t, "DELETE", suite.ServerAddr+url, suite.AuthToken, nil)
+	resp, err := NewRequestWithIfMatch(t, "DELETE", suite.ServerAddr+url, suite.AuthToken, `"1"`, nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -77,7 +77,7 @@ func TestHardDeleteArticle(t *testing.T) {
 
 	// First soft-delete the article (hard delete only works on soft-deleted articles)
 	softDeleteURL := fmt.Sprintf("/v1/articles/id/%d", articleID)
-	softResp, err := NewRequestWithAuthentication(t, "DELETE", suite.ServerAddr+softDeleteURL, suite.AuthToken, nil)
+	softResp, err := NewRequestWithIfMatch(t, "DELETE", suite.ServerAddr+softDeleteURL, suite.AuthToken, `"1"`, nil)
 	require.NoError(t, err)
 	defer softResp.Body.Close()
 	require.Equal(t, http.StatusOK, softResp.StatusCode)
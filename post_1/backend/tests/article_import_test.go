@@ -0,0 +1,171 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"personal_website/internal/infrastructure/markdown"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const importedContent = "This is an imported article content. It must be at least 50 characters long."
+
+func importArticles(t *testing.T, serverAddr, authToken string, files map[string][]byte) *http.Response {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, content := range files {
+		part, err := writer.CreateFormFile("files", name)
+		require.NoError(t, err)
+		_, err = part.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	req, err := http.NewRequest("POST", serverAddr+"/v1/articles/import", &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+authToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func decodeDataResponse(t *testing.T, resp *http.Response) map[string]any {
+	t.Helper()
+
+	var response map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	return response["data"].(map[string]any)
+}
+
+func TestImportArticles_CreatesAndUpdatesBySlug(t *testing.T) {
+	ts := NewTestSuite(t)
+
+	file := []byte("---\ntitle: Imported Article\ntags: [go]\ndate: 2024-05-01\n---\n\n" + importedContent + "\n")
+	resp := importArticles(t, ts.ServerAddr, ts.AuthToken, map[string][]byte{"imported-article.md": file})
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	data := decodeDataResponse(t, resp)
+	assert.Equal(t, float64(1), data["created"])
+	assert.Equal(t, float64(0), data["failed"])
+
+	resp, err := http.Get(ts.ServerAddr + "/v1/articles/slug/imported-article")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "an article without draft flag is published")
+
+	updated := []byte("---\ntitle: Imported Article Updated\nslug: imported-article\ndraft: true\n---\n" + importedContent + "\n")
+	resp = importArticles(t, ts.ServerAddr, ts.AuthToken, map[string][]byte{"renamed.md": updated})
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	data = decodeDataResponse(t, resp)
+	assert.Equal(t, float64(0), data["created"])
+	assert.Equal(t, float64(1), data["updated"])
+
+	var title string
+	var isPublished bool
+	err = db.QueryRow("SELECT title, is_published FROM content.articles WHERE slug = $1", "imported-article").Scan(&title, &isPublished)
+	require.NoError(t, err)
+	assert.Equal(t, "Imported Article Updated", title)
+	assert.False(t, isPublished)
+}
+
+func TestImportArticles_ReportsErrorsPerFile(t *testing.T) {
+	ts := NewTestSuite(t)
+
+	var archive bytes.Buffer
+	require.NoError(t, markdown.WriteArchive(&archive, markdown.FormatZip, []markdown.File{
+		{Name: "valid-archived.md", Data: []byte("---\ntitle: Archived Article\n---\n" + importedContent + "\n")},
+		{Name: "too-short.md", Data: []byte("---\ntitle: Short Article\n---\nshort\n")},
+	}))
+
+	resp := importArticles(t, ts.ServerAddr, ts.AuthToken, map[string][]byte{
+		"content.zip":        archive.Bytes(),
+		"no-front-matter.md": []byte(importedContent),
+		"notes.txt":          []byte("notes"),
+	})
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	data := decodeDataResponse(t, resp)
+	assert.Equal(t, float64(1), data["created"])
+	assert.Equal(t, float64(3), data["failed"])
+
+	failures := make(map[string]string)
+	for _, file := range data["files"].([]any) {
+		result := file.(map[string]any)
+		if result["status"] == "failed" {
+			failures[result["file"].(string)] = result["error"].(string)
+		}
+	}
+	assert.Contains(t, failures["too-short.md"], "content must be at least 50 characters")
+	assert.Equal(t, markdown.ErrMissingFrontMatter.Error(), failures["no-front-matter.md"])
+	assert.Equal(t, markdown.ErrUnsupportedFile.Error(), failures["notes.txt"])
+}
+
+func TestImportArticles_RequiresAuthentication(t *testing.T) {
+	ts := NewTestSuite(t)
+
+	resp := importArticles(t, ts.ServerAddr, "", map[string][]byte{"article.md": []byte("---\ntitle: Article\n---\n")})
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestExportArticles_RoundTrip(t *testing.T) {
+	ts := NewTestSuite(t)
+
+	file := []byte("---\ntitle: Exported Article\nslug: exported-article\ntags: [go, web]\ndraft: true\n---\n\n" + importedContent + "\n")
+	resp := importArticles(t, ts.ServerAddr, ts.AuthToken, map[string][]byte{"exported.md": file})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err := NewRequestWithAuthentication(t, "GET", ts.ServerAddr+"/v1/articles/export?format=zip", ts.AuthToken, nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/zip", resp.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	files, err := markdown.ReadArchive("export.zip", body, int64(len(body))*100)
+	require.NoError(t, err)
+
+	var exported []byte
+	for _, f := range files {
+		if f.Name == "exported-article.md" {
+			exported = f.Data
+		}
+	}
+	require.NotNil(t, exported)
+
+	article, err := markdown.Parse("exported-article.md", exported)
+	require.NoError(t, err)
+	assert.Equal(t, "Exported Article", article.Title)
+	assert.Equal(t, []string{"go", "web"}, article.Tags)
+	assert.Equal(t, importedContent, article.Content)
+	assert.False(t, article.IsPublished)
+}
+
+func TestExportArticles_InvalidFormat(t *testing.T) {
+	ts := NewTestSuite(t)
+
+	resp, err := NewRequestWithAuthentication(t, "GET", ts.ServerAddr+"/v1/articles/export?format=rar", ts.AuthToken, nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type previewLinkResponse struct {
+	Data struct {
+		ID     int32  `json:"id"`
+		Token  string `json:"token"`
+		Active bool   `json:"active"`
+	} `json:"data"`
+}
+
+func TestArticlePreview_SharesTheDraft(t *testing.T) {
+	suite := NewTestSuite(t)
+
+	resp, err := suite.POST(t, "/v1/articles", map[string]string{
+		"title":   "Draft Article",
+		"slug":    "draft-article",
+		"content": "The content of a draft shared with a reviewer, long enough.",
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var articleID int32
+	err = db.QueryRow("SELECT id FROM content.articles WHERE slug = $1", "draft-article").Scan(&articleID)
+	require.NoError(t, err)
+
+	resp, err = suite.POST(t, fmt.Sprintf("/v1/articles/id/%d/previews", articleID), nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var link previewLinkResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&link))
+	require.NotEmpty(t, link.Data.Token)
+	assert.True(t, link.Data.Active)
+
+	// The draft is not public, but the link gives access to it
+	resp = getWithoutRedirect(t, suite.ServerAddr+"/v1/articles/slug/draft-article")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	previewURL := suite.ServerAddr + "/v1/articles/preview/" + link.Data.Token
+	resp = getWithoutRedirect(t, previewURL)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "noindex", resp.Header.Get("X-Robots-Tag"))
+	assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+
+	var article map[string]map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&article))
+	assert.Equal(t, "draft-article", article["data"]["slug"])
+
+	resp = getWithoutRedirect(t, suite.ServerAddr+"/v1/articles/preview/"+link.Data.Token+"x")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// The link is listed, then revoked
+	listResp, err := suite.GET(t, fmt.Sprintf("/v1/articles/id/%d/previews", articleID))
+	require.NoError(t, err)
+	defer listResp.Body.Close()
+	require.Equal(t, http.StatusOK, listResp.StatusCode)
+
+	var links map[string][]map[string]any
+	require.NoError(t, json.NewDecoder(listResp.Body).Decode(&links))
+	require.Len(t, links["data"], 1)
+	assert.Equal(t, link.Data.Token, links["data"][0]["token"])
+
+	revokeResp, err := suite.DELETE(t, fmt.Sprintf("/v1/articles/id/%d/previews/%d", articleID, link.Data.ID))
+	require.NoError(t, err)
+	revokeResp.Body.Close()
+	require.Equal(t, http.StatusNoContent, revokeResp.StatusCode)
+
+	resp = getWithoutRedirect(t, previewURL)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, "noindex", resp.Header.Get("X-Robots-Tag"))
+}
+
+func TestArticlePreview_RequiresAuthentication(t *testing.T) {
+	suite := NewUnauthenticatedTestSuite(t)
+
+	resp, err := http.Post(suite.ServerAddr+"/v1/articles/id/1/previews", "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -31,7 +31,7 @@ func TestGetArticleBySlug(t *testing.T) {
 	require.NoError(t, err)
 
 	// Publish it
-	err = queries.PublishArticle(ctx, articleID)
+	_, err = queries.PublishArticle(ctx, sqlc.PublishArticleParams{ID: articleID})
 	require.NoError(t, err)
 
 	// Test getting the article
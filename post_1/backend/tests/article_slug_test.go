@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// renameArticle changes the slug of the article through the API.
+func renameArticle(t *testing.T, suite *TestSuite, articleID int32, slug string) *http.Response {
+	t.Helper()
+
+	jsonData, err := json.Marshal(map[string]string{
+		"title":   "Renamed Article",
+		"slug":    slug,
+		"content": "The content of an article whose slug changed, long enough.",
+	})
+	require.NoError(t, err)
+
+	url := fmt.Sprintf("%s/v1/articles/id/%d", suite.ServerAddr, articleID)
+	resp, err := NewRequestWithIfMatch(t, "PUT", url, suite.AuthToken, "*", jsonData)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// getWithoutRedirect gets the route without following the redirects.
+func getWithoutRedirect(t *testing.T, url string) *http.Response {
+	t.Helper()
+
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestGetArticleBySlug_RetiredSlugRedirects(t *testing.T) {
+	suite := NewTestSuite(t)
+	articleID := publishArticleThroughAPI(t, suite, "first-slug")
+
+	resp := renameArticle(t, suite, articleID, "second-slug")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = renameArticle(t, suite, articleID, "third-slug")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Every retired slug points to the current one
+	for _, slug := range []string{"first-slug", "second-slug"} {
+		resp = getWithoutRedirect(t, suite.ServerAddr+"/v1/articles/slug/"+slug)
+		require.Equal(t, http.StatusMovedPermanently, resp.StatusCode)
+		assert.Equal(t, "/v1/articles/slug/third-slug", resp.Header.Get("Location"))
+
+		var response map[string]map[string]string
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		assert.Equal(t, "third-slug", response["data"]["slug"])
+	}
+
+	resp = getWithoutRedirect(t, suite.ServerAddr+"/v1/articles/slug/third-slug")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The article takes back its first slug
+	resp = renameArticle(t, suite, articleID, "first-slug")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = getWithoutRedirect(t, suite.ServerAddr+"/v1/articles/slug/first-slug")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = getWithoutRedirect(t, suite.ServerAddr+"/v1/articles/slug/third-slug")
+	assert.Equal(t, http.StatusMovedPermanently, resp.StatusCode)
+	assert.Equal(t, "/v1/articles/slug/first-slug", resp.Header.Get("Location"))
+}
+
+func TestGetArticleBySlug_RetiredSlugOfUnpublishedArticle(t *testing.T) {
+	suite := NewTestSuite(t)
+	articleID := publishArticleThroughAPI(t, suite, "old-draft-slug")
+
+	resp := renameArticle(t, suite, articleID, "new-draft-slug")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	url := fmt.Sprintf("%s/v1/articles/id/%d/unpublish", suite.ServerAddr, articleID)
+	unpublishResp, err := NewRequestWithIfMatch(t, "PATCH", url, suite.AuthToken, "*", nil)
+	require.NoError(t, err)
+	unpublishResp.Body.Close()
+	require.Equal(t, http.StatusOK, unpublishResp.StatusCode)
+
+	resp = getWithoutRedirect(t, suite.ServerAddr+"/v1/articles/slug/old-draft-slug")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestCreateArticle_RetiredSlugIsRefused(t *testing.T) {
+	suite := NewTestSuite(t)
+	articleID := publishArticleThroughAPI(t, suite, "taken-slug")
+
+	resp := renameArticle(t, suite, articleID, "current-slug")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	createResp, err := suite.POST(t, "/v1/articles", map[string]string{
+		"title":   "Another Article",
+		"slug":    "taken-slug",
+		"content": "The content of another article trying to take a retired slug.",
+	})
+	require.NoError(t, err)
+	createResp.Body.Close()
+	assert.Equal(t, http.StatusConflict, createResp.StatusCode)
+
+	// Nor can another article be renamed to it
+	otherID := publishArticleThroughAPI(t, suite, "other-slug")
+	resp = renameArticle(t, suite, otherID, "taken-slug")
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
@@ -20,7 +20,7 @@ func TestContactHandler_Success(t *testing.T) {
 	mockSender.Calls = nil
 	mockSender.mu.Unlock()
 
-	contactData := ContactData()
+	contactData := SolvedContactData(t, suite.ServerAddr)
 
 	jsonData, err := json.Marshal(contactData)
 	require.NoError(t, err)
@@ -291,3 +291,87 @@ func TestContactHandler_WrongHTTPMethod_ReturnsMethodNotAllowed(t *testing.T) {
 
 	assert.Len(t, calls, 0, "No email should have been sent for method not allowed")
 }
+
+func TestContactHandler_HoneypotFilled_DiscardsSilently(t *testing.T) {
+	suite := NewUnauthenticatedTestSuite(t)
+
+	// Reset mock email sender calls
+	mockSender := GetMockEmailSender()
+	mockSender.mu.Lock()
+	mockSender.Calls = nil
+	mockSender.mu.Unlock()
+
+	contactData := SolvedContactData(t, suite.ServerAddr)
+	contactData["website"] = "https://spam.example.com"
+
+	jsonData, err := json.Marshal(contactData)
+	require.NoError(t, err)
+
+	resp, err := http.Post(suite.ServerAddr+"/v1/contact", "application/json", bytes.NewBuffer(jsonData))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Bots get the same answer as humans
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	mockSender.mu.Lock()
+	calls := mockSender.Calls
+	mockSender.mu.Unlock()
+
+	assert.Len(t, calls, 0, "No email should have been sent for spam")
+}
+
+func TestContactHandler_ReplayedChallenge_DiscardsSilently(t *testing.T) {
+	suite := NewUnauthenticatedTestSuite(t)
+
+	// Reset mock email sender calls
+	mockSender := GetMockEmailSender()
+	mockSender.mu.Lock()
+	mockSender.Calls = nil
+	mockSender.mu.Unlock()
+
+	jsonData, err := json.Marshal(SolvedContactData(t, suite.ServerAddr))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		resp, err := http.Post(suite.ServerAddr+"/v1/contact", "application/json", bytes.NewBuffer(jsonData))
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	mockSender.mu.Lock()
+	calls := mockSender.Calls
+	mockSender.mu.Unlock()
+
+	assert.Len(t, calls, 1, "A challenge must only be usable once")
+}
+
+func TestContactHandler_InvalidChallenge_ReturnsUnprocessableEntity(t *testing.T) {
+	suite := NewUnauthenticatedTestSuite(t)
+
+	// Reset mock email sender calls
+	mockSender := GetMockEmailSender()
+	mockSender.mu.Lock()
+	mockSender.Calls = nil
+	mockSender.mu.Unlock()
+
+	contactData := SolvedContactData(t, suite.ServerAddr)
+	contactData["challenge"] = "v1.1700000000.4.forged.signature"
+
+	jsonData, err := json.Marshal(contactData)
+	require.NoError(t, err)
+
+	resp, err := http.Post(suite.ServerAddr+"/v1/contact", "application/json", bytes.NewBuffer(jsonData))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	suite.AssertJSONError(t, resp, "the contact challenge is missing, invalid or expired")
+
+	mockSender.mu.Lock()
+	calls := mockSender.Calls
+	mockSender.mu.Unlock()
+
+	assert.Len(t, calls, 0, "No email should have been sent for an invalid challenge")
+}
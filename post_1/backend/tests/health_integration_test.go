@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"personal_website/pkg/health"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type readinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+func getReadiness(t *testing.T, ts *TestSuite) (int, readinessResponse) {
+	t.Helper()
+
+	resp, err := http.Get(ts.ServerAddr + "/health/ready")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body readinessResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+	return resp.StatusCode, body
+}
+
+func TestLivenessProbe(t *testing.T) {
+	ts := NewUnauthenticatedTestSuite(t)
+
+	resp, err := http.Get(ts.ServerAddr + "/health/live")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestHealthcheck_ListsFeatureFlags(t *testing.T) {
+	ts := NewUnauthenticatedTestSuite(t)
+
+	resp, err := http.Get(ts.ServerAddr + "/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body struct {
+		Features []string `json:"features"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, []string{"test-flag"}, body.Features)
+}
+
+func TestReadinessProbe_AllDependenciesUp(t *testing.T) {
+	ts := NewUnauthenticatedTestSuite(t)
+
+	status, body := getReadiness(t, ts)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "up", body.Status)
+	assert.Equal(t, map[string]string{"postgres": "up", "valkey": "up", "minio": "up"}, body.Checks)
+}
+
+func TestReadinessProbe_NonCriticalDependencyDown(t *testing.T) {
+	ts := NewUnauthenticatedTestSuite(t)
+
+	testMockResumeService.ShouldFailCheck = true
+	testMockResumeService.CheckConnError = errors.New("bucket not found")
+
+	status, body := getReadiness(t, ts)
+
+	assert.Equal(t, http.StatusOK, status, "a degraded application still serves traffic")
+	assert.Equal(t, "degraded", body.Status)
+	assert.Equal(t, "down", body.Checks["minio"])
+}
+
+func TestReadinessProbe_CriticalDependencyDown(t *testing.T) {
+	ts := NewUnauthenticatedTestSuite(t)
+
+	testHealthChecks.Register(health.Check{
+		Name:     "broken",
+		Critical: true,
+		Checker:  func(ctx context.Context) error { return errors.New("connection refused") },
+	})
+
+	status, body := getReadiness(t, ts)
+
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "down", body.Status)
+	assert.Equal(t, "down", body.Checks["broken"])
+}
@@ -3,16 +3,22 @@ package tests
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
+	"fmt"
 	"io"
+	"math/bits"
 	"net/http"
 	"net/smtp"
 	"personal_website/config"
 	"personal_website/internal/app/core/domain"
 	"personal_website/internal/app/core/ports"
 	"personal_website/internal/infrastructure/adapters/repository/postgres/sqlc"
+	"personal_website/internal/infrastructure/markdown"
+	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/awnumar/memguard"
 	"github.com/stretchr/testify/assert"
@@ -33,6 +39,7 @@ func createTestUser(t *testing.T, queries *sqlc.Queries, datastore ports.Datasto
 		Name:         user.Name,
 		Email:        user.Email,
 		PasswordHash: user.Password.Hash(),
+		Language:     "en",
 	})
 	require.NoError(t, err)
 
@@ -40,18 +47,15 @@ func createTestUser(t *testing.T, queries *sqlc.Queries, datastore ports.Datasto
 	err = queries.ActivateUser(ctx, userID)
 	require.NoError(t, err)
 
-	// Grant articles:read and articles:write permissions to the user
-	err = queries.AddPermissionForUser(ctx, sqlc.AddPermissionForUserParams{
-		UserID: int64(userID),
-		Code:   "articles:read",
-	})
-	require.NoError(t, err)
-
-	err = queries.AddPermissionForUser(ctx, sqlc.AddPermissionForUserParams{
-		UserID: int64(userID),
-		Code:   "articles:write",
-	})
-	require.NoError(t, err)
+	// Grant the article, newsletter, resume and analytics permissions to the user
+	permissions := domain.Permissions{"articles:read", "articles:write", "newsletter:read", "resume:write", "analytics:read"}
+	for _, code := range permissions {
+		err = queries.AddPermissionForUser(ctx, sqlc.AddPermissionForUserParams{
+			UserID: int64(userID),
+			Code:   code,
+		})
+		require.NoError(t, err)
+	}
 
 	// Create session in valkey instead of token in database
 	token := domain.GenerateToken(int(userID), domain.ScopeAuthentication)
@@ -60,7 +64,7 @@ func createTestUser(t *testing.T, queries *sqlc.Queries, datastore ports.Datasto
 	session := &domain.Session{
 		UserID:      int(userID),
 		Email:       user.Email,
-		Permissions: domain.Permissions{"articles:read", "articles:write"},
+		Permissions: permissions,
 		Activated:   true,
 	}
 
@@ -92,23 +96,71 @@ func NewTestConfig(dsnParts map[string]string, port int) *config.Config {
 			UseSSL:    false,
 		},
 		SMTP: config.SMTPConfig{
-			Host:      memguard.NewBufferFromBytes([]byte("localhost")),
-			Port:      memguard.NewBufferFromBytes([]byte("587")),
-			Username:  memguard.NewBufferFromBytes([]byte("test@example.com")),
-			Password:  memguard.NewBufferFromBytes([]byte("testpass")),
-			Recipient: memguard.NewBufferFromBytes([]byte("test@example.com")),
+			Host:          memguard.NewBufferFromBytes([]byte("localhost")),
+			Port:          memguard.NewBufferFromBytes([]byte("587")),
+			Username:      memguard.NewBufferFromBytes([]byte("test@example.com")),
+			Password:      memguard.NewBufferFromBytes([]byte("testpass")),
+			Recipient:     memguard.NewBufferFromBytes([]byte("test@example.com")),
+			DefaultLocale: "en",
+		},
+		Contact: config.ContactConfig{
+			ChallengeSecret:     memguard.NewBufferFromBytes([]byte("test-contact-secret")),
+			ChallengeDifficulty: 4, // Cheap proof-of-work for tests
+			ChallengeTTL:        time.Hour,
+			MinFillTime:         0,
+			MaxLinks:            2,
+			BlockedKeywords:     []string{"casino"},
+			EmailQuota:          100, // Valkey is shared between tests
+			IPQuota:             100,
+			QuotaWindow:         time.Hour,
+		},
+		Newsletter: config.NewsletterConfig{
+			ConfirmationURL:   "https://example.com/newsletter/confirm",
+			UnsubscribeURL:    "https://example.com/newsletter/unsubscribe",
+			ArticleURL:        "https://example.com/blog",
+			UnsubscribeSecret: memguard.NewBufferFromBytes([]byte("test-newsletter-secret")),
+			TokenTTL:          time.Hour,
+			ResendCooldown:    0, // Valkey is shared between tests
+			BatchSize:         10,
+			BatchInterval:     0,
+		},
+		Preview: config.PreviewConfig{
+			Secret: memguard.NewBufferFromBytes([]byte("test-preview-secret")),
+			TTL:    time.Hour,
+		},
+		Analytics: config.AnalyticsConfig{
+			FlushInterval: 100 * time.Millisecond,
 		},
 		App: config.AppConfig{
 			Environment: "test",
 			Version:     "test",
+			Features:    []string{"test-flag"},
 			Port:        port,
 			Cors: config.CORSConfig{
 				TrustedOrigins: []string{"http://localhost:3000", "https://example.com"},
 			},
+			// The tests act as the reverse proxy to pick the client IP
+			TrustedProxies: []string{"127.0.0.1", "::1"},
+			Cache: config.CacheConfig{
+				MaxAge:               time.Minute,
+				StaleWhileRevalidate: 5 * time.Minute,
+				TTL:                  10 * time.Minute,
+				Articles:             true,
+				ArticlesTTL:          10 * time.Minute,
+			},
 			Limiter: config.LimiterConfig{
 				Rps:     5,  // Low limit for easy testing
 				Burst:   10, // Low burst for easy testing
 				Enabled: true,
+				Backend: "memory", // Valkey is shared between test servers
+				Policies: map[string]config.RateLimitPolicy{
+					"login":        {Requests: 100, Period: time.Second, Burst: 100},
+					"registration": {Requests: 100, Period: time.Second, Burst: 100},
+					"activation":   {Requests: 100, Period: time.Second, Burst: 100},
+					"contact":      {Requests: 100, Period: time.Second, Burst: 100},
+					"newsletter":   {Requests: 100, Period: time.Second, Burst: 100},
+					"write":        {Requests: 100, Period: time.Second, Burst: 100},
+				},
 			},
 		},
 	}
@@ -165,6 +217,46 @@ func ContactData() map[string]string {
 	}
 }
 
+// SolvedContactData creates test contact form data with a solved challenge
+// fetched from the server
+func SolvedContactData(t *testing.T, serverAddr string) map[string]string {
+	resp, err := http.Get(serverAddr + "/v1/contact/challenge")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var challengeResp struct {
+		Data struct {
+			Challenge  string `json:"challenge"`
+			Difficulty int    `json:"difficulty"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&challengeResp))
+
+	data := ContactData()
+	data["challenge"] = challengeResp.Data.Challenge
+	data["nonce"] = solveChallenge(challengeResp.Data.Challenge, challengeResp.Data.Difficulty)
+	return data
+}
+
+func solveChallenge(challenge string, difficulty int) string {
+	for i := 0; ; i++ {
+		nonce := strconv.Itoa(i)
+		hash := sha256.Sum256([]byte(challenge + ":" + nonce))
+
+		zeros := 0
+		for _, b := range hash {
+			zeros += bits.LeadingZeros8(b)
+			if b != 0 {
+				break
+			}
+		}
+		if zeros >= difficulty {
+			return nonce
+		}
+	}
+}
+
 // Global mock email sender instance
 var testMockEmailSender *MockEmailSender
 
@@ -173,7 +265,8 @@ func GetMockEmailSender() *MockEmailSender {
 	return testMockEmailSender
 }
 
-// MockResumeService implements ports.ResumeService for testing
+// MockResumeService implements ports.ResumeService for testing, keeping the
+// versions and their content in memory
 type MockResumeService struct {
 	mu              sync.Mutex
 	GetResumeCalls  []string
@@ -183,24 +276,121 @@ type MockResumeService struct {
 	GetResumeData   []byte
 	GetResumeError  error
 	CheckConnError  error
+	Versions        []domain.ResumeVersion
+	Objects         map[string][]byte
 }
 
 func NewMockResumeService() *MockResumeService {
 	return &MockResumeService{
 		GetResumeData: []byte("mock pdf data"),
+		Versions:      defaultResumeVersions(),
+		Objects:       make(map[string][]byte),
 	}
 }
 
-func (m *MockResumeService) GetResume(ctx context.Context, objectName string) ([]byte, error) {
+// MockResumeLastModified is the modification time of every mock resume object
+var MockResumeLastModified = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+type nopReadSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopReadSeekCloser) Close() error { return nil }
+
+// defaultResumeVersions mirrors the version seeded by the resume migration
+func defaultResumeVersions() []domain.ResumeVersion {
+	return []domain.ResumeVersion{{
+		ID:          1,
+		ObjectName:  "resume_without_personal_data.pdf",
+		FileName:    "jordan_delbar_resume.pdf",
+		ContentType: "application/pdf",
+		IsCurrent:   true,
+	}}
+}
+
+func (m *MockResumeService) OpenCurrentResume(ctx context.Context) (domain.ResumeFile, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.GetResumeCalls = append(m.GetResumeCalls, objectName)
+
+	var current *domain.ResumeVersion
+	for i := range m.Versions {
+		if m.Versions[i].IsCurrent {
+			current = &m.Versions[i]
+		}
+	}
+	if current == nil {
+		return domain.ResumeFile{}, domain.ErrResumeNotFound
+	}
+
+	m.GetResumeCalls = append(m.GetResumeCalls, current.ObjectName)
 
 	if m.ShouldFailGet {
-		return nil, m.GetResumeError
+		return domain.ResumeFile{}, m.GetResumeError
+	}
+
+	data, ok := m.Objects[current.ObjectName]
+	if !ok {
+		data = m.GetResumeData
+	}
+
+	return domain.ResumeFile{
+		Version:      *current,
+		Content:      nopReadSeekCloser{bytes.NewReader(data)},
+		Size:         int64(len(data)),
+		ETag:         fmt.Sprintf("\"%x\"", sha256.Sum256(data)),
+		LastModified: MockResumeLastModified,
+	}, nil
+}
+
+func (m *MockResumeService) UploadResume(ctx context.Context, upload domain.ResumeUpload) (domain.ResumeVersion, error) {
+	data, err := io.ReadAll(upload.Content)
+	if err != nil {
+		return domain.ResumeVersion{}, err
+	}
+
+	m.mu.Lock()
+	version := domain.ResumeVersion{
+		ID:          int32(len(m.Versions) + 1),
+		ObjectName:  fmt.Sprintf("resumes/%d.pdf", len(m.Versions)+1),
+		FileName:    upload.FileName,
+		ContentType: upload.ContentType,
+		Size:        int64(len(data)),
+		UploadedBy:  upload.UploadedBy,
+		CreatedAt:   time.Now(),
+	}
+	m.Objects[version.ObjectName] = data
+	m.Versions = append(m.Versions, version)
+	m.mu.Unlock()
+
+	if upload.MakeCurrent {
+		return m.SetCurrentVersion(ctx, version.ID)
+	}
+	return version, nil
+}
+
+func (m *MockResumeService) ListVersions(ctx context.Context) ([]domain.ResumeVersion, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	versions := make([]domain.ResumeVersion, len(m.Versions))
+	for i, version := range m.Versions {
+		versions[len(m.Versions)-1-i] = version
+	}
+	return versions, nil
+}
+
+func (m *MockResumeService) SetCurrentVersion(ctx context.Context, id int32) (domain.ResumeVersion, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if id < 1 || int(id) > len(m.Versions) {
+		return domain.ResumeVersion{}, domain.ErrResumeVersionNotFound
 	}
 
-	return m.GetResumeData, nil
+	for i := range m.Versions {
+		m.Versions[i].IsCurrent = m.Versions[i].ID == id
+	}
+	return m.Versions[id-1], nil
 }
 
 func (m *MockResumeService) CheckConnection(ctx context.Context) error {
@@ -224,6 +414,8 @@ func (m *MockResumeService) Reset() {
 	m.ShouldFailCheck = false
 	m.GetResumeError = nil
 	m.CheckConnError = nil
+	m.Versions = defaultResumeVersions()
+	m.Objects = make(map[string][]byte)
 }
 
 // Global mock resume service instance
@@ -234,6 +426,47 @@ func GetMockResumeService() *MockResumeService {
 	return testMockResumeService
 }
 
+// MockContentSource is the content repository of the sync, its snapshot is
+// set by the tests
+type MockContentSource struct {
+	mu       sync.Mutex
+	snapshot domain.ContentSnapshot
+}
+
+func (m *MockContentSource) Snapshot(ctx context.Context) (domain.ContentSnapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.snapshot, nil
+}
+
+// SetFiles replaces the Markdown files of the repository at the commit
+func (m *MockContentSource) SetFiles(t *testing.T, commit string, files map[string]string) {
+	t.Helper()
+
+	snapshot := domain.ContentSnapshot{Commit: commit}
+	for name, data := range files {
+		article, err := markdown.Parse(name, []byte(data))
+		snapshot.Files = append(snapshot.Files, domain.SourceFile{
+			Path:    name,
+			Hash:    fmt.Sprintf("%x", sha256.Sum256([]byte(data))),
+			Article: article,
+			Err:     err,
+		})
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.snapshot = snapshot
+}
+
+// Global mock content source instance
+var testMockContentSource *MockContentSource
+
+// GetMockContentSource returns the global mock content source instance
+func GetMockContentSource() *MockContentSource {
+	return testMockContentSource
+}
+
 // POST makes authenticated POST request
 func (ts *TestSuite) POST(t *testing.T, path string, data interface{}) (*http.Response, error) {
 	jsonData, err := json.Marshal(data)
@@ -254,6 +487,12 @@ func (ts *TestSuite) DELETE(t *testing.T, path string) (*http.Response, error) {
 }
 
 func NewRequestWithAuthentication(t *testing.T, method string, route string, authToken string, payload []byte) (*http.Response, error) {
+	return NewRequestWithIfMatch(t, method, route, authToken, "", payload)
+}
+
+// NewRequestWithIfMatch makes an authenticated request changing an article,
+// with the ETag of the version it is based on unless it is empty.
+func NewRequestWithIfMatch(t *testing.T, method string, route string, authToken string, etag string, payload []byte) (*http.Response, error) {
 	var body io.Reader
 	if payload != nil {
 		body = bytes.NewBuffer(payload)
@@ -266,6 +505,9 @@ func NewRequestWithAuthentication(t *testing.T, method string, route string, aut
 		req.Header.Set("Content-Type", "application/json")
 	}
 	req.Header.Set("Authorization", "Bearer "+authToken)
+	if etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
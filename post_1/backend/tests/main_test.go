@@ -15,6 +15,7 @@ import (
 	postgres_adapter "personal_website/internal/infrastructure/adapters/repository/postgres"
 	"personal_website/internal/infrastructure/adapters/repository/postgres/sqlc"
 	valkey_adapter "personal_website/internal/infrastructure/adapters/repository/valkey"
+	"personal_website/pkg/health"
 	"personal_website/pkg/telemetry"
 	"testing"
 	"time"
@@ -39,6 +40,9 @@ var (
 	datastore       ports.Datastore
 	queries         *sqlc.Queries
 	testCfg         *config.Config
+
+	testHealthChecks *health.Registry
+	testTelemetry    *telemetry.Telemetry
 )
 
 func TestMain(m *testing.M) {
@@ -114,7 +118,7 @@ func TestMain(m *testing.M) {
 		panic(err)
 	}
 
-	datastore = datastore_adapter.NewDatastore(pgDatabase, vkDatabase)
+	datastore = datastore_adapter.NewDatastore(pgDatabase, vkDatabase, &testCfg.App.Cache)
 
 	queries = sqlc.New(db)
 
@@ -137,6 +141,10 @@ func setupTestDB(t *testing.T) {
 func cleanupDB(t *testing.T) {
 	err := runMigrationsDown(db)
 	require.NoError(t, err)
+
+	// The cached articles and responses would outlive the articles
+	err = vkDatabase.InvalidateArticleCaches(context.Background())
+	require.NoError(t, err)
 }
 
 func startTestServer(t *testing.T) string {
@@ -144,21 +152,30 @@ func startTestServer(t *testing.T) string {
 
 	testMockEmailSender = &MockEmailSender{}
 	testMockResumeService = NewMockResumeService()
+	testMockContentSource = &MockContentSource{}
 
 	// Create a mock telemetry for tests
-	testTelemetry, err := telemetry.NewTelemetry(logger)
+	var err error
+	testTelemetry, err = telemetry.NewTelemetry(logger, telemetry.TracingOptions{})
 	if err != nil {
 		// If telemetry setup fails, create a nil telemetry for tests
 		testTelemetry = nil
 	}
 
+	testHealthChecks = health.NewRegistry(0)
+	testHealthChecks.Register(health.Check{Name: "postgres", Checker: pgDatabase.Ping, Critical: true})
+	testHealthChecks.Register(health.Check{Name: "valkey", Checker: vkDatabase.Ping, Critical: true})
+	testHealthChecks.Register(health.Check{Name: "minio", Checker: testMockResumeService.CheckConnection})
+
 	srv, err := app.NewServer(app.ServerDeps{
 		Logger:        logger,
 		Config:        testCfg,
 		Datastore:     datastore,
 		EmailSender:   testMockEmailSender,
 		ResumeService: testMockResumeService,
+		ContentSource: testMockContentSource,
 		Telemetry:     testTelemetry,
+		HealthChecks:  testHealthChecks,
 	})
 	require.NoError(t, err)
 
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// labelValues returns the distinct values of label in the series of the
+// metric family name.
+func labelValues(t *testing.T, name string, label string) map[string]bool {
+	t.Helper()
+
+	families, err := testTelemetry.Gatherer().Gather()
+	require.NoError(t, err)
+
+	values := make(map[string]bool)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, pair := range m.GetLabel() {
+				if pair.GetName() == label {
+					values[pair.GetValue()] = true
+				}
+			}
+		}
+	}
+	return values
+}
+
+func TestHTTPMetrics_RouteLabelsStayBounded(t *testing.T) {
+	ts := NewUnauthenticatedTestSuite(t)
+	require.NotNil(t, testTelemetry)
+	client := &http.Client{}
+
+	get := func(path string, i int) {
+		req, err := http.NewRequest(http.MethodGet, ts.ServerAddr+path, nil)
+		require.NoError(t, err)
+		// A distinct client IP per request to stay below the rate limit
+		req.Header.Set("X-Forwarded-For", fmt.Sprintf("198.51.100.%d", i))
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	for i := 0; i < 50; i++ {
+		get(fmt.Sprintf("/v1/articles/slug/missing-article-%d", i), i)
+		get(fmt.Sprintf("/unknown/path-%d", i), i)
+	}
+
+	routes := labelValues(t, "http_requests_total", "route")
+	assert.True(t, routes["/v1/articles/slug/{slug}"], "requests are labelled with the route pattern")
+	assert.True(t, routes["unmatched"], "unknown paths share a single label")
+	assert.LessOrEqual(t, len(routes), 5, "series do not grow with the requested slugs: %v", routes)
+
+	codes := labelValues(t, "http_domain_errors_total", "code")
+	assert.True(t, codes["article_not_found"])
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	postgres_adapter "personal_website/internal/infrastructure/adapters/repository/postgres"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type migrator interface {
+	Migrate(ctx context.Context) (uint, error)
+	Rollback(ctx context.Context, steps int) (uint, error)
+}
+
+func TestMigrate_ConcurrentReplicas(t *testing.T) {
+	t.Cleanup(func() { cleanupDB(t) })
+	m := pgDatabase.(migrator)
+	latest := postgres_adapter.LatestSchemaVersion()
+
+	_, err := pgDatabase.SchemaVersion(context.Background())
+	assert.ErrorIs(t, err, postgres_adapter.ErrSchemaBehind)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = m.Migrate(context.Background())
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	version, err := pgDatabase.SchemaVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, latest, version)
+
+	version, err = m.Rollback(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Less(t, version, latest)
+
+	version, err = m.Migrate(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, latest, version)
+
+	// Migrating does not close the pool of the application
+	require.NoError(t, pgDatabase.Ping(context.Background()))
+	_, err = pgDatabase.ArticleRepo().ListArticles(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestMigrate_RefusesNewerSchema(t *testing.T) {
+	setupTestDB(t)
+	t.Cleanup(func() {
+		_, err := db.Exec("UPDATE schema_migrations SET version = $1", postgres_adapter.LatestSchemaVersion())
+		require.NoError(t, err)
+		cleanupDB(t)
+	})
+
+	_, err := db.Exec("UPDATE schema_migrations SET version = $1", postgres_adapter.LatestSchemaVersion()+1)
+	require.NoError(t, err)
+
+	_, err = pgDatabase.SchemaVersion(context.Background())
+	assert.ErrorIs(t, err, postgres_adapter.ErrSchemaAhead)
+
+	_, err = pgDatabase.(migrator).Migrate(context.Background())
+	assert.ErrorIs(t, err, postgres_adapter.ErrSchemaAhead)
+}
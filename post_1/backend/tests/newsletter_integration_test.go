@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"personal_website/internal/infrastructure/adapters/repository/postgres/sqlc"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var newsletterTokenPattern = regexp.MustCompile(`token=([A-Za-z0-9._-]+)`)
+
+func sentEmails(t *testing.T) []string {
+	t.Helper()
+
+	mockSender := GetMockEmailSender()
+	mockSender.mu.Lock()
+	defer mockSender.mu.Unlock()
+
+	emails := make([]string, 0, len(mockSender.Calls))
+	for _, call := range mockSender.Calls {
+		emails = append(emails, string(call["msg"].([]byte)))
+	}
+	return emails
+}
+
+func postNewsletter(t *testing.T, serverAddr, path string, data map[string]string) *http.Response {
+	t.Helper()
+
+	jsonData, err := json.Marshal(data)
+	require.NoError(t, err)
+
+	resp, err := http.Post(serverAddr+"/v1/newsletter/"+path, "application/json", bytes.NewBuffer(jsonData))
+	require.NoError(t, err)
+	return resp
+}
+
+func subscribeAndConfirm(t *testing.T, serverAddr, email string) {
+	t.Helper()
+
+	resp := postNewsletter(t, serverAddr, "subscribe", map[string]string{"email": email})
+	resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	emails := sentEmails(t)
+	require.NotEmpty(t, emails)
+	match := newsletterTokenPattern.FindStringSubmatch(emails[len(emails)-1])
+	require.NotNil(t, match, "confirmation email should contain a token")
+
+	resp = postNewsletter(t, serverAddr, "confirm", map[string]string{"token": match[1]})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestNewsletter_SubscribeAndConfirm(t *testing.T) {
+	suite := NewTestSuite(t)
+
+	subscribeAndConfirm(t, suite.ServerAddr, "reader@example.com")
+
+	resp, err := NewRequestWithAuthentication(t, "GET", suite.ServerAddr+"/v1/newsletter/subscribers", suite.AuthToken, nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var listResp struct {
+		Data []struct {
+			Email     string `json:"email"`
+			Confirmed bool   `json:"confirmed"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&listResp))
+	require.Len(t, listResp.Data, 1)
+	assert.Equal(t, "reader@example.com", listResp.Data[0].Email)
+	assert.True(t, listResp.Data[0].Confirmed)
+}
+
+func TestNewsletter_ConfirmWithInvalidToken_ReturnsUnprocessableEntity(t *testing.T) {
+	suite := NewUnauthenticatedTestSuite(t)
+
+	resp := postNewsletter(t, suite.ServerAddr, "confirm", map[string]string{"token": "INVALIDTOKEN"})
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestNewsletter_ListSubscribers_RequiresAuthentication(t *testing.T) {
+	suite := NewUnauthenticatedTestSuite(t)
+
+	resp, err := http.Get(suite.ServerAddr + "/v1/newsletter/subscribers")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestNewsletter_PublishArticle_NotifiesSubscribersOnce(t *testing.T) {
+	suite := NewTestSuite(t)
+
+	subscribeAndConfirm(t, suite.ServerAddr, "reader@example.com")
+
+	err := queries.CreateArticle(context.Background(), sqlc.CreateArticleParams{
+		Title:   "Newsletter Article",
+		Slug:    "newsletter-article",
+		Content: "Content announced to the subscribers.",
+	})
+	require.NoError(t, err)
+
+	var articleID int32
+	err = db.QueryRow("SELECT id FROM content.articles WHERE slug = $1", "newsletter-article").Scan(&articleID)
+	require.NoError(t, err)
+
+	publishURL := fmt.Sprintf("%s/v1/articles/id/%d/publish", suite.ServerAddr, articleID)
+	resp, err := NewRequestWithIfMatch(t, "PATCH", publishURL, suite.AuthToken, `"1"`, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get("ETag")
+
+	var notification string
+	require.Eventually(t, func() bool {
+		for _, email := range sentEmails(t) {
+			if strings.Contains(email, "https://example.com/blog/newsletter-article") {
+				notification = email
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 50*time.Millisecond, "subscriber should be notified of the new article")
+
+	assert.Contains(t, notification, "List-Unsubscribe: <https://example.com/newsletter/unsubscribe?token=")
+
+	// Publishing again does not send a second notification
+	countBefore := len(sentEmails(t))
+	resp, err = NewRequestWithIfMatch(t, "PATCH", publishURL, suite.AuthToken, etag, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	time.Sleep(200 * time.Millisecond)
+	assert.Len(t, sentEmails(t), countBefore)
+
+	// The unsubscribe link removes the subscriber
+	match := newsletterTokenPattern.FindStringSubmatch(notification[strings.Index(notification, "unsubscribe?"):])
+	require.NotNil(t, match)
+	resp = postNewsletter(t, suite.ServerAddr, "unsubscribe", map[string]string{"token": match[1]})
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
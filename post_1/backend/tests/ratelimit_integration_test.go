@@ -1,8 +1,13 @@
 package tests
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"personal_website/config"
+	"personal_website/internal/app/core/domain"
+	valkey_adapter "personal_website/internal/infrastructure/adapters/repository/valkey"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -80,7 +85,7 @@ func TestRateLimiterTraefikHeaders(t *testing.T) {
 		{
 			name:        "X-Forwarded-For header",
 			headers:     map[string]string{"X-Forwarded-For": "203.0.113.1, 192.168.1.1"},
-			description: "Should use first IP from comma-separated list",
+			description: "Should use the rightmost untrusted IP from comma-separated list",
 		},
 		{
 			name:        "X-Real-IP header",
@@ -129,6 +134,31 @@ func TestRateLimiterTraefikHeaders(t *testing.T) {
 	}
 }
 
+// TestRateLimiterIgnoresSpoofedForwardedFor tests that a client cannot escape
+// its rate limit by prepending random addresses to X-Forwarded-For
+func TestRateLimiterIgnoresSpoofedForwardedFor(t *testing.T) {
+	ts := NewUnauthenticatedTestSuite(t)
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	rateLimitHit := false
+	for i := 0; i < 20; i++ {
+		req, err := http.NewRequest("GET", ts.ServerAddr+"/health", nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Forwarded-For", fmt.Sprintf("198.51.100.%d, 192.168.1.150", i))
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			rateLimitHit = true
+			break
+		}
+	}
+
+	assert.True(t, rateLimitHit, "Spoofed leftmost entries should not reset the rate limit")
+}
+
 // TestRateLimiterConcurrentRequests tests that multiple IPs can make concurrent requests
 // without interfering with each other's rate limits
 func TestRateLimiterConcurrentRequests(t *testing.T) {
@@ -188,4 +218,130 @@ func TestRateLimiterConcurrentRequests(t *testing.T) {
 type testResult struct {
 	IP           string
 	SuccessCount int
-}
\ No newline at end of file
+}
+// TestRateLimiterHeaders tests that the RateLimit and Retry-After headers are
+// sent with every response
+func TestRateLimiterHeaders(t *testing.T) {
+	ts := NewUnauthenticatedTestSuite(t)
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	var last *http.Response
+	for i := 0; i < 20; i++ {
+		req, err := http.NewRequest("GET", ts.ServerAddr+"/health", nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Forwarded-For", "198.51.100.7")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, "10", resp.Header.Get("RateLimit-Limit"))
+		assert.NotEmpty(t, resp.Header.Get("RateLimit-Remaining"))
+		assert.NotEmpty(t, resp.Header.Get("RateLimit-Reset"))
+
+		last = resp
+		if resp.StatusCode == http.StatusTooManyRequests {
+			break
+		}
+	}
+
+	require.Equal(t, http.StatusTooManyRequests, last.StatusCode)
+	assert.Equal(t, "0", last.Header.Get("RateLimit-Remaining"))
+	assert.Equal(t, "1", last.Header.Get("Retry-After"))
+}
+
+// TestValkeyRateLimiterSharedState tests that the Valkey limiter enforces one
+// limit for a key, whichever instance asks
+func TestValkeyRateLimiterSharedState(t *testing.T) {
+	ctx := context.Background()
+	limiter := datastore.RateLimiter()
+	limit := domain.RateLimit{Requests: 1, Period: time.Minute, Burst: 3}
+	key := fmt.Sprintf("test:%d", time.Now().UnixNano())
+
+	for i := 2; i >= 0; i-- {
+		result, err := limiter.Allow(ctx, key, limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, i, result.Remaining)
+	}
+
+	// A second client on the same Valkey sees the same bucket
+	otherInstance, err := valkey_adapter.NewDatabase(&testCfg.Valkey)
+	require.NoError(t, err)
+	defer otherInstance.Close()
+
+	result, err := otherInstance.RateLimiter().Allow(ctx, key, limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.InDelta(t, time.Minute.Seconds(), result.RetryAfter.Seconds(), 1)
+}
+
+// TestRateLimitPolicy_Login tests that the login policy is stricter than the
+// global limit and only applies to the login route
+func TestRateLimitPolicy_Login(t *testing.T) {
+	previous := testCfg.App.Limiter.Policies["login"]
+	testCfg.App.Limiter.Policies["login"] = config.RateLimitPolicy{Requests: 1, Period: time.Minute, Burst: 2}
+	t.Cleanup(func() { testCfg.App.Limiter.Policies["login"] = previous })
+
+	ts := NewUnauthenticatedTestSuite(t)
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	login := func() *http.Response {
+		req, err := http.NewRequest("POST", ts.ServerAddr+"/v1/auth/login", strings.NewReader(`{"email":"nobody@example.com","password":"WrongPassword1!"}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", "203.0.113.9")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	for i := 0; i < 2; i++ {
+		resp := login()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get("RateLimit-Limit"))
+	}
+
+	resp := login()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "60", resp.Header.Get("Retry-After"))
+
+	// Other routes only see the global limit
+	req, err := http.NewRequest("GET", ts.ServerAddr+"/v1/articles", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Forwarded-For", "203.0.113.9")
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestRateLimitPolicy_WriteIsCountedPerUser(t *testing.T) {
+	previous := testCfg.App.Limiter.Policies["write"]
+	testCfg.App.Limiter.Policies["write"] = config.RateLimitPolicy{Requests: 1, Period: time.Minute, Burst: 1}
+	t.Cleanup(func() { testCfg.App.Limiter.Policies["write"] = previous })
+
+	ts := NewTestSuite(t)
+	ctx := context.Background()
+
+	// A second login of the same user
+	session, err := datastore.SessionRepo().GetSession(ctx, ts.AuthToken, domain.ScopeAuthentication)
+	require.NoError(t, err)
+	second := domain.GenerateToken(session.UserID, domain.ScopeAuthentication)
+	require.NoError(t, datastore.SessionRepo().StoreSession(ctx, second.Plaintext, domain.ScopeAuthentication, session))
+
+	resp, err := ts.POST(t, "/v1/articles", ArticleData())
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	article := ArticleData()
+	article["slug"] = "second-login-article"
+	resp, err = (&TestSuite{ServerAddr: ts.ServerAddr, AuthToken: second.Plaintext}).POST(t, "/v1/articles", article)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "both logins share the bucket of the user")
+}
@@ -1,11 +1,16 @@
 package tests
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"personal_website/internal/app/core/domain"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -243,4 +248,251 @@ func TestResumeService_ConnectionCheck(t *testing.T) {
 	var domainErr domain.DomainError
 	assert.ErrorAs(t, err, &domainErr)
 	assert.Equal(t, "resume_storage_unavailable", domainErr.Code)
-}
\ No newline at end of file
+}
+func uploadResume(t *testing.T, serverAddr, authToken string, content []byte, fields map[string]string) *http.Response {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "My Resume 2025.pdf")
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	for name, value := range fields {
+		require.NoError(t, writer.WriteField(name, value))
+	}
+	require.NoError(t, writer.Close())
+
+	req, err := http.NewRequest("POST", serverAddr+"/v1/resume", &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+authToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestResumeUpload_BecomesCurrentVersion(t *testing.T) {
+	ts := NewTestSuite(t)
+	GetMockResumeService().Reset()
+
+	pdf := []byte("%PDF-1.7\nnew resume")
+	resp := uploadResume(t, ts.ServerAddr, ts.AuthToken, pdf, nil)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var uploadResp struct {
+		Data struct {
+			ID        int32  `json:"id"`
+			FileName  string `json:"file_name"`
+			IsCurrent bool   `json:"is_current"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&uploadResp))
+	assert.Equal(t, "My_Resume_2025.pdf", uploadResp.Data.FileName)
+	assert.True(t, uploadResp.Data.IsCurrent)
+
+	// The download serves the new version with its filename
+	downloadResp, err := http.Get(ts.ServerAddr + "/v1/resume")
+	require.NoError(t, err)
+	defer downloadResp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, downloadResp.StatusCode)
+	assert.Equal(t, "attachment; filename=\"My_Resume_2025.pdf\"", downloadResp.Header.Get("Content-Disposition"))
+	body, err := io.ReadAll(downloadResp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, pdf, body)
+}
+
+func TestResumeUpload_SlowBodyOutlastsReadTimeout(t *testing.T) {
+	ts := NewTestSuite(t)
+	GetMockResumeService().Reset()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "slow.pdf")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("%PDF-1.7\nslow upload"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	// The body is sent in two parts, further apart than the read timeout of
+	// the server
+	reader, pipe := io.Pipe()
+	go func() {
+		data := body.Bytes()
+		_, _ = pipe.Write(data[:len(data)/2])
+		time.Sleep(1500 * time.Millisecond)
+		_, _ = pipe.Write(data[len(data)/2:])
+		pipe.Close()
+	}()
+
+	req, err := http.NewRequest("POST", ts.ServerAddr+"/v1/resume", reader)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+ts.AuthToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Len(t, GetMockResumeService().Versions, 2)
+}
+
+func TestResumeVersions_ListAndSetCurrent(t *testing.T) {
+	ts := NewTestSuite(t)
+	GetMockResumeService().Reset()
+
+	resp := uploadResume(t, ts.ServerAddr, ts.AuthToken, []byte("%PDF-1.7\ndraft"), map[string]string{
+		"filename": "draft.pdf",
+		"current":  "false",
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, err := NewRequestWithAuthentication(t, "GET", ts.ServerAddr+"/v1/resume/versions", ts.AuthToken, nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var listResp struct {
+		Data []struct {
+			ID        int32  `json:"id"`
+			FileName  string `json:"file_name"`
+			IsCurrent bool   `json:"is_current"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&listResp))
+	require.Len(t, listResp.Data, 2)
+	assert.Equal(t, "draft.pdf", listResp.Data[0].FileName)
+	assert.False(t, listResp.Data[0].IsCurrent, "upload with current=false keeps the previous version")
+	assert.True(t, listResp.Data[1].IsCurrent)
+
+	url := fmt.Sprintf("%s/v1/resume/versions/%d/current", ts.ServerAddr, listResp.Data[0].ID)
+	resp, err = NewRequestWithAuthentication(t, "PATCH", url, ts.AuthToken, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	downloadResp, err := http.Get(ts.ServerAddr + "/v1/resume")
+	require.NoError(t, err)
+	defer downloadResp.Body.Close()
+	assert.Equal(t, "attachment; filename=\"draft.pdf\"", downloadResp.Header.Get("Content-Disposition"))
+
+	resp, err = NewRequestWithAuthentication(t, "PATCH", ts.ServerAddr+"/v1/resume/versions/999/current", ts.AuthToken, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestResumeUpload_NotPDF_ReturnsUnprocessableEntity(t *testing.T) {
+	ts := NewTestSuite(t)
+	GetMockResumeService().Reset()
+
+	resp := uploadResume(t, ts.ServerAddr, ts.AuthToken, []byte("<html>not a resume</html>"), nil)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Len(t, GetMockResumeService().Versions, 1)
+}
+
+func TestResumeUpload_RequiresAuthentication(t *testing.T) {
+	ts := NewUnauthenticatedTestSuite(t)
+
+	resp := uploadResume(t, ts.ServerAddr, "", []byte("%PDF-1.7\n"), nil)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestResumeDownload_Range_ReturnsPartialContent(t *testing.T) {
+	ts := NewUnauthenticatedTestSuite(t)
+	mockResume := GetMockResumeService()
+	mockResume.Reset()
+	mockResume.GetResumeData = []byte("0123456789")
+
+	req, err := http.NewRequest("GET", ts.ServerAddr+"/v1/resume", nil)
+	require.NoError(t, err)
+	req.Header.Set("Range", "bytes=2-5")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	assert.Equal(t, "bytes 2-5/10", resp.Header.Get("Content-Range"))
+	assert.Equal(t, "bytes", resp.Header.Get("Accept-Ranges"))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("2345"), body)
+
+	req.Header.Set("Range", "bytes=20-30")
+	unsatisfiable, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer unsatisfiable.Body.Close()
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, unsatisfiable.StatusCode)
+}
+
+func TestResumeDownload_ConditionalRequests_ReturnNotModified(t *testing.T) {
+	ts := NewUnauthenticatedTestSuite(t)
+	GetMockResumeService().Reset()
+
+	resp, err := http.Get(ts.ServerAddr + "/v1/resume")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, MockResumeLastModified.Format(http.TimeFormat), resp.Header.Get("Last-Modified"))
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		status int
+	}{
+		{name: "matching etag", header: "If-None-Match", value: etag, status: http.StatusNotModified},
+		{name: "stale etag", header: "If-None-Match", value: `"stale"`, status: http.StatusOK},
+		{name: "not modified since", header: "If-Modified-Since", value: MockResumeLastModified.Format(http.TimeFormat), status: http.StatusNotModified},
+		{name: "modified since", header: "If-Modified-Since", value: MockResumeLastModified.Add(-time.Hour).Format(http.TimeFormat), status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", ts.ServerAddr+"/v1/resume", nil)
+			require.NoError(t, err)
+			req.Header.Set(tt.header, tt.value)
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
+
+func TestResumeDownload_Head_ReturnsHeadersOnly(t *testing.T) {
+	ts := NewUnauthenticatedTestSuite(t)
+	mockResume := GetMockResumeService()
+	mockResume.Reset()
+	mockResume.GetResumeData = []byte("PDF content here")
+
+	resp, err := http.Head(ts.ServerAddr + "/v1/resume")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "16", resp.Header.Get("Content-Length"))
+	assert.Equal(t, "attachment; filename=\"jordan_delbar_resume.pdf\"", resp.Header.Get("Content-Disposition"))
+	assert.NotEmpty(t, resp.Header.Get("ETag"))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Empty(t, body)
+}
@@ -0,0 +1,182 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type seriesPartResponse struct {
+	Data struct {
+		Series *struct {
+			Slug     string `json:"slug"`
+			Position int    `json:"position"`
+			Total    int    `json:"total"`
+			Previous *struct {
+				Slug string `json:"slug"`
+			} `json:"previous"`
+			Next *struct {
+				Slug string `json:"slug"`
+			} `json:"next"`
+		} `json:"series"`
+	} `json:"data"`
+}
+
+// getSeriesPart reads the place of the published article in its series.
+func getSeriesPart(t *testing.T, suite *TestSuite, slug string) seriesPartResponse {
+	t.Helper()
+
+	resp := getWithoutRedirect(t, suite.ServerAddr+"/v1/articles/slug/"+slug)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var response seriesPartResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	return response
+}
+
+// setSeriesArticles replaces the articles of the series through the API.
+func setSeriesArticles(t *testing.T, suite *TestSuite, seriesID int32, articleIDs ...int32) *http.Response {
+	t.Helper()
+
+	jsonData, err := json.Marshal(map[string][]int32{"article_ids": articleIDs})
+	require.NoError(t, err)
+
+	url := fmt.Sprintf("%s/v1/series/id/%d/articles", suite.ServerAddr, seriesID)
+	resp, err := NewRequestWithAuthentication(t, "PUT", url, suite.AuthToken, jsonData)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestSeries_NavigationFollowsPublication(t *testing.T) {
+	suite := NewTestSuite(t)
+	first := publishArticleThroughAPI(t, suite, "series-part-one")
+	second := publishArticleThroughAPI(t, suite, "series-part-two")
+	third := publishArticleThroughAPI(t, suite, "series-part-three")
+
+	url := fmt.Sprintf("%s/v1/articles/id/%d/unpublish", suite.ServerAddr, second)
+	unpublishResp, err := NewRequestWithIfMatch(t, "PATCH", url, suite.AuthToken, "*", nil)
+	require.NoError(t, err)
+	unpublishResp.Body.Close()
+	require.Equal(t, http.StatusOK, unpublishResp.StatusCode)
+
+	// The article is cached before it joins the series
+	part := getSeriesPart(t, suite, "series-part-one")
+	assert.Nil(t, part.Data.Series)
+
+	resp, err := suite.POST(t, "/v1/series", map[string]any{
+		"title":       "Go tutorial",
+		"slug":        "go-tutorial",
+		"description": "A tutorial in three parts",
+		"article_ids": []int32{first, second, third},
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var created map[string]map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	seriesID := int32(created["data"]["id"].(float64))
+	assert.Len(t, created["data"]["articles"], 3, "the CMS sees the unpublished parts")
+
+	// The unpublished part is skipped
+	part = getSeriesPart(t, suite, "series-part-one")
+	require.NotNil(t, part.Data.Series)
+	assert.Equal(t, "go-tutorial", part.Data.Series.Slug)
+	assert.Equal(t, 1, part.Data.Series.Position)
+	assert.Equal(t, 2, part.Data.Series.Total)
+	assert.Nil(t, part.Data.Series.Previous)
+	require.NotNil(t, part.Data.Series.Next)
+	assert.Equal(t, "series-part-three", part.Data.Series.Next.Slug)
+
+	seriesResp := getWithoutRedirect(t, suite.ServerAddr+"/v1/series/go-tutorial")
+	require.Equal(t, http.StatusOK, seriesResp.StatusCode)
+	var series map[string]map[string]any
+	require.NoError(t, json.NewDecoder(seriesResp.Body).Decode(&series))
+	assert.Len(t, series["data"]["articles"], 2)
+
+	// Reordering changes the navigation
+	resp = setSeriesArticles(t, suite, seriesID, third, second, first)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	part = getSeriesPart(t, suite, "series-part-one")
+	require.NotNil(t, part.Data.Series)
+	assert.Equal(t, 2, part.Data.Series.Position)
+	require.NotNil(t, part.Data.Series.Previous)
+	assert.Equal(t, "series-part-three", part.Data.Series.Previous.Slug)
+	assert.Nil(t, part.Data.Series.Next)
+}
+
+func TestSeries_HiddenWithoutPublishedArticle(t *testing.T) {
+	suite := NewTestSuite(t)
+	articleID := publishArticleThroughAPI(t, suite, "lonely-part")
+
+	resp, err := suite.POST(t, "/v1/series", map[string]any{
+		"title":       "Lonely series",
+		"slug":        "lonely-series",
+		"article_ids": []int32{articleID},
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	listResp := getWithoutRedirect(t, suite.ServerAddr+"/v1/series")
+	require.Equal(t, http.StatusOK, listResp.StatusCode)
+	var list map[string][]map[string]any
+	require.NoError(t, json.NewDecoder(listResp.Body).Decode(&list))
+	require.Len(t, list["data"], 1)
+
+	url := fmt.Sprintf("%s/v1/articles/id/%d/unpublish", suite.ServerAddr, articleID)
+	unpublishResp, err := NewRequestWithIfMatch(t, "PATCH", url, suite.AuthToken, "*", nil)
+	require.NoError(t, err)
+	unpublishResp.Body.Close()
+	require.Equal(t, http.StatusOK, unpublishResp.StatusCode)
+
+	seriesResp := getWithoutRedirect(t, suite.ServerAddr+"/v1/series/lonely-series")
+	assert.Equal(t, http.StatusNotFound, seriesResp.StatusCode)
+
+	listResp = getWithoutRedirect(t, suite.ServerAddr+"/v1/series")
+	require.Equal(t, http.StatusOK, listResp.StatusCode)
+	list = nil
+	require.NoError(t, json.NewDecoder(listResp.Body).Decode(&list))
+	assert.Empty(t, list["data"])
+}
+
+func TestSeries_ArticleInASingleSeries(t *testing.T) {
+	suite := NewTestSuite(t)
+	articleID := publishArticleThroughAPI(t, suite, "shared-part")
+
+	resp, err := suite.POST(t, "/v1/series", map[string]any{
+		"title":       "First series",
+		"slug":        "first-series",
+		"article_ids": []int32{articleID},
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, err = suite.POST(t, "/v1/series", map[string]any{
+		"title":       "Second series",
+		"slug":        "second-series",
+		"article_ids": []int32{articleID},
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, err = suite.POST(t, "/v1/series", map[string]any{
+		"title":       "Duplicated parts",
+		"slug":        "duplicated-parts",
+		"article_ids": []int32{articleID, articleID},
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = setSeriesArticles(t, suite, 4242, articleID)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const syncedContent = "This is the content of a synced article, long enough to pass the validation."
+
+func syncContent(t *testing.T, ts *TestSuite, query string) map[string]any {
+	t.Helper()
+
+	resp, err := NewRequestWithAuthentication(t, "POST", ts.ServerAddr+"/v1/sync"+query, ts.AuthToken, nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	return decodeDataResponse(t, resp)
+}
+
+func TestSync_AppliesRepositoryChanges(t *testing.T) {
+	ts := NewTestSuite(t)
+	source := GetMockContentSource()
+
+	source.SetFiles(t, "c1", map[string]string{
+		"first.md":  "---\ntitle: First Synced Article\n---\n" + syncedContent + "\n",
+		"second.md": "---\ntitle: Second Synced Article\n---\n" + syncedContent + "\n",
+	})
+
+	dryRun := syncContent(t, ts, "?dry_run=true")
+	assert.Equal(t, float64(2), dryRun["created"])
+	var count int
+	require.NoError(t, db.QueryRow("SELECT count(*) FROM content.articles").Scan(&count))
+	assert.Zero(t, count, "a dry run changes nothing")
+
+	data := syncContent(t, ts, "")
+	assert.Equal(t, "c1", data["commit"])
+	assert.Equal(t, float64(2), data["created"])
+
+	source.SetFiles(t, "c2", map[string]string{
+		"first.md": "---\ntitle: First Synced Article Edited\n---\n" + syncedContent + "\n",
+	})
+
+	data = syncContent(t, ts, "")
+	assert.Equal(t, float64(1), data["updated"])
+	assert.Equal(t, float64(1), data["deleted"])
+
+	var title, commit string
+	err := db.QueryRow(`
+		SELECT a.title, s.commit_sha
+		FROM content.articles a JOIN content.article_sources s ON s.article_id = a.id
+		WHERE a.slug = 'first'`).Scan(&title, &commit)
+	require.NoError(t, err)
+	assert.Equal(t, "First Synced Article Edited", title)
+	assert.Equal(t, "c2", commit)
+
+	var isDeleted bool
+	require.NoError(t, db.QueryRow("SELECT is_deleted FROM content.articles WHERE slug = 'second'").Scan(&isDeleted))
+	assert.True(t, isDeleted)
+
+	data = syncContent(t, ts, "")
+	assert.Equal(t, float64(1), data["unchanged"])
+	assert.Empty(t, data["changes"])
+}
+
+func TestSync_SlugChangeRenamesArticle(t *testing.T) {
+	ts := NewTestSuite(t)
+	source := GetMockContentSource()
+
+	source.SetFiles(t, "c1", map[string]string{
+		"post.md": "---\ntitle: Synced Article\nslug: old-slug\n---\n" + syncedContent + "\n",
+	})
+	syncContent(t, ts, "")
+
+	var id int32
+	require.NoError(t, db.QueryRow("SELECT id FROM content.articles WHERE slug = 'old-slug'").Scan(&id))
+
+	source.SetFiles(t, "c2", map[string]string{
+		"post.md": "---\ntitle: Synced Article\nslug: new-slug\n---\n" + syncedContent + "\n",
+	})
+	data := syncContent(t, ts, "")
+	assert.Equal(t, float64(1), data["updated"])
+	assert.Equal(t, float64(0), data["deleted"])
+
+	var renamedID int32
+	require.NoError(t, db.QueryRow("SELECT id FROM content.articles WHERE slug = 'new-slug' AND NOT is_deleted").Scan(&renamedID))
+	assert.Equal(t, id, renamedID, "the article keeps its ID")
+
+	var count int
+	require.NoError(t, db.QueryRow("SELECT count(*) FROM content.articles").Scan(&count))
+	assert.Equal(t, 1, count)
+
+	var retiredID int32
+	require.NoError(t, db.QueryRow("SELECT article_id FROM content.article_slugs WHERE slug = 'old-slug'").Scan(&retiredID))
+	assert.Equal(t, id, retiredID, "the former slug redirects to the article")
+}
+
+func TestSync_InvalidDryRun(t *testing.T) {
+	ts := NewTestSuite(t)
+
+	resp, err := NewRequestWithAuthentication(t, "POST", ts.ServerAddr+"/v1/sync?dry_run=maybe", ts.AuthToken, nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -32,6 +32,39 @@ func TestCreateUser(t *testing.T) {
 	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
 }
 
+func TestCreateUser_LanguageDefaultsToConfiguredLocale(t *testing.T) {
+	previous := testCfg.SMTP.DefaultLocale
+	testCfg.SMTP.DefaultLocale = "fr"
+	t.Cleanup(func() { testCfg.SMTP.DefaultLocale = previous })
+
+	setupTestDB(t)
+	t.Cleanup(func() { cleanupDB(t) })
+	serverAddr := startTestServer(t)
+
+	register := func(email string, acceptLanguage string) string {
+		jsonData, err := json.Marshal(map[string]string{"name": "John Doe", "email": email, "password": "Pa55word!"})
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("POST", serverAddr+"/v1/users", bytes.NewBuffer(jsonData))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+		var language string
+		require.NoError(t, db.QueryRow("SELECT language FROM app.users WHERE email = $1", email).Scan(&language))
+		return language
+	}
+
+	assert.Equal(t, "fr", register("no.header@email.com", ""))
+	assert.Equal(t, "de", register("german@email.com", "de;q=0.9, en;q=0.5"))
+}
+
 func TestCreateUser_DuplicateEmail_ReturnsConflict(t *testing.T) {
 	setupTestDB(t)
 	t.Cleanup(func() { cleanupDB(t) })